@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/google/subcommands"
+	"os"
+	"text/tabwriter"
+)
+
+// configCmd groups the subcommands about the settings
+type configCmd struct {
+	settings config.Settings
+	sources  config.Sources
+}
+
+func (*configCmd) Name() string     { return "config" }
+func (*configCmd) Synopsis() string { return "show the effective settings" }
+func (*configCmd) Usage() string {
+	return `config print:
+	prints the effective settings, with secrets redacted, and where each came from.
+  `
+}
+
+func (*configCmd) SetFlags(*flag.FlagSet) {}
+
+func (p *configCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	commander := subcommands.NewCommander(f, "config")
+	commander.Register(commander.HelpCommand(), "")
+	commander.Register(&configPrintCmd{settings: p.settings, sources: p.sources}, "")
+
+	return commander.Execute(ctx)
+}
+
+type configPrintCmd struct {
+	settings config.Settings
+	sources  config.Sources
+
+	onlySet bool
+}
+
+func (*configPrintCmd) Name() string     { return "print" }
+func (*configPrintCmd) Synopsis() string { return "print the effective settings with their sources" }
+func (*configPrintCmd) Usage() string {
+	return `print [-set]:
+	prints every setting with its value and source: settings.yaml, env, file:<path> for _FILE env vars, or default.
+	Secrets are redacted.
+  `
+}
+
+func (p *configPrintCmd) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&p.onlySet, "set", false, "only print settings that aren't defaults")
+}
+
+func (p *configPrintCmd) Execute(context.Context, *flag.FlagSet, ...interface{}) subcommands.ExitStatus {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+	for _, entry := range p.settings.Entries(p.sources) {
+		if p.onlySet && entry.Source == config.SourceDefault {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Key, entry.Value, entry.Source)
+	}
+	_ = w.Flush()
+
+	return subcommands.ExitSuccess
+}
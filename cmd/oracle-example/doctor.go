@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/DIMO-Network/oracle-example/internal/health"
+	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+	"math/big"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+const doctorCheckTimeout = 15 * time.Second
+
+// doctorWarning is a problem that doesn't stop the oracle from running, eg. a certificate expiring soon
+type doctorWarning struct {
+	msg string
+}
+
+func (w doctorWarning) Error() string { return w.msg }
+
+// doctorCmd validates the settings and checks them against every dependency
+type doctorCmd struct {
+	logger   zerolog.Logger
+	settings config.Settings
+	pdb      db.Store
+
+	mode string
+}
+
+func (*doctorCmd) Name() string     { return "doctor" }
+func (*doctorCmd) Synopsis() string { return "validate the settings and check the dependencies" }
+func (*doctorCmd) Usage() string {
+	return `doctor [-mode all]:
+	validates the settings of the mode, then connects to the database, RPC, DIS and DIMO Auth to check the chain ID,
+	the registry and NFT contracts, the developer AA wallet balance, the DIS client certificate and the DIMO Auth login.
+	The chain checks run for every chain profile.
+  `
+}
+
+func (p *doctorCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.mode, "mode", p.settings.Mode, "process mode whose settings are validated, defaults to the MODE setting")
+}
+
+func (p *doctorCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	settings := p.settings
+	settings.Mode = p.mode
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	failed := false
+	report := func(name, details string, err error) {
+		var warning doctorWarning
+		switch {
+		case errors.As(err, &warning):
+			fmt.Fprintf(w, "WARN\t%s\t%s\n", name, warning.msg)
+		case err != nil:
+			failed = true
+			fmt.Fprintf(w, "FAIL\t%s\t%s\n", name, err)
+		default:
+			fmt.Fprintf(w, "OK\t%s\t%s\n", name, details)
+		}
+	}
+
+	var validationErr *config.ValidationError
+	if err := settings.Validate(); errors.As(err, &validationErr) {
+		for _, problem := range validationErr.Problems {
+			report("settings", "", errors.New(problem))
+		}
+	} else {
+		report("settings", "valid for the mode", err)
+	}
+
+	type check struct {
+		name string
+		fn   health.CheckFunc
+	}
+	checks := []check{
+		{"database", databaseCheck(&p.pdb)},
+	}
+
+	// the chain checks run for every chain profile, the default one being the chain settings
+	profiles, err := settings.ChainProfiles()
+	if err != nil {
+		report("chainProfiles", "", err)
+		profiles = config.ChainProfiles{settings.DefaultChainProfile()}
+	}
+	for _, profile := range profiles {
+		var ec *ethclient.Client
+		rpcClient, err := rpc.DialContext(ctx, profile.RPCURL.String())
+		if err == nil {
+			defer rpcClient.Close()
+			ec = ethclient.NewClient(rpcClient)
+		}
+		rpcCheck := func(check func(context.Context, *ethclient.Client) (string, error)) health.CheckFunc {
+			return func(ctx context.Context) (string, error) {
+				if ec == nil {
+					return "", fmt.Errorf("failed to connect to RPC_URL: %w", err)
+				}
+				return check(ctx, ec)
+			}
+		}
+
+		checks = append(checks,
+			check{chainCheckName("rpc", profile), rpcCheck(func(ctx context.Context, _ *ethclient.Client) (string, error) {
+				return health.ChainIDCheck(rpcClient, profile.ChainID)(ctx)
+			})},
+			check{chainCheckName("registry", profile), rpcCheck(contractCheck(profile.RegistryAddress))},
+			check{chainCheckName("vehicleNft", profile), rpcCheck(contractCheck(profile.VehicleNftAddress))},
+			check{chainCheckName("syntheticNft", profile), rpcCheck(contractCheck(profile.SyntheticNftAddress))},
+			check{chainCheckName("aaWallet", profile), rpcCheck(walletCheck(settings.DeveloperAAWalletAddress))},
+		)
+	}
+
+	checks = append(checks,
+		check{"disCertificate", certificateCheck(settings.Cert)},
+		check{"dimoAuth", dimoAuthCheck(p.logger, settings)},
+	)
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, doctorCheckTimeout)
+		details, err := check.fn(checkCtx)
+		cancel()
+		report(check.name, details, err)
+	}
+	_ = w.Flush()
+
+	if failed {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+// databaseCheck waits for the connections, which main doesn't wait for before the doctor command, and pings them
+func databaseCheck(dbs *db.Store) health.CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		for !dbs.IsReady() {
+			select {
+			case <-ctx.Done():
+				return "", errors.New("failed to connect to postgres")
+			case <-time.After(time.Second):
+			}
+		}
+		return health.DBCheck(dbs)(ctx)
+	}
+}
+
+// contractCheck checks there is a contract deployed at the address, so it's not a wallet or an address of another chain
+func contractCheck(address common.Address) func(context.Context, *ethclient.Client) (string, error) {
+	return func(ctx context.Context, ec *ethclient.Client) (string, error) {
+		code, err := ec.CodeAt(ctx, address, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to get code at %s: %w", address.Hex(), err)
+		}
+		if len(code) == 0 {
+			return "", fmt.Errorf("no contract at %s on this chain", address.Hex())
+		}
+		return fmt.Sprintf("contract at %s", address.Hex()), nil
+	}
+}
+
+// walletCheck reports the balance of the developer AA wallet. User operations are usually sponsored by the
+// paymaster, so an empty wallet is only a warning.
+func walletCheck(address common.Address) func(context.Context, *ethclient.Client) (string, error) {
+	return func(ctx context.Context, ec *ethclient.Client) (string, error) {
+		balance, err := ec.BalanceAt(ctx, address, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to get balance of %s: %w", address.Hex(), err)
+		}
+
+		code, err := ec.CodeAt(ctx, address, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to get code at %s: %w", address.Hex(), err)
+		}
+		deployed := "deployed"
+		if len(code) == 0 {
+			// smart accounts are deployed by their first user operation
+			deployed = "not deployed yet"
+		}
+
+		ether := new(big.Float).Quo(new(big.Float).SetInt(balance), big.NewFloat(params.Ether))
+		details := fmt.Sprintf("%s %s, balance %s", address.Hex(), deployed, ether.Text('f', 6))
+		if balance.Sign() == 0 {
+			return "", doctorWarning{msg: details + ", relies on the paymaster for gas"}
+		}
+		return details, nil
+	}
+}
+
+// certificateCheck checks the DIS client certificate is valid, with a warning a month before it expires
+func certificateCheck(certPEM string) health.CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		details, err := health.CertificateCheck(certPEM)(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		expiry, err := health.CertificateExpiry(certPEM)
+		if err == nil && time.Until(expiry) < 30*24*time.Hour {
+			return "", doctorWarning{msg: details + ", renew it with DIMO"}
+		}
+		return details, nil
+	}
+}
+
+// dimoAuthCheck logs in to DIMO Auth with the client ID and private key
+func dimoAuthCheck(logger zerolog.Logger, settings config.Settings) health.CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		auth, err := service.NewDimoAuthService(logger, settings)
+		if err != nil {
+			return "", fmt.Errorf("failed to load DIMO_AUTH_PRIVATE_KEY: %w", err)
+		}
+		return auth.Check(ctx)
+	}
+}
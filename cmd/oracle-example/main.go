@@ -4,15 +4,11 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"github.com/DIMO-Network/go-transactions"
-	"github.com/DIMO-Network/oracle-example/internal/app"
 	"github.com/DIMO-Network/oracle-example/internal/config"
-	"github.com/DIMO-Network/oracle-example/internal/kafka"
-	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/oracle-example/internal/health"
 	"github.com/DIMO-Network/oracle-example/internal/onboarding"
 	"github.com/DIMO-Network/oracle-example/internal/service"
 	"github.com/DIMO-Network/shared/pkg/db"
-	ssetings "github.com/DIMO-Network/shared/pkg/settings"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/google/subcommands"
@@ -21,37 +17,45 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/riverqueue/river"
 	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"github.com/riverqueue/river/rivertype"
 	"github.com/rs/zerolog"
 	"golang.org/x/sync/errgroup"
 	"os"
 	"os/signal"
-	"strings"
 )
 
 func main() {
-	logger := zerolog.New(os.Stdout).Level(zerolog.InfoLevel).With().
+	// the level is only set globally, so a reloaded LOG_LEVEL applies to every logger
+	logger := zerolog.New(os.Stdout).With().
 		Timestamp().
 		Str("app", "oracle-example").
 		Logger()
 
-	settings, err := ssetings.LoadConfig[config.Settings]("settings.yaml")
+	settings, sources, err := config.Load("settings.yaml")
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to load settings")
 	}
 
-	logLevel, err := zerolog.ParseLevel(settings.LogLevel)
-	if err != nil {
+	if _, err := zerolog.ParseLevel(settings.LogLevel); err != nil {
 		logger.Fatal().Err(err).Msg("Couldn't parse log level setting.")
 	}
-	zerolog.SetGlobalLevel(logLevel)
-	logger = logger.Level(logLevel)
+	provider := config.NewProvider("settings.yaml", settings, logger)
+	provider.Subscribe(func(settings *config.Settings) {
+		// the provider only swaps in valid levels
+		logLevel, _ := zerolog.ParseLevel(settings.LogLevel)
+		zerolog.SetGlobalLevel(logLevel)
+	})
+	logger.Debug().Object("settings", &settings).Msg("Loaded settings")
 
 	// new context that cancels on program interrupt
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 	// connect to DB and make sure it is available in reasonable time
 	pdb := db.NewDbConnectionFromSettings(ctx, &settings.DB, true)
-	pdb.WaitForDB(logger)
+	// the doctor reports the database as one of the dependencies instead of exiting, config doesn't need it
+	if len(os.Args) < 2 || (os.Args[1] != "doctor" && os.Args[1] != "config") {
+		pdb.WaitForDB(logger)
+	}
 
 	// CLI commands
 	subcommands.Register(subcommands.HelpCommand(), "")
@@ -60,87 +64,20 @@ func main() {
 	if len(os.Args) > 1 {
 		// CLI only mode
 		subcommands.Register(&migrateDBCmd{logger: logger, settings: settings, pdb: pdb}, "database")
+		subcommands.Register(&vinCmd{logger: logger, settings: settings, pdb: pdb}, "operations")
+		subcommands.Register(&doctorCmd{logger: logger, settings: settings, pdb: pdb}, "operations")
+		subcommands.Register(&configCmd{settings: settings, sources: sources}, "operations")
+		for _, mode := range config.Modes {
+			subcommands.Register(&serveCmd{logger: logger, settings: settings, provider: provider, pdb: pdb, mode: mode}, "server")
+		}
 
 		flag.Parse()
 		os.Exit(int(subcommands.Execute(ctx)))
 	}
 
-	transactionsClient, err := onboarding.NewTransactionsClient(&settings)
-	if err != nil {
-		logger.Fatal().Err(err).Msg("failed to create transactions client")
-	}
-
-	walletService := service.NewSDWalletsService(ctx, logger, settings)
-	if walletService == nil {
-		logger.Fatal().Err(err).Msg("Failed to create SD Wallets service")
-	}
-
-	monApp := createMonitoringServer()
-	group, gCtx := errgroup.WithContext(ctx)
-	vehicleService := service.NewVehicleService(&pdb, &logger)
-	accessService := service.NewAccessService(&pdb, &logger)
-	identityService := service.NewIdentityAPIService(logger, settings)
-	deviceDefinitionsService := service.NewDeviceDefinitionsAPIService(logger, settings)
-	oracleService, err := service.NewOracleService(ctx, logger, settings, vehicleService)
-	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to create Oracle service")
+	if status := serve(ctx, logger, settings, provider, pdb, settings.Mode); status != subcommands.ExitSuccess {
+		os.Exit(int(status))
 	}
-
-	enrollmentChannel := make(chan models.OperationMessage, 100)
-	vendorOnboardingService := onboarding.NewExternalOnboardingService(&settings, vehicleService, &logger, enrollmentChannel)
-
-	riverClient, _, dbPool, err := createRiverClientWithWorkersAndPool(gCtx, logger, &settings, identityService, deviceDefinitionsService, oracleService, &pdb, transactionsClient, walletService, vendorOnboardingService)
-	if err != nil {
-		logger.Fatal().Err(err).Msg("failed to create river client, workers and db pool")
-	}
-	defer dbPool.Close()
-
-	runRiver(gCtx, logger, riverClient, group)
-
-	webAPI := app.App(&settings, &logger, vehicleService, riverClient, walletService, transactionsClient, accessService)
-
-	// start the Web Api
-	logger.Info().Str("port", settings.MonitoringPort).Msgf("Starting monitoring server %s", settings.MonitoringPort)
-	runFiber(gCtx, monApp, ":"+settings.MonitoringPort, group)
-	logger.Info().Str("port", settings.Port).Msgf("Starting web server %s", settings.Port)
-	runFiber(gCtx, webAPI, ":"+settings.Port, group)
-
-	kafkaBrokers := strings.Split(settings.KafkaBrokers, ",")
-
-	if settings.IsTelemetryConsumerEnabled {
-		// Setup consumer for UnbufferedTelemetryTopic
-		err = kafka.SetupKafkaConsumer(
-			ctx,
-			&logger,
-			kafkaBrokers,
-			settings.UnbufferedTelemetryTopic,
-			settings.UnbufferedTelemetryConsumerGroup,
-			kafka.MessageHandlerUnbuffered{Logger: &logger, OracleService: oracleService},
-		)
-		if err != nil {
-			logger.Fatal().Err(err).Msg("Failed to setup consumer for UnbufferedTelemetryTopic")
-		}
-	}
-
-	if settings.IsOperationsConsumerEnabled {
-		// Setup consumer for OperationsTopic
-		err = kafka.SetupKafkaConsumer(
-			ctx,
-			&logger,
-			kafkaBrokers,
-			settings.OperationsTopic,
-			settings.OperationsConsumerGroup,
-			kafka.MessageHandlerOperations{Logger: &logger, OracleService: oracleService, EnrollmentChannel: enrollmentChannel},
-		)
-		if err != nil {
-			logger.Fatal().Err(err).Msg("Failed to setup consumer for OperationsTopic")
-		}
-	}
-
-	if err = group.Wait(); err != nil {
-		logger.Fatal().Err(err).Msg("Server failed.")
-	}
-	logger.Info().Msg("Server stopped.")
 }
 
 func runFiber(ctx context.Context, fiberApp *fiber.App, addr string, group *errgroup.Group) {
@@ -159,23 +96,30 @@ func runFiber(ctx context.Context, fiberApp *fiber.App, addr string, group *errg
 	})
 }
 
-// createMonitoringServer meant for prometheus / openmetrics scraping.
-func createMonitoringServer() *fiber.App {
+// createMonitoringServer meant for prometheus / openmetrics scraping and the liveness and readiness probes.
+func createMonitoringServer(checker *health.Checker) *fiber.App {
 	monApp := fiber.New(fiber.Config{DisableStartupMessage: true})
 
 	monApp.Get("/", func(_ *fiber.Ctx) error { return nil })
 	monApp.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+	monApp.Get("/health/live", checker.LiveHandler)
+	monApp.Get("/health/ready", checker.ReadyHandler)
 
 	return monApp
 }
 
 // createRiverClientWithWorkersAndPool we use the river job client to orchestrate onboarding steps for a VIN
-func createRiverClientWithWorkersAndPool(ctx context.Context, logger zerolog.Logger, settings *config.Settings, identityService service.IdentityAPI, dd service.DeviceDefinitionsAPI, os *service.OracleService, dbs *db.Store, tr *transactions.Client, ws service.SDWalletsAPI, onboardingService onboarding.VendorOnboardingAPI) (*river.Client[pgx.Tx], *river.Workers, *pgxpool.Pool, error) {
+func createRiverClientWithWorkersAndPool(ctx context.Context, logger zerolog.Logger, settings *config.Settings, identityService service.IdentityAPI, dd service.DeviceDefinitionsAPI, os *service.OracleService, dbs *db.Store, chains *onboarding.Chains, ws service.SDWalletsAPI, onboardingService onboarding.VendorOnboardingAPI, switches *onboarding.VendorSwitches) (*river.Client[pgx.Tx], *river.Workers, *pgxpool.Pool, error) {
 	workers := river.NewWorkers()
-	verifyWorker := onboarding.NewVerifyWorker(settings, logger, identityService, dd, os, dbs, onboardingService)
-	onboardingWorker := onboarding.NewOnboardingWorker(settings, logger, identityService, dbs, tr, ws, onboardingService)
-	disconnectWorker := onboarding.NewDisconnectWorker(settings, logger, identityService, dbs, tr, ws, onboardingService)
-	deleteWorker := onboarding.NewDeleteWorker(settings, logger, identityService, dbs, tr, ws, onboardingService)
+	verifyWorker := onboarding.NewVerifyWorker(settings, logger, identityService, dd, os, dbs, onboardingService, switches)
+	onboardingWorker := onboarding.NewOnboardingWorker(settings, logger, identityService, dbs, chains, ws, onboardingService, switches)
+	disconnectWorker := onboarding.NewDisconnectWorker(settings, logger, identityService, dbs, chains, ws, onboardingService, switches)
+	deleteWorker := onboarding.NewDeleteWorker(settings, logger, identityService, dbs, chains, ws, onboardingService)
+	sacdWorker := onboarding.NewSacdWorker(settings, logger, dbs, chains)
+	pauseWorker := onboarding.NewPauseWorker(settings, logger, dbs, onboardingService, switches)
+	transferHandler := onboarding.NewTransferHandler(settings, logger, dbs, onboardingService, switches)
+	reconcileWorker := onboarding.NewReconcileWorker(settings, logger, identityService, dbs, transferHandler)
+	stuckJobWorker := onboarding.NewStuckJobWorker(settings, logger, identityService, dbs)
 
 	err := river.AddWorkerSafely(workers, verifyWorker)
 	if err != nil {
@@ -205,6 +149,56 @@ func createRiverClientWithWorkersAndPool(ctx context.Context, logger zerolog.Log
 	}
 	logger.Debug().Msg("Added delete worker")
 
+	err = river.AddWorkerSafely(workers, sacdWorker)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to add sacd worker")
+		return nil, nil, nil, err
+	}
+	logger.Debug().Msg("Added sacd worker")
+
+	err = river.AddWorkerSafely(workers, pauseWorker)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to add pause worker")
+		return nil, nil, nil, err
+	}
+	logger.Debug().Msg("Added pause worker")
+
+	err = river.AddWorkerSafely(workers, reconcileWorker)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to add reconcile worker")
+		return nil, nil, nil, err
+	}
+	logger.Debug().Msg("Added reconcile worker")
+
+	err = river.AddWorkerSafely(workers, stuckJobWorker)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to add stuck job worker")
+		return nil, nil, nil, err
+	}
+	logger.Debug().Msg("Added stuck job worker")
+
+	var periodicJobs []*river.PeriodicJob
+	if settings.EnableReconciliation {
+		periodicJobs = append(periodicJobs, river.NewPeriodicJob(
+			river.PeriodicInterval(onboarding.ReconciliationInterval(settings)),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return onboarding.ReconcileArgs{}, nil
+			},
+			&river.PeriodicJobOpts{RunOnStart: true},
+		))
+		logger.Debug().Msg("Scheduled periodic reconciliation")
+	}
+	if settings.EnableStuckJobRecovery {
+		periodicJobs = append(periodicJobs, river.NewPeriodicJob(
+			river.PeriodicInterval(onboarding.StuckJobInterval(settings)),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return onboarding.RecoverStuckArgs{}, nil
+			},
+			&river.PeriodicJobOpts{RunOnStart: true},
+		))
+		logger.Debug().Msg("Scheduled periodic stuck job recovery")
+	}
+
 	dbURL := settings.DB.BuildConnectionString(true)
 	dbPool, err := pgxpool.New(ctx, dbURL)
 	if err != nil {
@@ -215,10 +209,10 @@ func createRiverClientWithWorkersAndPool(ctx context.Context, logger zerolog.Log
 	logger.Debug().Msg("DB pool for workers created")
 
 	riverClient, err := river.NewClient(riverpgxv5.New(dbPool), &river.Config{
-		Queues: map[string]river.QueueConfig{
-			river.QueueDefault: {MaxWorkers: 100},
-		},
-		Workers: workers,
+		Queues:       onboarding.RiverQueues(settings),
+		Middleware:   []rivertype.Middleware{onboarding.NewQueueMiddleware(settings)},
+		Workers:      workers,
+		PeriodicJobs: periodicJobs,
 	})
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to create river client")
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"github.com/DIMO-Network/oracle-example/internal/app"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/DIMO-Network/oracle-example/internal/health"
+	"github.com/DIMO-Network/oracle-example/internal/kafka"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/oracle-example/internal/onboarding"
+	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/google/subcommands"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+	"slices"
+	"strings"
+)
+
+// modeSynopses describe the process modes, every mode serves the monitoring server
+var modeSynopses = map[string]string{
+	config.ModeAll:               "run the API, the workers and the enabled Kafka consumers in one process",
+	config.ModeServeAPI:          "serve the web API, jobs are only inserted",
+	config.ModeRunWorkers:        "work river jobs",
+	config.ModeConsumeTelemetry:  "consume the unbuffered telemetry topic",
+	config.ModeConsumeOperations: "consume the operations topic",
+}
+
+// serveCmd runs the oracle in one of the process modes
+type serveCmd struct {
+	logger   zerolog.Logger
+	settings config.Settings
+	provider *config.Provider
+	pdb      db.Store
+	mode     string
+}
+
+func (p *serveCmd) Name() string     { return p.mode }
+func (p *serveCmd) Synopsis() string { return modeSynopses[p.mode] }
+func (p *serveCmd) Usage() string {
+	return p.mode + `:
+	` + modeSynopses[p.mode] + `. Without a command the oracle runs in the MODE setting, all by default.
+  `
+}
+
+func (*serveCmd) SetFlags(*flag.FlagSet) {}
+
+func (p *serveCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	return serve(ctx, p.logger, p.settings, p.provider, p.pdb, p.mode)
+}
+
+// serve constructs the dependencies of the mode's components and runs them until ctx is done. Components read the
+// reloadable settings through the provider, the others from settings.
+func serve(ctx context.Context, logger zerolog.Logger, settings config.Settings, provider *config.Provider, pdb db.Store, mode string) subcommands.ExitStatus {
+	if mode == "" {
+		mode = config.ModeAll
+	}
+	if !slices.Contains(config.Modes, mode) {
+		logger.Error().Str("mode", mode).Msgf("Unknown mode, expected one of %s", strings.Join(config.Modes, ", "))
+		return subcommands.ExitUsageError
+	}
+	logger = logger.With().Str("mode", mode).Logger()
+
+	// fail on every bad setting the mode needs now, rather than one at a time at runtime
+	settings.Mode = mode
+	if err := settings.Validate(); err != nil {
+		var validationErr *config.ValidationError
+		if errors.As(err, &validationErr) {
+			logger.Error().Strs("problems", validationErr.Problems).Msg("Invalid settings, run the doctor command to check the dependencies too")
+		} else {
+			logger.Error().Err(err).Msg("Invalid settings")
+		}
+		return subcommands.ExitFailure
+	}
+
+	runAPI := mode == config.ModeAll || mode == config.ModeServeAPI
+	runWorkers := mode == config.ModeAll || mode == config.ModeRunWorkers
+	// all only runs the consumers enabled in settings, dedicated modes always run theirs
+	consumeTelemetry := mode == config.ModeConsumeTelemetry || (mode == config.ModeAll && settings.IsTelemetryConsumerEnabled)
+	consumeOperations := mode == config.ModeConsumeOperations || (mode == config.ModeAll && settings.IsOperationsConsumerEnabled)
+
+	// readiness only checks the dependencies of the mode's components
+	checker := health.NewChecker(&settings)
+	checker.Register("database", health.DBCheck(&pdb))
+
+	monApp := createMonitoringServer(checker)
+	group, gCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		if err := provider.Watch(gCtx); err != nil {
+			// the oracle keeps running with the settings it started with
+			logger.Error().Err(err).Msg("Failed to watch settings, reloading is disabled")
+		}
+		return nil
+	})
+	vehicleService := service.NewVehicleService(&pdb, &logger, &settings)
+	// one Identity API client for the API, workers and oracle, so they share caches
+	identityService := service.NewDeviceDefinitionCache(gCtx, logger, settings, &pdb, service.NewIdentityAPIService(logger, settings))
+
+	var oracleService *service.OracleService
+	if runWorkers || consumeTelemetry || consumeOperations {
+		var err error
+		oracleService, err = service.NewOracleService(ctx, logger, settings, vehicleService, identityService)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to create Oracle service")
+		}
+		// the oracle sends telemetry to DIS with the client certificate
+		checker.Register("disCertificate", health.CertificateCheck(settings.Cert))
+	}
+
+	enrollmentChannel := make(chan models.OperationMessage, 100)
+
+	if runAPI || runWorkers {
+		// a transactions client per chain profile, VINs are minted on the chain of their record
+		profiles, err := settings.ChainProfiles()
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to load chain profiles")
+		}
+		chains, err := onboarding.NewChainsFromProfiles(&settings, profiles)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to create transactions clients")
+		}
+		for _, profile := range profiles {
+			transactionsClient, _ := chains.Client(profile.ChainID)
+			checker.Register(chainCheckName("rpc", profile), health.ChainIDCheck(transactionsClient.ZerodevClient.RpcClients.Network, profile.ChainID))
+		}
+
+		walletService := service.NewSDWalletsService(ctx, logger, settings)
+		if walletService == nil {
+			logger.Fatal().Err(err).Msg("Failed to create SD Wallets service")
+		}
+
+		var riverClient *river.Client[pgx.Tx]
+		var dbPool *pgxpool.Pool
+		if runWorkers {
+			deviceDefinitionsAPI := service.NewDeviceDefinitionsAPIService(logger, settings)
+			checker.Register("dimoAuth", deviceDefinitionsAPI.AuthCheck)
+			deviceDefinitionsService, err := service.NewFallbackVinDecoder(logger, settings, deviceDefinitionsAPI)
+			if err != nil {
+				logger.Fatal().Err(err).Msg("Failed to create VIN decoder")
+			}
+			vendorOnboardingService := onboarding.NewExternalOnboardingService(&settings, vehicleService, &logger, enrollmentChannel)
+			vendorSwitches := onboarding.NewVendorSwitches(provider)
+
+			riverClient, _, dbPool, err = createRiverClientWithWorkersAndPool(gCtx, logger, &settings, identityService, deviceDefinitionsService, oracleService, &pdb, chains, walletService, vendorOnboardingService, vendorSwitches)
+			if err != nil {
+				logger.Fatal().Err(err).Msg("failed to create river client, workers and db pool")
+			}
+
+			runRiver(gCtx, logger, riverClient, group)
+
+			queueMetrics := onboarding.NewQueueMetrics(&settings, logger, &pdb)
+			group.Go(func() error {
+				queueMetrics.Run(gCtx)
+				return nil
+			})
+		} else {
+			riverClient, dbPool, err = createInsertOnlyRiverClient(gCtx, &settings)
+			if err != nil {
+				logger.Fatal().Err(err).Msg("failed to create insert only river client")
+			}
+		}
+		defer dbPool.Close()
+		checker.Register("river", health.RiverCheck(riverClient, runWorkers))
+
+		if runAPI {
+			accessService := service.NewAccessService(&pdb, &logger)
+			webAPI := app.App(&settings, &logger, vehicleService, identityService, riverClient, walletService, chains, accessService, checker, provider)
+
+			logger.Info().Str("port", settings.Port).Msgf("Starting web server %s", settings.Port)
+			runFiber(gCtx, webAPI, ":"+settings.Port, group)
+		}
+	}
+
+	// start the monitoring server
+	logger.Info().Str("port", settings.MonitoringPort).Msgf("Starting monitoring server %s", settings.MonitoringPort)
+	runFiber(gCtx, monApp, ":"+settings.MonitoringPort, group)
+
+	kafkaBrokers := strings.Split(settings.KafkaBrokers, ",")
+
+	if consumeTelemetry {
+		// Setup consumer for UnbufferedTelemetryTopic
+		status, err := kafka.SetupKafkaConsumer(
+			ctx,
+			&logger,
+			kafkaBrokers,
+			settings.UnbufferedTelemetryTopic,
+			settings.UnbufferedTelemetryConsumerGroup,
+			kafka.MessageHandlerUnbuffered{Logger: &logger, OracleService: oracleService},
+		)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to setup consumer for UnbufferedTelemetryTopic")
+		}
+		checker.Register("telemetryConsumer", status.Check)
+	}
+
+	if consumeOperations {
+		// Setup consumer for OperationsTopic
+		status, err := kafka.SetupKafkaConsumer(
+			ctx,
+			&logger,
+			kafkaBrokers,
+			settings.OperationsTopic,
+			settings.OperationsConsumerGroup,
+			kafka.MessageHandlerOperations{Logger: &logger, OracleService: oracleService, EnrollmentChannel: enrollmentChannel},
+		)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to setup consumer for OperationsTopic")
+		}
+		checker.Register("operationsConsumer", status.Check)
+	}
+
+	if err := group.Wait(); err != nil {
+		logger.Error().Err(err).Msg("Server failed.")
+		return subcommands.ExitFailure
+	}
+	logger.Info().Msg("Server stopped.")
+
+	return subcommands.ExitSuccess
+}
+
+// chainCheckName keeps the name of the default chain's check, other chains are suffixed with their profile name
+func chainCheckName(name string, profile config.ChainProfile) string {
+	if profile.Name == config.DefaultChainProfileName {
+		return name
+	}
+	return name + "-" + profile.Name
+}
+
+// createInsertOnlyRiverClient creates a river client that inserts jobs without working them, for the API and CLI.
+// It shares the queue middleware with the workers, so inserted jobs get their queue's priority.
+func createInsertOnlyRiverClient(ctx context.Context, settings *config.Settings) (*river.Client[pgx.Tx], *pgxpool.Pool, error) {
+	dbPool, err := pgxpool.New(ctx, settings.DB.BuildConnectionString(true))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	riverClient, err := river.NewClient(riverpgxv5.New(dbPool), &river.Config{
+		Middleware: []rivertype.Middleware{onboarding.NewQueueMiddleware(settings)},
+	})
+	if err != nil {
+		dbPool.Close()
+		return nil, nil, err
+	}
+
+	return riverClient, dbPool, nil
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/DIMO-Network/oracle-example/internal/onboarding"
+	"github.com/DIMO-Network/oracle-example/internal/vin"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/google/subcommands"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/rs/zerolog"
+	"os"
+	"os/user"
+	"strings"
+	"text/tabwriter"
+)
+
+// vinCmd groups the operator subcommands to inspect and repair VIN records
+type vinCmd struct {
+	logger   zerolog.Logger
+	settings config.Settings
+	pdb      db.Store
+}
+
+func (*vinCmd) Name() string     { return "vin" }
+func (*vinCmd) Synopsis() string { return "inspect and repair VIN records" }
+func (*vinCmd) Usage() string {
+	return `vin <show | list | reset | enqueue> [flags] [args]:
+	show and list VIN records, reset a VIN to a retryable status or re-enqueue its last job.
+	Mutations support -dry-run and are recorded in VIN history with the operator's name.
+  `
+}
+
+func (*vinCmd) SetFlags(*flag.FlagSet) {}
+
+func (p *vinCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	// jobs are worked by the running oracle
+	riverClient, dbPool, err := createInsertOnlyRiverClient(ctx, &p.settings)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to create river client")
+		return subcommands.ExitFailure
+	}
+	defer dbPool.Close()
+
+	admin := onboarding.NewVinAdmin(&p.settings, p.logger, &p.pdb, riverClient)
+
+	commander := subcommands.NewCommander(f, "vin")
+	commander.Register(commander.HelpCommand(), "")
+	commander.Register(&vinShowCmd{admin: admin}, "")
+	commander.Register(&vinListCmd{admin: admin}, "")
+	commander.Register(&vinResetCmd{admin: admin}, "")
+	commander.Register(&vinEnqueueCmd{admin: admin}, "")
+
+	return commander.Execute(ctx)
+}
+
+type vinShowCmd struct {
+	admin *onboarding.VinAdmin
+}
+
+func (*vinShowCmd) Name() string     { return "show" }
+func (*vinShowCmd) Synopsis() string { return "show a VIN record with its history" }
+func (*vinShowCmd) Usage() string {
+	return `show <vin>:
+	prints the VIN record, with the name of its onboarding status, and its history.
+  `
+}
+
+func (*vinShowCmd) SetFlags(*flag.FlagSet) {}
+
+func (p *vinShowCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 1 {
+		f.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	record, history, err := p.admin.Get(ctx, vin.Normalize(f.Arg(0)))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "VIN\t%s\n", record.Vin)
+	fmt.Fprintf(w, "Onboarding status\t%s (%d)\n", onboarding.GetDetailedStatus(record.OnboardingStatus), record.OnboardingStatus)
+	fmt.Fprintf(w, "Status updated at\t%s\n", record.OnboardingStatusUpdatedAt.Format("2006-01-02 15:04:05Z07:00"))
+	fmt.Fprintf(w, "Owner\t%s\n", record.OwnerAddress.String)
+	fmt.Fprintf(w, "Vehicle token ID\t%s\n", nullInt(record.VehicleTokenID.Valid, record.VehicleTokenID.Int64))
+	fmt.Fprintf(w, "Synthetic token ID\t%s\n", nullInt(record.SyntheticTokenID.Valid, record.SyntheticTokenID.Int64))
+	fmt.Fprintf(w, "Device definition ID\t%s\n", record.DeviceDefinitionID.String)
+	fmt.Fprintf(w, "External ID\t%s\n", record.ExternalID.String)
+	fmt.Fprintf(w, "Telemetry paused\t%t\n", record.TelemetryPaused)
+	if record.OperationErrorCode.Valid {
+		fmt.Fprintf(w, "Operation error\t%s %s: %s\n", record.OperationErrorType.String, record.OperationErrorCode.String, record.OperationErrorDescription.String)
+	}
+	_ = w.Flush()
+
+	if len(history) > 0 {
+		fmt.Println("\nHistory")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, event := range history {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", event.CreatedAt.Format("2006-01-02 15:04:05Z07:00"), event.Event, event.Details.String)
+		}
+		_ = w.Flush()
+	}
+
+	return subcommands.ExitSuccess
+}
+
+type vinListCmd struct {
+	admin *onboarding.VinAdmin
+
+	statuses string
+	limit    int
+}
+
+func (*vinListCmd) Name() string     { return "list" }
+func (*vinListCmd) Synopsis() string { return "list VINs by onboarding status" }
+func (*vinListCmd) Usage() string {
+	return `list [-status MintPending,MintSubmitPending] [-limit 100]:
+	lists VINs with any of the onboarding statuses, given by name or number.
+  `
+}
+
+func (p *vinListCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.statuses, "status", "", "comma separated onboarding statuses, all statuses if empty")
+	f.IntVar(&p.limit, "limit", 100, "maximum number of VINs")
+}
+
+func (p *vinListCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	var statuses []int
+	for _, name := range strings.Split(p.statuses, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		status, ok := onboarding.ParseStatus(name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown status %q\n", name)
+			return subcommands.ExitUsageError
+		}
+		statuses = append(statuses, status)
+	}
+
+	vins, err := p.admin.List(ctx, statuses, p.limit)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VIN\tSTATUS\tOWNER\tVEHICLE\tSYNTHETIC")
+	for _, record := range vins {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", record.Vin, onboarding.GetDetailedStatus(record.OnboardingStatus), record.OwnerAddress.String,
+			nullInt(record.VehicleTokenID.Valid, record.VehicleTokenID.Int64), nullInt(record.SyntheticTokenID.Valid, record.SyntheticTokenID.Int64))
+	}
+	_ = w.Flush()
+
+	return subcommands.ExitSuccess
+}
+
+type vinResetCmd struct {
+	admin *onboarding.VinAdmin
+
+	status   string
+	operator string
+	dryRun   bool
+}
+
+func (*vinResetCmd) Name() string     { return "reset" }
+func (*vinResetCmd) Synopsis() string { return "reset a VIN to a retryable onboarding status" }
+func (*vinResetCmd) Usage() string {
+	retryable := make([]string, 0, len(onboarding.RetryableStatuses))
+	for _, status := range onboarding.RetryableStatuses {
+		retryable = append(retryable, onboarding.GetDetailedStatus(status))
+	}
+
+	return `reset -status <status> [-operator name] [-dry-run] <vin>:
+	sets the onboarding status of the VIN, so the owner can retry the step through the API.
+	Retryable statuses: ` + strings.Join(retryable, ", ") + `
+  `
+}
+
+func (p *vinResetCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.status, "status", "", "retryable onboarding status, by name or number")
+	f.StringVar(&p.operator, "operator", currentUser(), "operator name recorded in VIN history")
+	f.BoolVar(&p.dryRun, "dry-run", false, "only print what would change")
+}
+
+func (p *vinResetCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	status, ok := onboarding.ParseStatus(p.status)
+	if f.NArg() != 1 || !ok || p.operator == "" {
+		f.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	normalizedVin := vin.Normalize(f.Arg(0))
+	previous, err := p.admin.Reset(ctx, normalizedVin, status, p.operator, p.dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	prefix := ""
+	if p.dryRun {
+		prefix = "[dry run] "
+	}
+	fmt.Printf("%s%s: %s -> %s\n", prefix, normalizedVin, onboarding.GetDetailedStatus(previous), onboarding.GetDetailedStatus(status))
+
+	return subcommands.ExitSuccess
+}
+
+type vinEnqueueCmd struct {
+	admin *onboarding.VinAdmin
+
+	operator string
+	dryRun   bool
+}
+
+func (*vinEnqueueCmd) Name() string     { return "enqueue" }
+func (*vinEnqueueCmd) Synopsis() string { return "re-enqueue the last job of a VIN" }
+func (*vinEnqueueCmd) Usage() string {
+	return `enqueue [-operator name] [-dry-run] <` + strings.Join(onboarding.EnqueueableKinds, " | ") + `> <vin>:
+	makes the last job of the kind for the VIN available again, with its original (signed) args.
+	Reset the VIN first if its status doesn't let the worker run the step again.
+  `
+}
+
+func (p *vinEnqueueCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.operator, "operator", currentUser(), "operator name recorded in VIN history")
+	f.BoolVar(&p.dryRun, "dry-run", false, "only print the job that would be re-enqueued")
+}
+
+func (p *vinEnqueueCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 2 || p.operator == "" {
+		f.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	kind, normalizedVin := f.Arg(0), vin.Normalize(f.Arg(1))
+	job, err := p.admin.Enqueue(ctx, normalizedVin, kind, p.operator, p.dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	switch {
+	case p.dryRun:
+		fmt.Printf("[dry run] %s: would re-enqueue %s job %d, currently %s\n", normalizedVin, kind, job.ID, job.State)
+	case job.State == rivertype.JobStateRunning:
+		fmt.Printf("%s: %s job %d is still running, not re-enqueued\n", normalizedVin, kind, job.ID)
+		return subcommands.ExitFailure
+	default:
+		fmt.Printf("%s: %s job %d re-enqueued, now %s\n", normalizedVin, kind, job.ID, job.State)
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func nullInt(valid bool, value int64) string {
+	if !valid {
+		return "-"
+	}
+	return fmt.Sprint(value)
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
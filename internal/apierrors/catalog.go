@@ -0,0 +1,97 @@
+// Package apierrors is the catalog of API error codes and their RFC 7807 problem+json responses.
+// Codes are stable, clients can program against them, titles and details are only meant for humans.
+package apierrors
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"strings"
+)
+
+type Code string
+
+const (
+	CodeRequestInvalid Code = "REQUEST_INVALID"
+	CodeUnauthorized   Code = "UNAUTHORIZED"
+	CodeAccessDenied   Code = "ACCESS_DENIED"
+	CodeNotFound       Code = "NOT_FOUND"
+
+	CodeVinInvalid    Code = "VIN_INVALID"
+	CodeVinDuplicate  Code = "VIN_DUPLICATE"
+	CodeVinNotFound   Code = "VIN_NOT_FOUND"
+	CodeVinNotOwned   Code = "VIN_NOT_OWNED"
+	CodeDataInvalid   Code = "DATA_INVALID"
+	CodeStateConflict Code = "STATE_CONFLICT"
+	CodeTokenMismatch Code = "TOKEN_MISMATCH"
+	// CodeChainUnsupported is returned for a chain without profile, requested or stored in the VIN record
+	CodeChainUnsupported Code = "CHAIN_UNSUPPORTED"
+	// CodeBatchRejected is returned when none of the VINs of a batch request (or any, for atomic requests) could be processed
+	CodeBatchRejected Code = "BATCH_REJECTED"
+
+	CodeUpstreamIdentityFailed Code = "UPSTREAM_IDENTITY_FAILED"
+	CodeUpstreamChainFailed    Code = "UPSTREAM_CHAIN_FAILED"
+	CodeDatabaseFailed         Code = "DATABASE_FAILED"
+	CodeInternal               Code = "INTERNAL_ERROR"
+)
+
+type entry struct {
+	status int
+	title  string
+}
+
+var catalog = map[Code]entry{
+	CodeRequestInvalid: {fiber.StatusBadRequest, "Invalid request"},
+	CodeUnauthorized:   {fiber.StatusUnauthorized, "Missing or invalid token"},
+	CodeAccessDenied:   {fiber.StatusForbidden, "Wallet does not have access"},
+	CodeNotFound:       {fiber.StatusNotFound, "Not found"},
+
+	CodeVinInvalid:       {fiber.StatusBadRequest, "Invalid VIN"},
+	CodeVinDuplicate:     {fiber.StatusBadRequest, "Duplicated VIN"},
+	CodeVinNotFound:      {fiber.StatusNotFound, "Vehicle not found"},
+	CodeVinNotOwned:      {fiber.StatusForbidden, "Vehicle not owned by wallet"},
+	CodeDataInvalid:      {fiber.StatusBadRequest, "Invalid vehicle data"},
+	CodeStateConflict:    {fiber.StatusConflict, "Vehicle is in the wrong state for the operation"},
+	CodeTokenMismatch:    {fiber.StatusConflict, "Token IDs don't match on-chain state"},
+	CodeChainUnsupported: {fiber.StatusBadRequest, "Chain is not supported by this oracle"},
+	CodeBatchRejected:    {fiber.StatusBadRequest, "None of the VINs could be processed"},
+
+	CodeUpstreamIdentityFailed: {fiber.StatusBadGateway, "Identity API request failed"},
+	CodeUpstreamChainFailed:    {fiber.StatusBadGateway, "Chain request failed"},
+	CodeDatabaseFailed:         {fiber.StatusInternalServerError, "Database request failed"},
+	CodeInternal:               {fiber.StatusInternalServerError, "Internal error"},
+}
+
+// Status is the HTTP status code for the code, 500 for unknown codes
+func (c Code) Status() int {
+	if e, ok := catalog[c]; ok {
+		return e.status
+	}
+	return fiber.StatusInternalServerError
+}
+
+func (c Code) Title() string {
+	if e, ok := catalog[c]; ok {
+		return e.title
+	}
+	return catalog[CodeInternal].title
+}
+
+// Type is the problem type URI, e.g. urn:oracle-example:error:vin-not-owned
+func (c Code) Type() string {
+	return "urn:oracle-example:error:" + strings.ReplaceAll(strings.ToLower(string(c)), "_", "-")
+}
+
+// FromStatus maps the status of errors that don't come from the catalog, like fiber's, to the closest code
+func FromStatus(status int) Code {
+	switch {
+	case status == fiber.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == fiber.StatusForbidden:
+		return CodeAccessDenied
+	case status == fiber.StatusNotFound:
+		return CodeNotFound
+	case status >= 400 && status < 500:
+		return CodeRequestInvalid
+	default:
+		return CodeInternal
+	}
+}
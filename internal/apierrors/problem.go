@@ -0,0 +1,127 @@
+package apierrors
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	MIMEApplicationProblemJSON = "application/problem+json"
+	// CorrelationIDHeader is read from the request, or generated, and sent back with every response
+	CorrelationIDHeader = "X-Correlation-ID"
+	// CorrelationIDKey is the fiber locals key of the request correlation ID
+	CorrelationIDKey = "correlationId"
+)
+
+// Error is returned by handlers and rendered by the app ErrorHandler as problem+json
+type Error struct {
+	Code   Code
+	Detail string
+	// Errors is an extension member, e.g. per-VIN errors of a batch request
+	Errors any
+	// cause is logged, and only shown to clients outside of production
+	cause error
+}
+
+func New(code Code, detail string) *Error {
+	return &Error{
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// Wrap keeps the underlying error for logs, clients only get the detail in production
+func Wrap(err error, code Code, detail string) *Error {
+	return &Error{
+		Code:   code,
+		Detail: detail,
+		cause:  err,
+	}
+}
+
+// WithErrors sets the extension member listing the individual errors
+func (e *Error) WithErrors(list any) *Error {
+	e.Errors = list
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return string(e.Code) + ": " + e.Detail + ": " + e.cause.Error()
+	}
+	return string(e.Code) + ": " + e.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Problem is the RFC 7807 response body
+type Problem struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Status        int    `json:"status"`
+	Detail        string `json:"detail,omitempty"`
+	Instance      string `json:"instance,omitempty"`
+	Code          Code   `json:"code"`
+	CorrelationID string `json:"correlationId,omitempty"`
+	Errors        any    `json:"errors,omitempty"`
+}
+
+// NewProblem converts any error to a problem. Outside of the catalog and fiber errors, the error string is internal,
+// so it's replaced by the title in production.
+func NewProblem(c *fiber.Ctx, err error, production bool) Problem {
+	var problem Problem
+
+	var apiErr *Error
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &apiErr):
+		problem = Problem{
+			Code:   apiErr.Code,
+			Detail: apiErr.Detail,
+			Errors: apiErr.Errors,
+		}
+		if apiErr.cause != nil && !production {
+			problem.Detail += ": " + apiErr.cause.Error()
+		}
+	case errors.As(err, &fiberErr):
+		problem = Problem{
+			Code:   FromStatus(fiberErr.Code),
+			Detail: fiberErr.Message,
+		}
+	default:
+		problem = Problem{Code: CodeInternal}
+		if !production {
+			problem.Detail = err.Error()
+		}
+	}
+
+	problem.Type = problem.Code.Type()
+	problem.Title = problem.Code.Title()
+	problem.Status = problem.Code.Status()
+	if fiberErr != nil && apiErr == nil {
+		problem.Status = fiberErr.Code
+	}
+	problem.Instance = c.Path()
+	problem.CorrelationID = CorrelationID(c)
+
+	return problem
+}
+
+// Send writes the problem+json response
+func Send(c *fiber.Ctx, problem Problem) error {
+	c.Status(problem.Status)
+	if err := c.JSON(problem); err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, MIMEApplicationProblemJSON)
+
+	return nil
+}
+
+// CorrelationID returns the ID set by the correlation ID middleware, empty when it's not used
+func CorrelationID(c *fiber.Ctx) string {
+	id, _ := c.Locals(CorrelationIDKey).(string)
+	return id
+}
@@ -0,0 +1,105 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"net/http/httptest"
+	"testing"
+)
+
+type ProblemTestSuite struct {
+	suite.Suite
+}
+
+func TestProblemTestSuite(t *testing.T) {
+	suite.Run(t, new(ProblemTestSuite))
+}
+
+func (s *ProblemTestSuite) request(production bool, handlerErr error, headers map[string]string) (int, string, Problem) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return Send(c, NewProblem(c, err, production))
+		},
+	})
+	app.Use(requestid.New(requestid.Config{
+		Header:     CorrelationIDHeader,
+		ContextKey: CorrelationIDKey,
+	}))
+	app.Get("/v1/vehicle/verify", func(_ *fiber.Ctx) error {
+		return handlerErr
+	})
+
+	req := httptest.NewRequest("GET", "/v1/vehicle/verify", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	response, err := app.Test(req)
+	s.Require().NoError(err)
+
+	body, err := io.ReadAll(response.Body)
+	s.Require().NoError(err)
+
+	var problem Problem
+	s.Require().NoError(json.Unmarshal(body, &problem))
+
+	return response.StatusCode, response.Header.Get(fiber.HeaderContentType), problem
+}
+
+func (s *ProblemTestSuite) TestCatalogError() {
+	status, contentType, problem := s.request(false, New(CodeVinNotOwned, "Vehicle not owned by wallet"), map[string]string{
+		CorrelationIDHeader: "abc-123",
+	})
+
+	s.Equal(fiber.StatusForbidden, status)
+	s.Equal(MIMEApplicationProblemJSON, contentType)
+	s.Equal(Problem{
+		Type:          "urn:oracle-example:error:vin-not-owned",
+		Title:         "Vehicle not owned by wallet",
+		Status:        fiber.StatusForbidden,
+		Detail:        "Vehicle not owned by wallet",
+		Instance:      "/v1/vehicle/verify",
+		Code:          CodeVinNotOwned,
+		CorrelationID: "abc-123",
+	}, problem)
+}
+
+func (s *ProblemTestSuite) TestCauseOnlyOutsideProduction() {
+	err := Wrap(errors.New("connection refused"), CodeDatabaseFailed, "Failed to load vehicles")
+
+	_, _, problem := s.request(false, err, nil)
+	s.Equal("Failed to load vehicles: connection refused", problem.Detail)
+	s.NotEmpty(problem.CorrelationID)
+
+	status, _, problem := s.request(true, err, nil)
+	s.Equal(fiber.StatusInternalServerError, status)
+	s.Equal("Failed to load vehicles", problem.Detail)
+}
+
+func (s *ProblemTestSuite) TestUnknownError() {
+	_, _, problem := s.request(false, errors.New("boom"), nil)
+	s.Equal(CodeInternal, problem.Code)
+	s.Equal("boom", problem.Detail)
+
+	_, _, problem = s.request(true, errors.New("boom"), nil)
+	s.Equal(CodeInternal, problem.Code)
+	s.Empty(problem.Detail)
+}
+
+func (s *ProblemTestSuite) TestFiberError() {
+	status, _, problem := s.request(true, fiber.ErrUnauthorized, nil)
+	s.Equal(fiber.StatusUnauthorized, status)
+	s.Equal(CodeUnauthorized, problem.Code)
+	s.Equal("Unauthorized", problem.Detail)
+}
+
+func (s *ProblemTestSuite) TestErrors() {
+	err := New(CodeBatchRejected, "VINs could not be processed").WithErrors([]map[string]string{{"vin": "A345"}})
+
+	status, _, problem := s.request(true, err, nil)
+	s.Equal(fiber.StatusBadRequest, status)
+	s.Equal([]any{map[string]any{"vin": "A345"}}, problem.Errors)
+}
@@ -2,6 +2,8 @@ package app
 
 import (
 	"context"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
+	"github.com/DIMO-Network/oracle-example/internal/config"
 	"github.com/DIMO-Network/oracle-example/internal/service"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/friendsofgo/errors"
@@ -35,10 +37,29 @@ func NewAccessMiddleware(access *service.Access) fiber.Handler {
 			}
 		}
 
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error":  "Wallet does not have access.",
-			"wallet": walletAddress.String(),
-		})
+		return apierrors.New(apierrors.CodeAccessDenied, "Wallet "+walletAddress.String()+" does not have access")
+	}
+}
+
+// NewAdminMiddleware returns a middleware that only lets the ADMIN_WALLETS through, for endpoints over every owner's VINs.
+// Admin endpoints are closed when ADMIN_WALLETS is empty. Requires JWT middleware to be executed first
+func NewAdminMiddleware(settings *config.Settings) fiber.Handler {
+	admins := make(map[common.Address]bool)
+	for _, wallet := range settings.AdminWalletList() {
+		admins[common.HexToAddress(wallet)] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		walletAddress, err := getWalletAddress(c)
+		if err != nil {
+			return err
+		}
+
+		if !admins[walletAddress] {
+			return apierrors.New(apierrors.CodeAccessDenied, "Wallet "+walletAddress.String()+" is not an admin")
+		}
+
+		return c.Next()
 	}
 }
 
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/DIMO-Network/oracle-example/internal/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"testing"
+)
+
+type AccessTestSuite struct {
+	suite.Suite
+}
+
+func TestAccessTestSuite(t *testing.T) {
+	suite.Run(t, new(AccessTestSuite))
+}
+
+func (s *AccessTestSuite) status(settings *config.Settings, wallet common.Address) int {
+	logger := zerolog.Nop()
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return ErrorHandler(c, err, &logger, true)
+		},
+	})
+	app.Get("/admin", test.AuthInjectorTestHandler("user", &wallet), NewAdminMiddleware(settings), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	res, err := app.Test(test.BuildRequest(http.MethodGet, "/admin", ""))
+	s.Require().NoError(err)
+	return res.StatusCode
+}
+
+func (s *AccessTestSuite) TestAdminMiddleware() {
+	admin := common.HexToAddress("0x1e7B7e2a8c0F3C1A2F4e5d0b6C3f2A9e8D7c6B5a")
+	other := common.HexToAddress("0x2")
+	settings := &config.Settings{AdminWallets: " 0x1e7b7e2a8c0f3c1a2f4e5d0b6c3f2a9e8d7c6b5a , 0x3"}
+
+	s.Equal(http.StatusOK, s.status(settings, admin))
+	s.Equal(http.StatusForbidden, s.status(settings, other))
+	// closed when no admin is set
+	s.Equal(http.StatusForbidden, s.status(&config.Settings{}, admin))
+}
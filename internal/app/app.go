@@ -2,29 +2,34 @@ package app
 
 import (
 	"errors"
-	"github.com/DIMO-Network/go-transactions"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
 	"github.com/DIMO-Network/oracle-example/internal/config"
 	"github.com/DIMO-Network/oracle-example/internal/controllers"
+	"github.com/DIMO-Network/oracle-example/internal/docs"
+	"github.com/DIMO-Network/oracle-example/internal/health"
+	"github.com/DIMO-Network/oracle-example/internal/onboarding"
 	"github.com/DIMO-Network/oracle-example/internal/service"
 	"github.com/DIMO-Network/shared/pkg/middleware/metrics"
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	fiberrecover "github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/jackc/pgx/v5"
 	"github.com/riverqueue/river"
 	"github.com/rs/zerolog"
 	"strconv"
 )
 
-func App(settings *config.Settings, logger *zerolog.Logger, db *service.Vehicle, riverClient *river.Client[pgx.Tx], ws service.SDWalletsAPI, tr *transactions.Client, acc *service.Access) *fiber.App {
-	if tr == nil {
-		logger.Fatal().Err(errors.New("tr transactions.Client is nil"))
+func App(settings *config.Settings, logger *zerolog.Logger, db *service.Vehicle, identityService service.IdentityAPI, riverClient *river.Client[pgx.Tx], ws service.SDWalletsAPI, chains *onboarding.Chains, acc *service.Access, checker *health.Checker, provider *config.Provider) *fiber.App {
+	if chains == nil {
+		logger.Fatal().Err(errors.New("chains is nil"))
 	}
 	// all the fiber logic here, routes, authorization
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			return ErrorHandler(c, err, logger)
+			return ErrorHandler(c, err, logger, settings.IsProduction())
 		},
 		DisableStartupMessage:    true,
 		ReadBufferSize:           16000,
@@ -33,25 +38,41 @@ func App(settings *config.Settings, logger *zerolog.Logger, db *service.Vehicle,
 	})
 	app.Use(metrics.HTTPMetricsMiddleware)
 
+	// correlation ID from the caller or a new one, returned in the header and in error responses
+	app.Use(requestid.New(requestid.Config{
+		Header:     apierrors.CorrelationIDHeader,
+		Generator:  utils.UUIDv4,
+		ContextKey: apierrors.CorrelationIDKey,
+	}))
+
 	app.Use(fiberrecover.New(fiberrecover.Config{
 		Next:              nil,
 		EnableStackTrace:  true,
 		StackTraceHandler: nil,
 	}))
 
+	// CORS_ALLOWED_ORIGINS is reloadable, so origins are checked against the current list
+	origins := newAllowedOrigins(provider)
 	app.Use(cors.New(cors.Config{
-		AllowOrigins:     "https://localdev.dimo.org:3008", // localhost development
+		AllowOriginsFunc: origins.allowed,
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, " + apierrors.CorrelationIDHeader,
+		ExposeHeaders:    apierrors.CorrelationIDHeader,
 		AllowCredentials: true,
 	}))
 
 	app.Get("/health", healthCheck)
+	// per dependency readiness, also served by the monitoring server for the probes
+	app.Get("/health/live", checker.LiveHandler)
+	app.Get("/health/ready", checker.ReadyHandler)
+
+	// OpenAPI document and Swagger UI, for frontends to generate typed clients
+	docs.Register(app)
 
-	identityService := service.NewIdentityAPIService(*logger, *settings)
-	vehiclesCtrl := controllers.NewVehiclesController(settings, logger, identityService, db, riverClient, ws, tr)
+	vehiclesCtrl := controllers.NewVehiclesController(settings, logger, identityService, db, riverClient, ws, chains)
 
 	accessCtrl := controllers.NewAccessController()
+	reconciliationCtrl := controllers.NewReconciliationController(logger, db)
 
 	// assumes frontend has used Login With DIMO and has a JWT from DIMO.
 	jwtAuth := jwtware.New(jwtware.Config{
@@ -62,6 +83,9 @@ func App(settings *config.Settings, logger *zerolog.Logger, db *service.Vehicle,
 
 	app.Get("/v1/access", jwtAuth, accessCheck, accessCtrl.CheckAccess)
 
+	// gets a page of user's onboarded vehicles, filterable and sortable, for fleet dashboards
+	app.Get("/v1/vehicles", jwtAuth, accessCheck, vehiclesCtrl.GetVehicles)
+
 	// gets verification (VIN decoding and vendor support check) statuses
 	app.Get("/v1/vehicle/verify", jwtAuth, accessCheck, vehiclesCtrl.GetVerificationStatusForVins)
 	// handles decoding the VIN to be onboarded and checking if the vendor supports this VIN. Optional.
@@ -88,11 +112,28 @@ func App(settings *config.Settings, logger *zerolog.Logger, db *service.Vehicle,
 	// submits the passkey signed delete vehicle payload to the backend
 	app.Post("/v1/vehicle/delete", jwtAuth, accessCheck, vehiclesCtrl.SubmitDeleteDataForVins)
 
+	// gets telemetry pause status
+	app.Get("/v1/vehicle/pause/status", jwtAuth, accessCheck, vehiclesCtrl.GetPauseStatusForVins)
+	// temporarily stops data for the VINs, vehicle and synthetic device stay minted
+	app.Post("/v1/vehicle/pause", jwtAuth, accessCheck, vehiclesCtrl.SubmitPauseForVins)
+	// resumes data for paused VINs
+	app.Post("/v1/vehicle/resume", jwtAuth, accessCheck, vehiclesCtrl.SubmitResumeForVins)
+
+	// gets SACD grant state
+	app.Get("/v1/vehicle/sacd/status", jwtAuth, accessCheck, vehiclesCtrl.GetSacdStatusForVins)
+	// gets the payload to be signed for granting, extending or revoking (zero permissions) SACD by the frontend (using passkey)
+	app.Get("/v1/vehicle/sacd", jwtAuth, accessCheck, vehiclesCtrl.GetSacdDataForVins)
+	// submits the passkey signed SACD payload to the backend
+	app.Post("/v1/vehicle/sacd", jwtAuth, accessCheck, vehiclesCtrl.SubmitSacdDataForVins)
+
 	// get a specific vehicle by ID (could be VIN or whatever identifier)
 	app.Get("/v1/vehicle/:externalID", jwtAuth, accessCheck, vehiclesCtrl.GetVehicleByExternalID)
 	// submits vehicles to be registered by the backend
 	app.Post("/v1/vehicle/register", jwtAuth, accessCheck, vehiclesCtrl.RegisterVehicle)
 
+	// gets mismatches between the vins table and on-chain state found by the reconciliation job, for admins only
+	app.Get("/v1/reconciliation/drifts", jwtAuth, accessCheck, NewAdminMiddleware(settings), reconciliationCtrl.GetDrifts)
+
 	return app
 }
 
@@ -110,37 +151,19 @@ func healthCheck(c *fiber.Ctx) error {
 	return nil
 }
 
-// ErrorHandler custom handler to log recovered errors using our logger and return json instead of string
-func ErrorHandler(c *fiber.Ctx, err error, logger *zerolog.Logger) error {
-	code := fiber.StatusInternalServerError // Default 500 statuscode
-
-	var e *fiber.Error
-	isFiberErr := errors.As(err, &e)
-	if isFiberErr {
-		// Override status code if fiber.Error type
-		code = e.Code
-	}
-	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
-	codeStr := strconv.Itoa(code)
+// ErrorHandler custom handler to log recovered errors using our logger and return problem+json (RFC 7807) instead of string.
+// In production, strings of errors outside the catalog are hidden as they may leak internals.
+func ErrorHandler(c *fiber.Ctx, err error, logger *zerolog.Logger, production bool) error {
+	problem := apierrors.NewProblem(c, err, production)
 
-	if code != fiber.StatusNotFound {
-		logger.Err(err).Str("httpStatusCode", codeStr).
+	if problem.Status != fiber.StatusNotFound {
+		logger.Err(err).Str("httpStatusCode", strconv.Itoa(problem.Status)).
 			Str("httpMethod", c.Method()).
 			Str("httpPath", c.Path()).
+			Str("errorCode", string(problem.Code)).
+			Str("correlationId", problem.CorrelationID).
 			Msg("caught an error from http request")
 	}
-	// return an opaque error if we're in a higher level environment and we haven't specified an fiber type err.
-	//if !isFiberErr && isProduction {
-	//	err = fiber.NewError(fiber.StatusInternalServerError, "Internal error")
-	//}
-
-	return c.Status(code).JSON(ErrorRes{
-		Code:    code,
-		Message: err.Error(),
-	})
-}
 
-type ErrorRes struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	return apierrors.Send(c, problem)
 }
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"strings"
+	"sync/atomic"
+)
+
+const defaultCorsAllowedOrigin = "https://localdev.dimo.org:3008" // localhost development
+
+// allowedOrigins holds the CORS origins, swapped when CORS_ALLOWED_ORIGINS is reloaded
+type allowedOrigins struct {
+	origins atomic.Pointer[map[string]bool]
+}
+
+func newAllowedOrigins(provider *config.Provider) *allowedOrigins {
+	a := &allowedOrigins{}
+	provider.Subscribe(func(settings *config.Settings) {
+		a.set(settings.CorsAllowedOrigins)
+	})
+	return a
+}
+
+func (a *allowedOrigins) set(setting string) {
+	if strings.TrimSpace(setting) == "" {
+		setting = defaultCorsAllowedOrigin
+	}
+
+	origins := make(map[string]bool)
+	for _, origin := range strings.Split(setting, ",") {
+		// a wildcard isn't allowed as the API accepts credentials
+		if origin = strings.ToLower(strings.TrimSpace(origin)); origin != "" && origin != "*" {
+			origins[origin] = true
+		}
+	}
+	a.origins.Store(&origins)
+}
+
+func (a *allowedOrigins) allowed(origin string) bool {
+	return (*a.origins.Load())[strings.ToLower(origin)]
+}
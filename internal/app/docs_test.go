@@ -0,0 +1,218 @@
+package app
+
+import (
+	"github.com/DIMO-Network/go-transactions"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/DIMO-Network/oracle-example/internal/controllers"
+	"github.com/DIMO-Network/oracle-example/internal/docs"
+	"github.com/DIMO-Network/oracle-example/internal/health"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/oracle-example/internal/onboarding"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type DocsTestSuite struct {
+	suite.Suite
+	app  *fiber.App
+	jwks *httptest.Server
+	spec map[string]any
+}
+
+func TestDocsTestSuite(t *testing.T) {
+	suite.Run(t, new(DocsTestSuite))
+}
+
+func (s *DocsTestSuite) SetupSuite() {
+	// the JWT middleware loads the key set when the app is built
+	s.jwks = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"keys":[]}`))
+	}))
+
+	logger := zerolog.Nop()
+	settings := &config.Settings{JwtKeySetURL: s.jwks.URL}
+	s.app = App(settings, &logger, nil, nil, nil, nil, onboarding.NewChains(0, map[int64]*transactions.Client{}), nil, health.NewChecker(settings), config.NewProvider("settings.yaml", *settings, logger))
+
+	s.Require().NoError(yaml.Unmarshal(docs.Spec(), &s.spec))
+}
+
+func (s *DocsTestSuite) TearDownSuite() {
+	s.jwks.Close()
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func (s *DocsTestSuite) TestSpecCoversRoutes() {
+	var routes []string
+	for _, route := range s.app.GetRoutes(true) {
+		if !strings.HasPrefix(route.Path, "/v1/") || route.Method == fiber.MethodHead {
+			continue
+		}
+		routes = append(routes, route.Method+" "+pathParam.ReplaceAllString(route.Path, "{$1}"))
+	}
+
+	var operations []string
+	for path, item := range s.spec["paths"].(map[string]any) {
+		for method := range item.(map[string]any) {
+			operations = append(operations, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(routes)
+	sort.Strings(operations)
+	s.Equal(routes, operations)
+}
+
+func (s *DocsTestSuite) TestSchemasMatchTypes() {
+	types := map[string]any{
+		"Problem":                     apierrors.Problem{},
+		"VinError":                    controllers.VinError{},
+		"VinsGetParams":               controllers.VinsGetParams{},
+		"VinWithCountryCode":          controllers.VinWithCountryCode{},
+		"SubmitVinVerificationParams": controllers.SubmitVinVerificationParams{},
+		"VinStatus":                   controllers.VinStatus{},
+		"StatusForVinsResponse":       controllers.StatusForVinsResponse{},
+		"Definition":                  models.Definition{},
+		"SyntheticDevice":             models.SyntheticDevice{},
+		"Vehicle":                     models.Vehicle{},
+		"VehicleListItem":             controllers.VehicleListItem{},
+		"VehiclesResponse":            controllers.VehiclesResponse{},
+		"VehicleResponse":             controllers.VehicleResponse{},
+		"VehicleRegisterPayload":      controllers.VehicleRegisterPayload{},
+		"SacdInput":                   controllers.SacdInput{},
+		"VinTransactionData":          controllers.VinTransactionData{},
+		"MintDataForVins":             controllers.MintDataForVins{},
+		"VinUserOperationData":        controllers.VinUserOperationData{},
+		"DisconnectDataForVins":       controllers.DisconnectDataForVins{},
+		"DeleteDataForVins":           controllers.DeleteDataForVins{},
+		"VinSacdData":                 controllers.VinSacdData{},
+		"SacdDataForVins":             controllers.SacdDataForVins{},
+		"VinSacdStatus":               controllers.VinSacdStatus{},
+		"SacdStatusForVinsResponse":   controllers.SacdStatusForVinsResponse{},
+		"VinDrift":                    controllers.VinDrift{},
+		"DriftReportResponse":         controllers.DriftReportResponse{},
+	}
+
+	schemas := s.spec["components"].(map[string]any)["schemas"].(map[string]any)
+	for name, value := range types {
+		schema, ok := schemas[name].(map[string]any)
+		if !s.Truef(ok, "schema %s is missing", name) {
+			continue
+		}
+		s.Equalf(jsonFields(reflect.TypeOf(value)), s.schemaProperties(schema), "properties of schema %s", name)
+	}
+}
+
+func (s *DocsTestSuite) TestRefsResolve() {
+	var walk func(node any)
+	walk = func(node any) {
+		switch n := node.(type) {
+		case map[string]any:
+			for k, v := range n {
+				if ref, ok := v.(string); ok && k == "$ref" {
+					s.NotNilf(s.resolve(ref), "unresolved %s", ref)
+				}
+				walk(v)
+			}
+		case []any:
+			for _, v := range n {
+				walk(v)
+			}
+		}
+	}
+	walk(s.spec)
+}
+
+func (s *DocsTestSuite) TestServesDocs() {
+	response, err := s.app.Test(httptest.NewRequest(fiber.MethodGet, docs.SpecPath, nil))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusOK, response.StatusCode)
+
+	body, err := io.ReadAll(response.Body)
+	s.Require().NoError(err)
+	s.Equal(docs.Spec(), body)
+
+	response, err = s.app.Test(httptest.NewRequest(fiber.MethodGet, docs.UIPath, nil))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusOK, response.StatusCode)
+
+	body, err = io.ReadAll(response.Body)
+	s.Require().NoError(err)
+	s.NotContains(string(body), "https://", "the UI must not load assets from other hosts")
+
+	for _, asset := range []string{"/swagger-ui.css", "/swagger-ui-bundle.js"} {
+		s.Contains(string(body), docs.AssetsPath+asset)
+
+		response, err = s.app.Test(httptest.NewRequest(fiber.MethodGet, docs.AssetsPath+asset, nil))
+		s.Require().NoError(err)
+		s.Equal(fiber.StatusOK, response.StatusCode, asset)
+	}
+}
+
+func (s *DocsTestSuite) resolve(ref string) any {
+	var node any = s.spec
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = m[part]
+	}
+	return node
+}
+
+// schemaProperties collects property names, including those of allOf members
+func (s *DocsTestSuite) schemaProperties(schema map[string]any) []string {
+	var names []string
+	properties, _ := schema["properties"].(map[string]any)
+	for name := range properties {
+		names = append(names, name)
+	}
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, member := range allOf {
+			m := member.(map[string]any)
+			if ref, ok := m["$ref"].(string); ok {
+				m = s.resolve(ref).(map[string]any)
+			}
+			names = append(names, s.schemaProperties(m)...)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonFields returns the JSON names of the struct fields, like encoding/json does for embedded structs and missing tags
+func jsonFields(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			names = append(names, jsonFields(field.Type)...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
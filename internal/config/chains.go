@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/yaml"
+	"github.com/ethereum/go-ethereum/common"
+	"net/url"
+	"os"
+)
+
+// DefaultChainProfileName names the profile built from the CHAIN_ID, RPC_URL, contract addresses etc. settings
+const DefaultChainProfileName = "default"
+
+// ChainProfile is a chain the oracle mints on: its DIMO contracts and the endpoints sending transactions to it.
+// One deployment can hold VINs on several chains, eg. Amoy testnet and Polygon mainnet, each VIN record stores its chain.
+type ChainProfile struct {
+	Name                string
+	ChainID             int64
+	RPCURL              url.URL
+	PaymasterURL        url.URL
+	BundlerURL          url.URL
+	RegistryAddress     common.Address
+	VehicleNftAddress   common.Address
+	SyntheticNftAddress common.Address
+}
+
+// chainProfileEntry is a profile as written in CHAIN_PROFILES_FILE
+type chainProfileEntry struct {
+	Name                string `yaml:"name"`
+	ChainID             int64  `yaml:"chainId"`
+	RPCURL              string `yaml:"rpcUrl"`
+	PaymasterURL        string `yaml:"paymasterUrl"`
+	BundlerURL          string `yaml:"bundlerUrl"`
+	RegistryAddress     string `yaml:"registryAddress"`
+	VehicleNftAddress   string `yaml:"vehicleNftAddress"`
+	SyntheticNftAddress string `yaml:"syntheticNftAddress"`
+}
+
+// ChainProfiles are the chains of the deployment, the default profile first
+type ChainProfiles []ChainProfile
+
+// Default returns the profile built from the chain settings
+func (p ChainProfiles) Default() ChainProfile {
+	return p[0]
+}
+
+// ByID returns the profile of the chain. 0 is the chain of records registered before profiles existed, the default one.
+func (p ChainProfiles) ByID(chainID int64) (ChainProfile, bool) {
+	if chainID == 0 {
+		return p.Default(), true
+	}
+	for _, profile := range p {
+		if profile.ChainID == chainID {
+			return profile, true
+		}
+	}
+	return ChainProfile{}, false
+}
+
+// LoadChainProfiles reads the additional chain profiles from a yaml file with a list of profiles
+func LoadChainProfiles(file string) ([]ChainProfile, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain profiles file: %w", err)
+	}
+
+	var entries []chainProfileEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse chain profiles file: %w", err)
+	}
+
+	profiles := make([]ChainProfile, 0, len(entries))
+	var errs []error
+	for i, entry := range entries {
+		profile, err := entry.profile()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("chain profile %d %q: %w", i+1, entry.Name, err))
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, errors.Join(errs...)
+}
+
+func (e chainProfileEntry) profile() (ChainProfile, error) {
+	profile := ChainProfile{Name: e.Name, ChainID: e.ChainID}
+
+	var errs []error
+	for _, u := range []struct {
+		key   string
+		raw   string
+		value *url.URL
+	}{
+		{"rpcUrl", e.RPCURL, &profile.RPCURL},
+		{"paymasterUrl", e.PaymasterURL, &profile.PaymasterURL},
+		{"bundlerUrl", e.BundlerURL, &profile.BundlerURL},
+	} {
+		parsed, err := url.Parse(u.raw)
+		if err != nil {
+			// the URLs hold API keys, don't echo them
+			errs = append(errs, fmt.Errorf("%s is not a URL", u.key))
+			continue
+		}
+		*u.value = *parsed
+	}
+
+	for _, a := range []struct {
+		key   string
+		raw   string
+		value *common.Address
+	}{
+		{"registryAddress", e.RegistryAddress, &profile.RegistryAddress},
+		{"vehicleNftAddress", e.VehicleNftAddress, &profile.VehicleNftAddress},
+		{"syntheticNftAddress", e.SyntheticNftAddress, &profile.SyntheticNftAddress},
+	} {
+		if !common.IsHexAddress(a.raw) {
+			errs = append(errs, fmt.Errorf("%s %q is not a hex address", a.key, a.raw))
+			continue
+		}
+		*a.value = common.HexToAddress(a.raw)
+	}
+
+	return profile, errors.Join(errs...)
+}
+
+// DefaultChainProfile is the chain of the CHAIN_ID, RPC_URL, PAYMASTER_URL, BUNDLER_URL and contract address settings.
+// VIN records without a chain, registered before profiles existed, are on this chain.
+func (s *Settings) DefaultChainProfile() ChainProfile {
+	return ChainProfile{
+		Name:                DefaultChainProfileName,
+		ChainID:             s.ChainID,
+		RPCURL:              s.RPCURL,
+		PaymasterURL:        s.PaymasterURL,
+		BundlerURL:          s.BundlerURL,
+		RegistryAddress:     s.RegistryAddress,
+		VehicleNftAddress:   s.VehicleNftAddress,
+		SyntheticNftAddress: s.SyntheticNftAddress,
+	}
+}
+
+// ChainProfiles returns the default chain profile followed by the ones of CHAIN_PROFILES_FILE, if set
+func (s *Settings) ChainProfiles() (ChainProfiles, error) {
+	profiles := ChainProfiles{s.DefaultChainProfile()}
+	if s.ChainProfilesFile == "" {
+		return profiles, nil
+	}
+
+	additional, err := LoadChainProfiles(s.ChainProfilesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(profiles, additional...), nil
+}
+
+// WithChainProfile returns a copy of the settings using the chain and contracts of the profile
+func (s Settings) WithChainProfile(profile ChainProfile) Settings {
+	s.ChainID = profile.ChainID
+	s.RPCURL = profile.RPCURL
+	s.PaymasterURL = profile.PaymasterURL
+	s.BundlerURL = profile.BundlerURL
+	s.RegistryAddress = profile.RegistryAddress
+	s.VehicleNftAddress = profile.VehicleNftAddress
+	s.SyntheticNftAddress = profile.SyntheticNftAddress
+	return s
+}
@@ -0,0 +1,302 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/yaml"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Sources of the effective value of a setting, besides the path of the settings file
+const (
+	SourceDefault = "default"
+	SourceEnv     = "env"
+	// SourceSecretFile is followed by the path, eg. "file:/secrets/developer-pk"
+	SourceSecretFile = "file"
+)
+
+const redacted = "[redacted]"
+
+// DB settings come from the shared package, so their secret can't be tagged
+var untaggedSecrets = map[string]bool{"DB_PASSWORD": true}
+
+// Sources maps the key of each setting, eg. DB_HOST, to where its effective value came from
+type Sources map[string]string
+
+// Entry is the effective value of a setting, redacted for secrets, and where it came from
+type Entry struct {
+	Key    string
+	Value  string
+	Source string
+	Secret bool
+}
+
+type field struct {
+	key    string
+	value  reflect.Value
+	secret bool
+}
+
+// Load reads the settings file, when it exists, and overrides every setting with its env var, eg. DEVELOPER_PK,
+// or with the content of the file named by its _FILE env var, eg. DEVELOPER_PK_FILE=/secrets/developer-pk for mounted
+// secrets. Nested settings use flat keys, eg. DB_HOST, in the file too. Every parse error is reported at once.
+func Load(path string) (Settings, Sources, error) {
+	var settings Settings
+	sources := make(Sources)
+
+	values, err := readFile(path)
+	if err != nil {
+		return settings, sources, err
+	}
+
+	var errs []error
+	for _, f := range settings.fields() {
+		raw, source, err := lookup(f.key, values, path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sources[f.key] = source
+		if source == SourceDefault {
+			continue
+		}
+		if err := setField(f.value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s from %s: %w", f.key, source, err))
+		}
+	}
+
+	return settings, sources, errors.Join(errs...)
+}
+
+// readFile returns the scalars of the settings file as written, so eg. a quoted port or a short hex address isn't
+// turned into a number. A missing file has no values, deployments only use env vars.
+func readFile(path string) (map[string]any, error) {
+	file, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(file, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(root.Content) == 0 {
+		return map[string]any{}, nil
+	}
+	return mappingValues(root.Content[0]), nil
+}
+
+func mappingValues(node *yaml.Node) map[string]any {
+	values := make(map[string]any)
+	if node.Kind != yaml.MappingNode {
+		return values
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case value.Kind == yaml.MappingNode:
+			values[key] = mappingValues(value)
+		case value.Kind == yaml.ScalarNode && value.ShortTag() != "!!null":
+			values[key] = value.Value
+		}
+	}
+	return values
+}
+
+// lookup returns the raw value of the setting from the highest priority source: its _FILE env var, its env var,
+// then the settings file
+func lookup(key string, values map[string]any, path string) (string, string, error) {
+	filePath, hasFile := os.LookupEnv(key + "_FILE")
+	env, hasEnv := os.LookupEnv(key)
+
+	switch {
+	case hasFile && hasEnv:
+		return "", "", fmt.Errorf("%s and %s_FILE are both set", key, key)
+	case hasFile:
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return "", "", fmt.Errorf("%s_FILE: %w", key, err)
+		}
+		// mounted secrets and files written by editors usually end with a newline
+		return strings.TrimRight(string(content), "\r\n"), SourceSecretFile + ":" + filePath, nil
+	case hasEnv:
+		return env, SourceEnv, nil
+	}
+
+	if value, ok := yamlValue(values, key); ok {
+		return value, path, nil
+	}
+	return "", SourceDefault, nil
+}
+
+// yamlValue finds the key flat, eg. DB_HOST, or nested, eg. HOST under DB
+func yamlValue(values map[string]any, key string) (string, bool) {
+	if value, ok := values[key].(string); ok {
+		return value, true
+	}
+
+	parent, child, ok := strings.Cut(key, "_")
+	for ok {
+		if nested, isMap := values[parent].(map[string]any); isMap {
+			if value, found := yamlValue(nested, child); found {
+				return value, true
+			}
+		}
+		var next string
+		next, child, ok = strings.Cut(child, "_")
+		parent += "_" + next
+	}
+	return "", false
+}
+
+func setField(value reflect.Value, raw string) error {
+	switch value.Interface().(type) {
+	case url.URL:
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(*parsed))
+		return nil
+	case common.Address:
+		// placeholders of the sample settings, Validate reports them when the address is needed
+		if raw == "" || raw == "0x" {
+			value.Set(reflect.ValueOf(common.Address{}))
+			return nil
+		}
+		if !common.IsHexAddress(raw) {
+			return errors.New("not a hex address")
+		}
+		value.Set(reflect.ValueOf(common.HexToAddress(raw)))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	default:
+		return fmt.Errorf("unsupported setting type %s", value.Type())
+	}
+	return nil
+}
+
+// fields lists the settings with their flat keys, nested structs are prefixed with their own key
+func (s *Settings) fields() []field {
+	var fields []field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		for i := 0; i < v.NumField(); i++ {
+			structField := v.Type().Field(i)
+			key := structField.Tag.Get("yaml")
+			if key == "" || key == "-" {
+				continue
+			}
+			key = prefix + key
+
+			value := v.Field(i)
+			switch value.Interface().(type) {
+			case url.URL, common.Address:
+			default:
+				if value.Kind() == reflect.Struct {
+					walk(value, key+"_")
+					continue
+				}
+			}
+
+			fields = append(fields, field{
+				key:    key,
+				value:  value,
+				secret: structField.Tag.Get("secret") == "true" || untaggedSecrets[key],
+			})
+		}
+	}
+	walk(reflect.ValueOf(s).Elem(), "")
+
+	return fields
+}
+
+// Entries returns every setting with its value, secrets redacted, and its source when sources is not nil
+func (s *Settings) Entries(sources Sources) []Entry {
+	fields := s.fields()
+	entries := make([]Entry, 0, len(fields))
+	for _, f := range fields {
+		entries = append(entries, Entry{
+			Key:    f.key,
+			Value:  displayValue(f),
+			Source: sources[f.key],
+			Secret: f.secret,
+		})
+	}
+	return entries
+}
+
+func displayValue(f field) string {
+	var value string
+	switch v := f.value.Interface().(type) {
+	case url.URL:
+		if f.secret && v.Host != "" {
+			// the host tells which provider is used, the path and query usually hold the API key
+			return v.Scheme + "://" + v.Host + "/" + redacted
+		}
+		value = v.String()
+	case common.Address:
+		value = v.Hex()
+	default:
+		value = fmt.Sprint(v)
+	}
+
+	switch {
+	case value == "":
+		return ""
+	case f.secret:
+		return redacted
+	case strings.Contains(value, "\n"):
+		// certificates
+		firstLine, _, _ := strings.Cut(value, "\n")
+		return fmt.Sprintf("%s ... (%d bytes)", firstLine, len(value))
+	}
+	return value
+}
+
+// MarshalZerologObject logs the settings with secrets redacted
+func (s *Settings) MarshalZerologObject(e *zerolog.Event) {
+	for _, entry := range s.Entries(nil) {
+		e.Str(entry.Key, entry.Value)
+	}
+}
+
+// String formats the settings with secrets redacted, so they can't leak through %v
+func (s Settings) String() string {
+	entries := s.Entries(nil)
+	parts := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		parts = append(parts, entry.Key+"="+entry.Value)
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// GoString formats the settings with secrets redacted for %#v
+func (s Settings) GoString() string {
+	return "config.Settings" + s.String()
+}
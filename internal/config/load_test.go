@@ -0,0 +1,84 @@
+package config
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type LoadTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestLoadTestSuite(t *testing.T) {
+	suite.Run(t, new(LoadTestSuite))
+}
+
+func (s *LoadTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+func (s *LoadTestSuite) write(name, content string) string {
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func (s *LoadTestSuite) TestSources() {
+	path := s.write("settings.yaml", `
+LOG_LEVEL: info
+PORT: '8080'
+CHAIN_ID: 137
+DB_HOST: localhost
+REGISTRY_ADDRESS: '0xFA8beC73cebB9D88FF88a2f75E7D7312f2Fd39EC'
+DEVELOPER_PK: from-yaml
+`)
+	s.T().Setenv("LOG_LEVEL", "debug")
+	s.T().Setenv("DEVELOPER_PK_FILE", s.write("developer-pk", "abc123\n"))
+
+	settings, sources, err := Load(path)
+	s.Require().NoError(err)
+
+	s.Equal("debug", settings.LogLevel)
+	s.Equal(SourceEnv, sources["LOG_LEVEL"])
+	s.Equal("abc123", settings.DeveloperPK)
+	s.True(strings.HasPrefix(sources["DEVELOPER_PK"], SourceSecretFile+":"))
+	s.Equal("8080", settings.Port)
+	s.Equal(int64(137), settings.ChainID)
+	s.Equal(common.HexToAddress("0xFA8beC73cebB9D88FF88a2f75E7D7312f2Fd39EC"), settings.RegistryAddress)
+	// nested settings use flat keys in the file
+	s.Equal("localhost", settings.DB.Host)
+	s.Equal(path, sources["DB_HOST"])
+	s.Equal(SourceDefault, sources["MONITORING_PORT"])
+}
+
+func (s *LoadTestSuite) TestReportsEveryError() {
+	path := s.write("settings.yaml", "CHAIN_ID: polygon\nENABLE_SACD_CHECK: maybe\n")
+	s.T().Setenv("CERT", "inline")
+	s.T().Setenv("CERT_FILE", "/secrets/cert")
+
+	_, _, err := Load(path)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "CHAIN_ID")
+	s.Contains(err.Error(), "ENABLE_SACD_CHECK")
+	s.Contains(err.Error(), "CERT and CERT_FILE are both set")
+}
+
+func (s *LoadTestSuite) TestSecretsAreRedacted() {
+	s.T().Setenv("SD_WALLETS_SEED", "123e5901b5814d1237a39af36ca123d69bdb3c938ebf123c869f112357f20123")
+	s.T().Setenv("RPC_URL", "https://polygon-mainnet.g.alchemy.com/v2/api-key")
+	s.T().Setenv("DB_PASSWORD", "dimo")
+
+	settings, _, err := Load(filepath.Join(s.dir, "missing.yaml"))
+	s.Require().NoError(err)
+
+	printed := settings.String()
+	s.NotContains(printed, "123e5901")
+	s.NotContains(printed, "api-key")
+	s.NotContains(printed, "PASSWORD=dimo")
+	s.Contains(printed, "RPC_URL=https://polygon-mainnet.g.alchemy.com/[redacted]")
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// reloadDebounce groups the events of one save, editors write, rename and chmod the file in a row
+const reloadDebounce = 500 * time.Millisecond
+
+// reloadable are the settings applied without a restart, changes to any other setting are rejected
+var reloadable = map[string]bool{
+	"LOG_LEVEL":                      true,
+	"ENABLE_VENDOR_CAPABILITY_CHECK": true,
+	"ENABLE_VENDOR_CONNECTION":       true,
+	"CORS_ALLOWED_ORIGINS":           true,
+}
+
+// Provider holds the current settings and reloads their reloadable fields when the settings file changes or on
+// SIGHUP. Components that use a reloadable setting subscribe to it instead of keeping their own copy.
+type Provider struct {
+	path   string
+	logger zerolog.Logger
+
+	current atomic.Pointer[Settings]
+	// mu serializes reloads and subscriptions, so subscribers see every change in order
+	mu          sync.Mutex
+	subscribers []func(settings *Settings)
+}
+
+// NewProvider starts from the settings loaded from path, before any override like the process mode
+func NewProvider(path string, settings Settings, logger zerolog.Logger) *Provider {
+	p := &Provider{
+		path:   path,
+		logger: logger.With().Str("component", "settings").Logger(),
+	}
+	p.current.Store(&settings)
+	return p
+}
+
+// Current returns the current settings, they are swapped on reload and must not be modified
+func (p *Provider) Current() *Settings {
+	return p.current.Load()
+}
+
+// Subscribe calls fn with the current settings right away, then with the new settings after each reload that
+// changes a reloadable setting
+func (p *Provider) Subscribe(fn func(settings *Settings)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.subscribers = append(p.subscribers, fn)
+	fn(p.current.Load())
+}
+
+// Reload loads the settings again and swaps the current ones with the changed reloadable fields. Changes to the
+// other settings are logged and ignored, they only take effect on restart.
+func (p *Provider) Reload() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	loaded, _, err := Load(p.path)
+	if err != nil {
+		return err
+	}
+
+	next := *p.current.Load()
+	// both lists walk the same struct, so the fields are in the same order
+	nextFields, loadedFields := next.fields(), loaded.fields()
+	var changed, rejected []string
+	for i, f := range loadedFields {
+		if reflect.DeepEqual(f.value.Interface(), nextFields[i].value.Interface()) {
+			continue
+		}
+		if !reloadable[f.key] {
+			rejected = append(rejected, f.key)
+			continue
+		}
+		nextFields[i].value.Set(f.value)
+		changed = append(changed, f.key)
+	}
+
+	if len(rejected) > 0 {
+		p.logger.Warn().Strs("settings", rejected).Msg("Ignoring changed settings that can't be reloaded, restart to apply them")
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if _, err := zerolog.ParseLevel(next.LogLevel); err != nil {
+		return fmt.Errorf("LOG_LEVEL %q is not a log level", next.LogLevel)
+	}
+
+	p.current.Store(&next)
+	p.logger.Info().Strs("settings", changed).Msg("Reloaded settings")
+	for _, fn := range p.subscribers {
+		fn(&next)
+	}
+	return nil
+}
+
+// Watch reloads the settings when the settings file changes or the process gets SIGHUP, until ctx is done
+func (p *Provider) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create settings watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// editors and config map mounts replace the file instead of writing to it, which drops a watch on the file
+	dir, name := filepath.Split(p.path)
+	if dir == "" {
+		dir = "."
+	}
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// config map mounts swap the ..data symlink the file points to
+			if base := filepath.Base(event.Name); base == name || base == "..data" {
+				debounce = time.After(reloadDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			p.logger.Warn().Err(err).Msg("Settings watcher failed")
+		case <-hangup:
+			p.reload()
+		case <-debounce:
+			debounce = nil
+			p.reload()
+		}
+	}
+}
+
+func (p *Provider) reload() {
+	if err := p.Reload(); err != nil {
+		p.logger.Error().Err(err).Msg("Failed to reload settings, keeping the current ones")
+	}
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type ProviderTestSuite struct {
+	suite.Suite
+	path string
+}
+
+func TestProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(ProviderTestSuite))
+}
+
+func (s *ProviderTestSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "settings.yaml")
+}
+
+func (s *ProviderTestSuite) write(content string) {
+	s.Require().NoError(os.WriteFile(s.path, []byte(content), 0o600))
+}
+
+func (s *ProviderTestSuite) load() *Provider {
+	settings, _, err := Load(s.path)
+	s.Require().NoError(err)
+	return NewProvider(s.path, settings, zerolog.Nop())
+}
+
+func (s *ProviderTestSuite) TestReloadsReloadableSettings() {
+	s.write("LOG_LEVEL: info\nENABLE_VENDOR_CONNECTION: false\nCORS_ALLOWED_ORIGINS: https://a.example\n")
+	provider := s.load()
+
+	var received []*Settings
+	provider.Subscribe(func(settings *Settings) {
+		received = append(received, settings)
+	})
+	s.Require().Len(received, 1)
+
+	s.write("LOG_LEVEL: debug\nENABLE_VENDOR_CONNECTION: true\nCORS_ALLOWED_ORIGINS: https://a.example,https://b.example\n")
+	s.Require().NoError(provider.Reload())
+
+	s.Require().Len(received, 2)
+	s.Equal("debug", received[1].LogLevel)
+	s.True(received[1].EnableVendorConnection)
+	s.Equal("https://a.example,https://b.example", received[1].CorsAllowedOrigins)
+	s.Same(received[1], provider.Current())
+	// subscribers keep the snapshot they were given
+	s.Equal("info", received[0].LogLevel)
+}
+
+func (s *ProviderTestSuite) TestRejectsOtherSettings() {
+	s.write("LOG_LEVEL: info\nPORT: '8080'\nCHAIN_ID: 80002\n")
+	provider := s.load()
+
+	calls := 0
+	provider.Subscribe(func(*Settings) { calls++ })
+
+	s.write("LOG_LEVEL: warn\nPORT: '9090'\nCHAIN_ID: 137\n")
+	s.Require().NoError(provider.Reload())
+
+	s.Equal(2, calls)
+	s.Equal("warn", provider.Current().LogLevel)
+	s.Equal("8080", provider.Current().Port)
+	s.Equal(int64(80002), provider.Current().ChainID)
+
+	// only rejected changes don't notify
+	s.write("LOG_LEVEL: warn\nPORT: '9191'\nCHAIN_ID: 137\n")
+	s.Require().NoError(provider.Reload())
+	s.Equal(2, calls)
+}
+
+func (s *ProviderTestSuite) TestKeepsCurrentSettingsOnInvalidReload() {
+	s.write("LOG_LEVEL: info\n")
+	provider := s.load()
+
+	s.write("LOG_LEVEL: loud\n")
+	s.Error(provider.Reload())
+	s.Equal("info", provider.Current().LogLevel)
+
+	s.write("LOG_LEVEL: info\nCHAIN_ID: polygon\n")
+	s.Error(provider.Reload())
+}
+
+func (s *ProviderTestSuite) TestWatchReloadsOnWrite() {
+	s.write("ENABLE_VENDOR_CAPABILITY_CHECK: false\n")
+	provider := s.load()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- provider.Watch(ctx) }()
+	// give the watcher time to be added before writing
+	time.Sleep(100 * time.Millisecond)
+
+	s.write("ENABLE_VENDOR_CAPABILITY_CHECK: true\n")
+	s.Eventually(func() bool {
+		return provider.Current().EnableVendorCapabilityCheck
+	}, 5*time.Second, 50*time.Millisecond)
+
+	cancel()
+	s.NoError(<-done)
+}
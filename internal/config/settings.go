@@ -4,22 +4,28 @@ import (
 	"github.com/DIMO-Network/shared/pkg/db"
 	"github.com/ethereum/go-ethereum/common"
 	"net/url"
+	"strings"
 )
 
-// Settings contains the application config
+// Settings contains the application config. Every setting can be overridden with its env var, or with a file
+// through its _FILE env var. Settings tagged secret are redacted when logged or printed. The settings commented as
+// reloadable are applied without a restart, see Provider.
 type Settings struct {
-	Environment    string      `yaml:"ENVIRONMENT"`
-	LogLevel       string      `yaml:"LOG_LEVEL"`
-	Port           string      `yaml:"PORT"`
-	MonitoringPort string      `yaml:"MONITORING_PORT"`
-	DB             db.Settings `yaml:"DB"`              // should be secrets
-	JwtKeySetURL   string      `yaml:"JWT_KEY_SET_URL"` // DIMO JWT key set.
+	Environment        string      `yaml:"ENVIRONMENT"`
+	LogLevel           string      `yaml:"LOG_LEVEL"` // reloadable
+	Port               string      `yaml:"PORT"`
+	MonitoringPort     string      `yaml:"MONITORING_PORT"`
+	DB                 db.Settings `yaml:"DB"`                   // should be secrets
+	JwtKeySetURL       string      `yaml:"JWT_KEY_SET_URL"`      // DIMO JWT key set.
+	Mode               string      `yaml:"MODE"`                 // all (default), serve-api, run-workers, consume-telemetry or consume-operations
+	CorsAllowedOrigins string      `yaml:"CORS_ALLOWED_ORIGINS"` // comma separated, reloadable, defaults to https://localdev.dimo.org:3008
+	AdminWallets       string      `yaml:"ADMIN_WALLETS"`        // comma separated wallets allowed on admin endpoints, eg. the drift report. None when empty
 
 	// Just an example - Communication and Auth with your external system. Should all be secrets
-	ExternalVendorAPIURL string `yaml:"EXTERNAL_VENDOR_APIURL"` // your system's api url
-	ClientID             string `yaml:"CLIENT_ID"`              // auth client id example
-	ClientSecret         string `yaml:"CLIENT_SECRET"`          // auth secret example
-	Audience             string `yaml:"AUDIENCE"`               // some other parameter you may need
+	ExternalVendorAPIURL string `yaml:"EXTERNAL_VENDOR_APIURL"`      // your system's api url
+	ClientID             string `yaml:"CLIENT_ID"`                   // auth client id example
+	ClientSecret         string `yaml:"CLIENT_SECRET" secret:"true"` // auth secret example
+	Audience             string `yaml:"AUDIENCE"`                    // some other parameter you may need
 
 	// Kafka - in this example we stream from kafka
 	IsTelemetryConsumerEnabled  bool   `yaml:"IS_TELEMETRY_CONSUMER_ENABLED"`
@@ -36,49 +42,102 @@ type Settings struct {
 
 	// DIS - DIMO Ingest Service
 	DimoNodeEndpoint string `yaml:"DIMO_NODE_ENDPOINT"`
-	Cert             string `yaml:"CERT"`     // should be secrets
-	CertKey          string `yaml:"CERT_KEY"` // should be secrets
-	CACert           string `yaml:"CA_CERT"`  // DIMO Root CA, same for everybody
+	Cert             string `yaml:"CERT"`                   // should be secrets
+	CertKey          string `yaml:"CERT_KEY" secret:"true"` // should be secrets
+	CACert           string `yaml:"CA_CERT"`                // DIMO Root CA, same for everybody
 
 	// Chain - These are standard Polygon values for DIMO
 	ChainID             int64          `yaml:"CHAIN_ID"`
 	VehicleNftAddress   common.Address `yaml:"VEHICLE_NFT_ADDRESS"`
 	SyntheticNftAddress common.Address `yaml:"SYNTHETIC_NFT_ADDRESS"`
+	// ChainProfilesFile is a yaml list of additional chains to mint on, the settings above are the default chain.
+	// Profiles hold RPC, paymaster and bundler URLs with API keys, so mount the file as a secret.
+	ChainProfilesFile string `yaml:"CHAIN_PROFILES_FILE"`
 
 	// Identity-api - DIMO Identity Service api
 	IdentityAPIEndpoint url.URL `yaml:"IDENTITY_API_ENDPOINT"`
 
 	// Device-Definitions API - DIMO Device Definitions Service api - used for Decoding VINs
 	DeviceDefinitionsAPIEndpoint url.URL `yaml:"DEVICE_DEFINITIONS_API_ENDPOINT"`
+	// VinDecoderOverridesFile is a yaml list of VIN patterns to definition IDs, used when the remote decoder fails or finds no match
+	VinDecoderOverridesFile string `yaml:"VIN_DECODER_OVERRIDES_FILE"`
 
 	// Transactions SDK
 	DeveloperAAWalletAddress common.Address `yaml:"DEVELOPER_AA_WALLET_ADDRESS"` // should be secret - dimo can generate for you
-	DeveloperPK              string         `yaml:"DEVELOPER_PK"`                // should be secret (private key) - used for signing transactions
-	RPCURL                   url.URL        `yaml:"RPC_URL"`                     // eg alchemy URL, secret since it contains your API Key
-	PaymasterURL             url.URL        `yaml:"PAYMASTER_URL"`               // eg. zerodev, secret since it contains your API Key
-	BundlerURL               url.URL        `yaml:"BUNDLER_URL"`                 // eg. zerodev, secret since it contains your API Key
+	DeveloperPK              string         `yaml:"DEVELOPER_PK" secret:"true"`  // should be secret (private key) - used for signing transactions
+	RPCURL                   url.URL        `yaml:"RPC_URL" secret:"true"`       // eg alchemy URL, secret since it contains your API Key
+	PaymasterURL             url.URL        `yaml:"PAYMASTER_URL" secret:"true"` // eg. zerodev, secret since it contains your API Key
+	BundlerURL               url.URL        `yaml:"BUNDLER_URL" secret:"true"`   // eg. zerodev, secret since it contains your API Key
 	RegistryAddress          common.Address `yaml:"REGISTRY_ADDRESS"`            // standard Polygon registry address for DIMO
 
 	// DIMO Auth - uses your dev console client ID and secret to authenticate with DIMO Auth to get JWT's for authenticated API calls.
 	DimoAuthURL        url.URL        `yaml:"DIMO_AUTH_URL"`
 	DimoAuthClientID   common.Address `yaml:"DIMO_AUTH_CLIENT_ID"`
 	DimoAuthDomain     url.URL        `yaml:"DIMO_AUTH_DOMAIN"`
-	DimoAuthPrivateKey string         `yaml:"DIMO_AUTH_PRIVATE_KEY"` // should be secret
+	DimoAuthPrivateKey string         `yaml:"DIMO_AUTH_PRIVATE_KEY" secret:"true"` // should be secret
 
 	// SD Wallats
-	SDWalletsSeed string `yaml:"SD_WALLETS_SEED"` // should be secret, used for minting Synthetic Devices
+	SDWalletsSeed string `yaml:"SD_WALLETS_SEED" secret:"true"` // should be secret, used for minting Synthetic Devices
 
 	// Minting
 	EnableMintingWithConnectionTokenID bool   `yaml:"ENABLE_MINTING_WITH_CONNECTION_TOKEN_ID"`
 	ConnectionTokenID                  string `yaml:"CONNECTION_TOKEN_ID"`
 	IntegrationTokenID                 string `yaml:"INTEGRATION_TOKEN_ID"`
 
-	// Onboarding - can be useful to disable this for local testing / debugging
+	// Onboarding - can be useful to disable this for local testing / debugging, the first two are reloadable
 	EnableVendorCapabilityCheck bool `yaml:"ENABLE_VENDOR_CAPABILITY_CHECK"`
 	EnableVendorConnection      bool `yaml:"ENABLE_VENDOR_CONNECTION"`
 	EnableVendorTestMode        bool `yaml:"ENABLE_VENDOR_TEST_MODE"`
+
+	// Reconciliation - periodically compares the vins table with on-chain state from Identity API
+	EnableReconciliation          bool `yaml:"ENABLE_RECONCILIATION"`
+	ReconciliationIntervalMinutes int  `yaml:"RECONCILIATION_INTERVAL_MINUTES"` // defaults to 60
+	ReconciliationPageSize        int  `yaml:"RECONCILIATION_PAGE_SIZE"`        // defaults to 100
+	ReconciliationAutoFix         bool `yaml:"RECONCILIATION_AUTO_FIX"`         // when false, mismatches are only flagged
+
+	// Stuck jobs - periodically recovers VINs left pending by mint, disconnect and delete jobs that died with their pod
+	EnableStuckJobRecovery   bool `yaml:"ENABLE_STUCK_JOB_RECOVERY"`
+	StuckJobIntervalMinutes  int  `yaml:"STUCK_JOB_INTERVAL_MINUTES"`  // defaults to 15
+	StuckJobThresholdMinutes int  `yaml:"STUCK_JOB_THRESHOLD_MINUTES"` // defaults to 60, keep it above the 30 minutes job timeout
+
+	// River queues - verify, chain and vendor jobs are worked on separate queues, empty values use the defaults
+	VerifyQueueMaxWorkers         int `yaml:"VERIFY_QUEUE_MAX_WORKERS"`           // defaults to 20
+	VerifyQueuePriority           int `yaml:"VERIFY_QUEUE_PRIORITY"`              // 1 (highest, default) to 4
+	VerifyQueueRateLimitPerMinute int `yaml:"VERIFY_QUEUE_RATE_LIMIT_PER_MINUTE"` // jobs started per minute per instance, 0 is unlimited
+	ChainQueueMaxWorkers          int `yaml:"CHAIN_QUEUE_MAX_WORKERS"`            // defaults to 10
+	ChainQueuePriority            int `yaml:"CHAIN_QUEUE_PRIORITY"`
+	ChainQueueRateLimitPerMinute  int `yaml:"CHAIN_QUEUE_RATE_LIMIT_PER_MINUTE"`
+	VendorQueueMaxWorkers         int `yaml:"VENDOR_QUEUE_MAX_WORKERS"` // defaults to 20
+	VendorQueuePriority           int `yaml:"VENDOR_QUEUE_PRIORITY"`
+	VendorQueueRateLimitPerMinute int `yaml:"VENDOR_QUEUE_RATE_LIMIT_PER_MINUTE"`
+
+	// SACD - only forward telemetry the vehicle owner has granted to SACD_GRANTEE (usually your Developer License client id)
+	EnableSacdCheck bool           `yaml:"ENABLE_SACD_CHECK"`
+	SacdGrantee     common.Address `yaml:"SACD_GRANTEE"`
+
+	// Ownership transfers - what to do when the vehicle NFT gets a new owner outside of this oracle
+	TransferPolicy string `yaml:"TRANSFER_POLICY"` // notify (default), pause or disconnect
+
+	// Device definitions cache - definitions are stored in the DB and shared by the API and workers
+	DeviceDefinitionCacheTTLMinutes     int `yaml:"DEVICE_DEFINITION_CACHE_TTL_MINUTES"`     // defaults to 1440
+	DeviceDefinitionRefreshAheadMinutes int `yaml:"DEVICE_DEFINITION_REFRESH_AHEAD_MINUTES"` // defaults to 60, refreshed in background when this close to expiration
+
+	// Health - /health/ready checks the dependencies of the process mode, results are cached between probes
+	HealthCheckTimeoutSeconds int `yaml:"HEALTH_CHECK_TIMEOUT_SECONDS"` // defaults to 5, per dependency
+	HealthCheckCacheSeconds   int `yaml:"HEALTH_CHECK_CACHE_SECONDS"`   // defaults to 10
 }
 
 func (s *Settings) IsProduction() bool {
 	return s.Environment == "prod" // this string is set in the helm chart values-prod.yaml
 }
+
+// AdminWalletList returns the ADMIN_WALLETS entries, as written
+func (s *Settings) AdminWalletList() []string {
+	var wallets []string
+	for _, wallet := range strings.Split(s.AdminWallets, ",") {
+		if wallet = strings.TrimSpace(wallet); wallet != "" {
+			wallets = append(wallets, wallet)
+		}
+	}
+	return wallets
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog"
+	"math/big"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Process modes, so the API, the river workers and the Kafka consumers can be scaled independently
+const (
+	ModeAll               = "all"
+	ModeServeAPI          = "serve-api"
+	ModeRunWorkers        = "run-workers"
+	ModeConsumeTelemetry  = "consume-telemetry"
+	ModeConsumeOperations = "consume-operations"
+)
+
+var Modes = []string{ModeAll, ModeServeAPI, ModeRunWorkers, ModeConsumeTelemetry, ModeConsumeOperations}
+
+// ValidationError lists every problem found in the settings, so they can be fixed in one go
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid settings: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks the settings needed by the components of MODE (all when empty), reporting every problem at once.
+// It only checks values locally, the doctor command checks them against the dependencies.
+func (s *Settings) Validate() error {
+	v := &validation{}
+
+	mode := s.Mode
+	if mode == "" {
+		mode = ModeAll
+	}
+	if !slices.Contains(Modes, mode) {
+		v.addf("MODE %q is not one of %s", s.Mode, strings.Join(Modes, ", "))
+	}
+
+	runAPI := mode == ModeAll || mode == ModeServeAPI
+	runWorkers := mode == ModeAll || mode == ModeRunWorkers
+	consumeTelemetry := mode == ModeConsumeTelemetry || (mode == ModeAll && s.IsTelemetryConsumerEnabled)
+	consumeOperations := mode == ModeConsumeOperations || (mode == ModeAll && s.IsOperationsConsumerEnabled)
+
+	if _, err := zerolog.ParseLevel(s.LogLevel); err != nil {
+		v.addf("LOG_LEVEL %q is not a log level", s.LogLevel)
+	}
+	v.port("MONITORING_PORT", s.MonitoringPort)
+	v.required("DB_HOST", s.DB.Host)
+	v.required("DB_NAME", s.DB.Name)
+	v.required("DB_USER", s.DB.User)
+	v.url("IDENTITY_API_ENDPOINT", s.IdentityAPIEndpoint)
+
+	if runAPI {
+		v.port("PORT", s.Port)
+		v.required("JWT_KEY_SET_URL", s.JwtKeySetURL)
+		for _, wallet := range s.AdminWalletList() {
+			if !common.IsHexAddress(wallet) {
+				v.addf("ADMIN_WALLETS %q is not a hex address", wallet)
+			}
+		}
+	}
+
+	if runAPI || runWorkers {
+		if s.ChainID <= 0 {
+			v.addf("CHAIN_ID must be set")
+		}
+		v.url("RPC_URL", s.RPCURL)
+		v.url("PAYMASTER_URL", s.PaymasterURL)
+		v.url("BUNDLER_URL", s.BundlerURL)
+		v.address("REGISTRY_ADDRESS", s.RegistryAddress)
+		v.address("VEHICLE_NFT_ADDRESS", s.VehicleNftAddress)
+		v.address("SYNTHETIC_NFT_ADDRESS", s.SyntheticNftAddress)
+		v.address("DEVELOPER_AA_WALLET_ADDRESS", s.DeveloperAAWalletAddress)
+		v.privateKey("DEVELOPER_PK", s.DeveloperPK)
+
+		if seed := common.FromHex(s.SDWalletsSeed); len(seed) != hdkeychain.RecommendedSeedLen {
+			v.addf("SD_WALLETS_SEED must be %d hex encoded bytes, got %d", hdkeychain.RecommendedSeedLen, len(seed))
+		}
+
+		if s.EnableMintingWithConnectionTokenID {
+			v.tokenID("CONNECTION_TOKEN_ID", s.ConnectionTokenID)
+		} else {
+			v.tokenID("INTEGRATION_TOKEN_ID", s.IntegrationTokenID)
+		}
+	}
+
+	if runWorkers {
+		v.url("DEVICE_DEFINITIONS_API_ENDPOINT", s.DeviceDefinitionsAPIEndpoint)
+		v.url("DIMO_AUTH_URL", s.DimoAuthURL)
+		v.url("DIMO_AUTH_DOMAIN", s.DimoAuthDomain)
+		v.address("DIMO_AUTH_CLIENT_ID", s.DimoAuthClientID)
+		v.privateKey("DIMO_AUTH_PRIVATE_KEY", s.DimoAuthPrivateKey)
+
+		if s.VinDecoderOverridesFile != "" {
+			if _, err := os.Stat(s.VinDecoderOverridesFile); err != nil {
+				v.addf("VIN_DECODER_OVERRIDES_FILE: %v", err)
+			}
+		}
+
+		switch s.TransferPolicy {
+		case "", "notify", "pause", "disconnect":
+		default:
+			v.addf("TRANSFER_POLICY %q is not notify, pause or disconnect", s.TransferPolicy)
+		}
+
+		v.queue("VERIFY", s.VerifyQueueMaxWorkers, s.VerifyQueuePriority, s.VerifyQueueRateLimitPerMinute)
+		v.queue("CHAIN", s.ChainQueueMaxWorkers, s.ChainQueuePriority, s.ChainQueueRateLimitPerMinute)
+		v.queue("VENDOR", s.VendorQueueMaxWorkers, s.VendorQueuePriority, s.VendorQueueRateLimitPerMinute)
+	}
+
+	// the oracle service sends telemetry to DIS
+	if runWorkers || consumeTelemetry || consumeOperations {
+		v.required("DIMO_NODE_ENDPOINT", s.DimoNodeEndpoint)
+		if _, err := tls.X509KeyPair([]byte(s.Cert), []byte(s.CertKey)); err != nil {
+			v.addf("CERT and CERT_KEY are not a valid key pair: %v", err)
+		}
+		if s.EnableSacdCheck {
+			v.address("SACD_GRANTEE", s.SacdGrantee)
+		}
+	}
+
+	if s.ChainProfilesFile != "" {
+		v.chainProfiles(s)
+	}
+
+	if consumeTelemetry || consumeOperations {
+		v.required("KAFKA_BROKERS", s.KafkaBrokers)
+	}
+	if consumeTelemetry {
+		v.required("UNBUFFERED_TELEMETRY_TOPIC", s.UnbufferedTelemetryTopic)
+		v.required("UNBUFFERED_TELEMETRY_CONSUMER_GROUP", s.UnbufferedTelemetryConsumerGroup)
+	}
+	if consumeOperations {
+		v.required("OPERATIONS_TOPIC", s.OperationsTopic)
+		v.required("OPERATIONS_CONSUMER_GROUP", s.OperationsConsumerGroup)
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+type validation struct {
+	problems []string
+}
+
+func (v *validation) addf(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validation) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf("%s must be set", key)
+	}
+}
+
+func (v *validation) port(key, value string) {
+	if port, err := strconv.Atoi(value); err != nil || port <= 0 || port > 65535 {
+		v.addf("%s %q is not a port", key, value)
+	}
+}
+
+func (v *validation) url(key string, value url.URL) {
+	if value.Scheme == "" || value.Host == "" {
+		v.addf("%s %q is not an absolute URL", key, value.String())
+	}
+}
+
+func (v *validation) address(key string, value common.Address) {
+	if value == (common.Address{}) {
+		v.addf("%s must be set", key)
+	}
+}
+
+func (v *validation) privateKey(key, value string) {
+	// the key is a secret, don't echo it
+	if _, err := crypto.HexToECDSA(value); err != nil {
+		v.addf("%s is not a hex encoded private key", key)
+	}
+}
+
+func (v *validation) tokenID(key, value string) {
+	if id, ok := new(big.Int).SetString(value, 10); !ok || id.Sign() <= 0 {
+		v.addf("%s %q is not a token ID", key, value)
+	}
+}
+
+// chainProfiles checks the profiles of CHAIN_PROFILES_FILE, VIN records reference their chain by ID so IDs must be unique
+func (v *validation) chainProfiles(s *Settings) {
+	additional, err := LoadChainProfiles(s.ChainProfilesFile)
+	if err != nil {
+		v.addf("CHAIN_PROFILES_FILE: %v", err)
+		return
+	}
+
+	chainIDs := map[int64]string{s.ChainID: DefaultChainProfileName}
+	for i, profile := range additional {
+		prefix := fmt.Sprintf("CHAIN_PROFILES_FILE profile %d %q", i+1, profile.Name)
+		v.required(prefix+" name", profile.Name)
+		if profile.Name == DefaultChainProfileName {
+			v.addf("%s name is reserved for the chain settings", prefix)
+		}
+		if profile.ChainID <= 0 {
+			v.addf("%s chainId must be set", prefix)
+		} else if other, ok := chainIDs[profile.ChainID]; ok {
+			v.addf("%s chainId %d is already used by %q", prefix, profile.ChainID, other)
+		}
+		chainIDs[profile.ChainID] = profile.Name
+
+		v.url(prefix+" rpcUrl", profile.RPCURL)
+		v.url(prefix+" paymasterUrl", profile.PaymasterURL)
+		v.url(prefix+" bundlerUrl", profile.BundlerURL)
+		v.address(prefix+" registryAddress", profile.RegistryAddress)
+		v.address(prefix+" vehicleNftAddress", profile.VehicleNftAddress)
+		v.address(prefix+" syntheticNftAddress", profile.SyntheticNftAddress)
+	}
+}
+
+func (v *validation) queue(name string, maxWorkers, priority, rateLimitPerMinute int) {
+	if maxWorkers < 0 {
+		v.addf("%s_QUEUE_MAX_WORKERS must not be negative", name)
+	}
+	if priority < 0 || priority > 4 {
+		v.addf("%s_QUEUE_PRIORITY must be 1 to 4, or empty for the default", name)
+	}
+	if rateLimitPerMinute < 0 {
+		v.addf("%s_QUEUE_RATE_LIMIT_PER_MINUTE must not be negative", name)
+	}
+}
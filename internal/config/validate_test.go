@@ -0,0 +1,103 @@
+package config
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (s *ValidateTestSuite) TestReportsEveryProblem() {
+	settings := &Settings{Mode: ModeServeAPI, LogLevel: "verbose", MonitoringPort: "8888", Port: "8080"}
+
+	var validationErr *ValidationError
+	s.Require().ErrorAs(settings.Validate(), &validationErr)
+	s.Contains(validationErr.Problems, `LOG_LEVEL "verbose" is not a log level`)
+	s.Contains(validationErr.Problems, "DB_HOST must be set")
+	s.Contains(validationErr.Problems, "CHAIN_ID must be set")
+	s.Contains(validationErr.Problems, "DEVELOPER_PK is not a hex encoded private key")
+	s.Contains(validationErr.Problems, "SD_WALLETS_SEED must be 32 hex encoded bytes, got 0")
+	s.Contains(validationErr.Problems, `INTEGRATION_TOKEN_ID "" is not a token ID`)
+}
+
+func (s *ValidateTestSuite) TestOnlyChecksTheModesComponents() {
+	settings := &Settings{
+		Mode:                             ModeConsumeTelemetry,
+		LogLevel:                         "info",
+		MonitoringPort:                   "8888",
+		IdentityAPIEndpoint:              url.URL{Scheme: "https", Host: "identity-api.dimo.zone"},
+		KafkaBrokers:                     "localhost:9092",
+		UnbufferedTelemetryTopic:         "unbuffered-telemetry",
+		UnbufferedTelemetryConsumerGroup: "oracle-example",
+		DimoNodeEndpoint:                 "https://dis.dimo.zone",
+		EnableSacdCheck:                  true,
+		SacdGrantee:                      common.HexToAddress("0x1"),
+	}
+	settings.DB.Host, settings.DB.Name, settings.DB.User = "localhost", "oracle_example", "dimo"
+
+	var validationErr *ValidationError
+	s.Require().ErrorAs(settings.Validate(), &validationErr)
+	// no chain, API or operations consumer settings
+	s.Len(validationErr.Problems, 1)
+	s.Contains(validationErr.Problems[0], "CERT and CERT_KEY are not a valid key pair")
+}
+
+func (s *ValidateTestSuite) TestUnknownMode() {
+	var validationErr *ValidationError
+	s.Require().ErrorAs((&Settings{Mode: "worker"}).Validate(), &validationErr)
+	s.Contains(validationErr.Problems[0], `MODE "worker" is not one of`)
+}
+
+func (s *ValidateTestSuite) TestChainProfiles() {
+	file := filepath.Join(s.T().TempDir(), "chain_profiles.yaml")
+	s.Require().NoError(os.WriteFile(file, []byte(`
+- name: amoy
+  chainId: 80002
+  rpcUrl: https://polygon-amoy.example/key
+  paymasterUrl: https://paymaster.example/key
+  bundlerUrl: https://bundler.example/key
+  registryAddress: '0x5eAA326fB2fc97fAcCe6A79A304876daD0F2e96c'
+  vehicleNftAddress: '0x45fbCD3ef7361d156e8b16F5538AE36DEdf61Da8'
+  syntheticNftAddress: '0x78513c8CB4D6B6079f813850376bc9c7fc8aE67f'
+- name: default
+  chainId: 137
+  rpcUrl: polygon
+  paymasterUrl: https://paymaster.example/key
+  bundlerUrl: https://bundler.example/key
+  registryAddress: '0x5eAA326fB2fc97fAcCe6A79A304876daD0F2e96c'
+  vehicleNftAddress: '0x45fbCD3ef7361d156e8b16F5538AE36DEdf61Da8'
+  syntheticNftAddress: '0x78513c8CB4D6B6079f813850376bc9c7fc8aE67f'
+`), 0o600))
+	settings := &Settings{Mode: ModeServeAPI, ChainID: 137, ChainProfilesFile: file}
+
+	var validationErr *ValidationError
+	s.Require().ErrorAs(settings.Validate(), &validationErr)
+	s.Contains(validationErr.Problems, `CHAIN_PROFILES_FILE profile 2 "default" name is reserved for the chain settings`)
+	s.Contains(validationErr.Problems, `CHAIN_PROFILES_FILE profile 2 "default" chainId 137 is already used by "default"`)
+	s.Contains(validationErr.Problems, `CHAIN_PROFILES_FILE profile 2 "default" rpcUrl "polygon" is not an absolute URL`)
+	for _, problem := range validationErr.Problems {
+		s.NotContains(problem, `"amoy"`)
+	}
+
+	profiles, err := settings.ChainProfiles()
+	s.Require().NoError(err)
+	s.Len(profiles, 3)
+	amoy, ok := profiles.ByID(80002)
+	s.Require().True(ok)
+	s.Equal("amoy", amoy.Name)
+	s.Equal(common.HexToAddress("0x45fbCD3ef7361d156e8b16F5538AE36DEdf61Da8"), amoy.VehicleNftAddress)
+	// records without a chain are on the default one
+	s.Equal(DefaultChainProfileName, profiles.Default().Name)
+	defaultProfile, _ := profiles.ByID(0)
+	s.Equal(int64(137), defaultProfile.ChainID)
+}
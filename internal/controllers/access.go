@@ -9,6 +9,13 @@ type AccessController struct{}
 func NewAccessController() *AccessController {
 	return &AccessController{}
 }
+
+// CheckAccess
+// @Summary Check if the logged in wallet has access
+// @Produce json
+// @Success 200
+// @Security     BearerAuth
+// @Router /v1/access [get]
 func (a *AccessController) CheckAccess(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{})
 }
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
+	"github.com/DIMO-Network/oracle-example/internal/vin"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// VinError is the result of a VIN that was left out of a batch request, codes come from the apierrors catalog
+type VinError struct {
+	Vin     string         `json:"vin"`
+	Code    apierrors.Code `json:"code"`
+	Message string         `json:"message"`
+}
+
+// batch collects per-VIN errors of a batch request. VINs with an error are left out, the rest is still processed.
+// With the atomic=true query param any error fails the whole request before anything is processed.
+type batch struct {
+	atomic bool
+	errors []VinError
+	failed map[string]bool
+}
+
+func newBatch(c *fiber.Ctx) *batch {
+	return &batch{
+		atomic: c.QueryBool("atomic"),
+		failed: make(map[string]bool),
+	}
+}
+
+func (b *batch) fail(vin string, code apierrors.Code, message string) {
+	b.errors = append(b.errors, VinError{
+		Vin:     vin,
+		Code:    code,
+		Message: message,
+	})
+	b.failed[vin] = true
+}
+
+func (b *batch) hasFailed(vin string) bool {
+	return b.failed[vin]
+}
+
+// rejectDuplicates fails VINs submitted more than once, none of their entries is processed as it's unclear which one is meant
+func (b *batch) rejectDuplicates(vins []string) {
+	counts := make(map[string]int, len(vins))
+	for _, v := range vins {
+		counts[v]++
+	}
+
+	for _, v := range vins {
+		if counts[v] > 1 && !b.hasFailed(v) {
+			b.fail(v, apierrors.CodeVinDuplicate, "VIN submitted more than once")
+		}
+	}
+}
+
+// valid returns the VINs without an error
+func (b *batch) valid(vins []string) []string {
+	result := make([]string, 0, len(vins))
+	for _, v := range vins {
+		if !b.hasFailed(v) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// shouldAbort reports whether the request fails as a whole: on any error in atomic mode, otherwise when no VIN is left to process
+func (b *batch) shouldAbort(remaining int) bool {
+	return len(b.errors) > 0 && (b.atomic || remaining == 0)
+}
+
+// rejected fails the request with all per-VIN errors, as an internal error if any of them was on our side
+func (b *batch) rejected() error {
+	code := apierrors.CodeBatchRejected
+	reasons := make([]string, 0, len(b.errors))
+	for _, vinError := range b.errors {
+		if vinError.Code.Status() >= fiber.StatusInternalServerError {
+			code = apierrors.CodeInternal
+		}
+		reasons = append(reasons, fmt.Sprintf("%q: %s", vinError.Vin, vinError.Message))
+	}
+
+	return apierrors.New(code, "VINs could not be processed: "+strings.Join(reasons, "; ")).WithErrors(b.errors)
+}
+
+// vinErrorCode tells invalid VINs apart from other invalid submitted data
+func vinErrorCode(err error) apierrors.Code {
+	if errors.Is(err, vin.ErrInvalidLength) || errors.Is(err, vin.ErrInvalidCharacters) || errors.Is(err, vin.ErrInvalidCheckDigit) {
+		return apierrors.CodeVinInvalid
+	}
+
+	return apierrors.CodeDataInvalid
+}
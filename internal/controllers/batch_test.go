@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/DIMO-Network/oracle-example/internal/vin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type BatchTestSuite struct {
+	suite.Suite
+	controller *VehicleController
+}
+
+func (s *BatchTestSuite) SetupTest() {
+	s.controller = &VehicleController{settings: &config.Settings{}}
+}
+
+func TestBatchTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchTestSuite))
+}
+
+func (s *BatchTestSuite) TestNormalizeVins() {
+	b := &batch{failed: make(map[string]bool)}
+
+	valid := s.controller.normalizeVins(b, []string{
+		"1hgcm82633a004352",
+		"1HGCM82643A004352",
+		"ABCDEFG1234567811",
+		"ABCDEFG1234567812",
+		" abcdefg1234567812",
+	})
+
+	s.Equal([]string{"1HGCM82633A004352", "ABCDEFG1234567811"}, valid)
+	s.Equal([]VinError{
+		{Vin: "1HGCM82643A004352", Code: apierrors.CodeVinInvalid, Message: "invalid VIN check digit: expected 3, got 4"},
+		{Vin: "ABCDEFG1234567812", Code: apierrors.CodeVinDuplicate, Message: "VIN submitted more than once"},
+	}, b.errors)
+}
+
+func (s *BatchTestSuite) TestShouldAbort() {
+	b := &batch{failed: make(map[string]bool)}
+	s.False(b.shouldAbort(0))
+
+	b.fail("ABCDEFG1234567811", apierrors.CodeVinNotOwned, "VIN not owned")
+	s.False(b.shouldAbort(1))
+	s.True(b.shouldAbort(0))
+
+	b.atomic = true
+	s.True(b.shouldAbort(1))
+}
+
+func (s *BatchTestSuite) TestVinErrorCode() {
+	s.Equal(apierrors.CodeVinInvalid, vinErrorCode(errors.Wrap(vin.ErrInvalidLength, "invalid VIN")))
+	s.Equal(apierrors.CodeDataInvalid, vinErrorCode(errors.New("definition not found")))
+}
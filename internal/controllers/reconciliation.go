@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
+	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type ReconciliationController struct {
+	logger *zerolog.Logger
+	vs     *service.Vehicle
+}
+
+func NewReconciliationController(logger *zerolog.Logger, vs *service.Vehicle) *ReconciliationController {
+	return &ReconciliationController{
+		logger: logger,
+		vs:     vs,
+	}
+}
+
+const (
+	defaultDriftsPageSize = 100
+	maxDriftsPageSize     = 500
+)
+
+type DriftsGetParams struct {
+	Resolved bool   `query:"resolved"`
+	Cursor   string `query:"cursor"`
+	Limit    int    `query:"limit"`
+}
+
+// GetDrifts
+// @Summary Get chain/DB drift report
+// @Description Get a page of mismatches between the oracle DB and on-chain state found by the reconciliation job, newest first.
+// @Description Only for the ADMIN_WALLETS, the report holds every owner's VINs.
+// @Produce json
+// @Param resolved query bool false "include already resolved drifts"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param limit query int false "page size, default 100, max 500"
+// @Success 200 {object} DriftReportResponse
+// @Security     BearerAuth
+// @Router /v1/reconciliation/drifts [get]
+func (r *ReconciliationController) GetDrifts(c *fiber.Ctx) error {
+	params := new(DriftsGetParams)
+	if err := c.QueryParser(params); err != nil {
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse query params")
+	}
+
+	filter := service.VinDriftFilter{
+		IncludeResolved: params.Resolved,
+		Limit:           params.Limit,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDriftsPageSize
+	}
+	if filter.Limit > maxDriftsPageSize {
+		filter.Limit = maxDriftsPageSize
+	}
+
+	if params.Cursor != "" {
+		cursor, err := service.DecodeVinDriftCursor(params.Cursor)
+		if err != nil {
+			return apierrors.New(apierrors.CodeRequestInvalid, "Invalid cursor")
+		}
+		filter.After = cursor
+	}
+
+	page, err := r.vs.GetVinDrifts(c.Context(), filter)
+	if err != nil {
+		return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load drifts from Database")
+	}
+
+	counts, err := r.vs.CountUnresolvedVinDrifts(c.Context())
+	if err != nil {
+		return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load drifts from Database")
+	}
+
+	response := DriftReportResponse{
+		Drifts: make([]VinDrift, 0, len(page.Drifts)),
+		Counts: counts,
+	}
+	if page.Next != nil {
+		response.NextCursor = page.Next.Encode()
+	}
+
+	for _, drift := range page.Drifts {
+		item := VinDrift{
+			Vin:        drift.Vin,
+			Kind:       drift.Kind,
+			DBValue:    drift.DBValue.String,
+			ChainValue: drift.ChainValue.String,
+			DetectedAt: drift.DetectedAt,
+		}
+		if drift.ResolvedAt.Valid {
+			item.ResolvedAt = &drift.ResolvedAt.Time
+		}
+
+		response.Drifts = append(response.Drifts, item)
+	}
+
+	return c.JSON(response)
+}
+
+type VinDrift struct {
+	Vin        string     `json:"vin"`
+	Kind       string     `json:"kind"`
+	DBValue    string     `json:"dbValue"`
+	ChainValue string     `json:"chainValue"`
+	DetectedAt time.Time  `json:"detectedAt"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+type DriftReportResponse struct {
+	Drifts []VinDrift `json:"drifts"`
+	// Counts holds the number of unresolved drifts by kind, over all pages
+	Counts     map[string]int `json:"counts"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
@@ -1,17 +1,20 @@
 package controllers
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/DIMO-Network/go-transactions"
 	registry "github.com/DIMO-Network/go-transactions/contracts"
 	"github.com/DIMO-Network/go-zerodev"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
 	"github.com/DIMO-Network/oracle-example/internal/config"
 	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
 	"github.com/DIMO-Network/oracle-example/internal/kafka"
 	"github.com/DIMO-Network/oracle-example/internal/models"
 	"github.com/DIMO-Network/oracle-example/internal/onboarding"
 	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/DIMO-Network/oracle-example/internal/vin"
 	"github.com/DIMO-Network/shared/pkg/logfields"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -24,14 +27,11 @@ import (
 	"github.com/tidwall/gjson"
 	"github.com/volatiletech/null/v8"
 	"math/big"
-	"regexp"
-	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
-var vinRegexp, _ = regexp.Compile("^[A-HJ-NPR-Z0-9]{17}$")
-
 type VehicleController struct {
 	settings    *config.Settings
 	logger      *zerolog.Logger
@@ -39,10 +39,10 @@ type VehicleController struct {
 	vs          *service.Vehicle
 	riverClient *river.Client[pgx.Tx]
 	ws          service.SDWalletsAPI
-	tr          *transactions.Client
+	chains      *onboarding.Chains
 }
 
-func NewVehiclesController(settings *config.Settings, logger *zerolog.Logger, identity service.IdentityAPI, vs *service.Vehicle, riverClient *river.Client[pgx.Tx], ws service.SDWalletsAPI, tr *transactions.Client) *VehicleController {
+func NewVehiclesController(settings *config.Settings, logger *zerolog.Logger, identity service.IdentityAPI, vs *service.Vehicle, riverClient *river.Client[pgx.Tx], ws service.SDWalletsAPI, chains *onboarding.Chains) *VehicleController {
 	return &VehicleController{
 		settings:    settings,
 		logger:      logger,
@@ -50,111 +50,229 @@ func NewVehiclesController(settings *config.Settings, logger *zerolog.Logger, id
 		vs:          vs,
 		riverClient: riverClient,
 		ws:          ws,
-		tr:          tr,
+		chains:      chains,
 	}
 }
 
+const (
+	defaultVehiclesPageSize = 50
+	maxVehiclesPageSize     = 200
+	vehicleIdentityCacheTTL = time.Hour
+)
+
+type VehiclesGetParams struct {
+	Cursor           string `query:"cursor"`
+	Limit            int    `query:"limit"`
+	Phase            string `query:"phase"`
+	ConnectionStatus string `query:"connectionStatus"`
+	Make             string `query:"make"`
+	Model            string `query:"model"`
+	Year             int    `query:"year"`
+	ErrorCode        string `query:"errorCode"`
+	Sort             string `query:"sort"`
+	Order            string `query:"order"`
+}
+
 // GetVehicles
 // @Summary Get user's vehicles
-// @Description Get user's vehicles from Identity API and add external ID (VIN)
+// @Description Get a page of user's onboarded vehicles from the oracle DB, joined with cached Identity API data
 // @Produce json
-// @Success 200
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param limit query int false "page size, default 50, max 200"
+// @Param phase query string false "onboarding phase: verification, minting, minted, disconnect, delete, paused"
+// @Param connectionStatus query string false "vendor connection status"
+// @Param make query string false "make"
+// @Param model query string false "model"
+// @Param year query int false "year"
+// @Param errorCode query string false "last operation error code"
+// @Param sort query string false "vin (default), status, make, model or year"
+// @Param order query string false "asc (default) or desc"
+// @Success 200 {object} VehiclesResponse
 // @Security     BearerAuth
 // @Router /v1/vehicles [get]
 func (v *VehicleController) GetVehicles(c *fiber.Ctx) error {
 	walletAddress := c.Locals("wallet").(common.Address)
 
-	identityVehicles, err := (v.identity).FetchVehiclesByWalletAddress(walletAddress.String())
+	params := new(VehiclesGetParams)
+	if err := c.QueryParser(params); err != nil {
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse query params")
+	}
+
+	filter := service.VehicleListFilter{
+		Owner:            walletAddress,
+		ConnectionStatus: params.ConnectionStatus,
+		Make:             params.Make,
+		Model:            params.Model,
+		Year:             params.Year,
+		ErrorCode:        params.ErrorCode,
+		SortBy:           params.Sort,
+		Desc:             strings.EqualFold(params.Order, "desc"),
+		Limit:            params.Limit,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultVehiclesPageSize
+	}
+	if filter.Limit > maxVehiclesPageSize {
+		filter.Limit = maxVehiclesPageSize
+	}
+
+	if params.Phase != "" {
+		statusRange, ok := onboarding.OnboardingPhases[params.Phase]
+		if !ok {
+			return apierrors.New(apierrors.CodeRequestInvalid, "Unknown phase")
+		}
+		filter.StatusRange = &statusRange
+	}
+
+	if _, ok := service.VehicleListSortFields[params.Sort]; params.Sort != "" && !ok {
+		return apierrors.New(apierrors.CodeRequestInvalid, "Unknown sort field")
+	}
+
+	if params.Cursor != "" {
+		cursor, err := service.DecodeVehicleListCursor(params.Cursor)
+		if err != nil {
+			return apierrors.New(apierrors.CodeRequestInvalid, "Invalid cursor")
+		}
+		filter.After = cursor
+	} else {
+		v.claimUnownedVins(c.Context(), walletAddress)
+	}
+
+	page, err := v.vs.ListVehicles(c.Context(), filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to load vehicles from Identity API",
+		if errors.Is(err, service.ErrInvalidCursor) {
+			return apierrors.New(apierrors.CodeRequestInvalid, "Invalid cursor")
+		}
+
+		return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
+	}
+
+	response := VehiclesResponse{
+		Vehicles: make([]VehicleListItem, 0, len(page.Vins)),
+	}
+	if page.Next != nil {
+		response.NextCursor = page.Next.Encode()
+	}
+
+	v.refreshVehicleIdentities(c.Context(), walletAddress, page)
+
+	for _, vin := range page.Vins {
+		identity := page.Identities[vin.VehicleTokenID.Int64]
+
+		response.Vehicles = append(response.Vehicles, VehicleListItem{
+			Vehicle:          service.VehicleFromIdentity(vin, identity),
+			OnboardingStatus: onboarding.GetDetailedStatus(vin.OnboardingStatus),
+			Phase:            onboarding.GetPhase(vin.OnboardingStatus),
+			TelemetryPaused:  vin.TelemetryPaused,
+			ErrorCode:        vin.OperationErrorCode.String,
 		})
 	}
 
-	tokenIDsToCheck := []int64{}
-	vehiclesByTokenID := make(map[int64]models.Vehicle)
-	returnVehicles := []models.Vehicle{}
+	return c.JSON(response)
+}
+
+// claimUnownedVins tracks the wallet as owner of its minted VINs that have no owner yet, so they are listed. Ownership
+// comes from the wallet's vehicles on Identity API (cached), it's only done for the first page. Failures are just logged.
+func (v *VehicleController) claimUnownedVins(ctx context.Context, walletAddress common.Address) {
+	vehicles, err := v.identity.GetVehiclesByWalletAddress(ctx, walletAddress.Hex())
+	if err != nil {
+		v.logger.Warn().Err(err).Msg("Failed to load wallet vehicles to claim unowned VINs")
+		return
+	}
 
-	for _, vehicle := range identityVehicles {
-		tokenIDsToCheck = append(tokenIDsToCheck, vehicle.TokenID)
-		vehiclesByTokenID[vehicle.TokenID] = vehicle
+	tokenIDs := make([]int64, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		tokenIDs = append(tokenIDs, vehicle.TokenID)
 	}
 
-	vins, err := v.vs.GetVinsByTokenIDs(c.Context(), tokenIDsToCheck)
+	claimed, err := v.vs.ClaimUnownedVins(ctx, walletAddress, tokenIDs)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to load vehicles from Vehicle Service",
-		})
+		v.logger.Warn().Err(err).Msg("Failed to claim unowned VINs")
+		return
+	}
+	if claimed > 0 {
+		v.logger.Info().Int64("vins", claimed).Str("owner", walletAddress.Hex()).Msg("Claimed unowned VINs")
 	}
+}
 
-	for _, vin := range vins {
-		tid, tidErr := vin.VehicleTokenID.Value()
-		if tidErr != nil {
-			continue
+// refreshVehicleIdentities fills the Identity API DB cache for VINs of the page that are missing or stale in it,
+// from the wallet's full vehicle set which is fetched (and cached) once per page instead of once per VIN.
+// Failures only mean less details in the listing, so they are just logged.
+func (v *VehicleController) refreshVehicleIdentities(ctx context.Context, walletAddress common.Address, page *service.VehicleListPage) {
+	stale := make(map[int64]bool)
+	for _, vin := range page.Vins {
+		identity := page.Identities[vin.VehicleTokenID.Int64]
+		if vin.VehicleTokenID.Valid && (identity == nil || time.Since(identity.UpdatedAt) >= vehicleIdentityCacheTTL) {
+			stale[vin.VehicleTokenID.Int64] = true
 		}
+	}
 
-		vehicle, ok := vehiclesByTokenID[tid.(int64)]
-		if !ok {
+	if len(stale) == 0 {
+		return
+	}
+
+	vehicles, err := v.identity.GetVehiclesByWalletAddress(ctx, walletAddress.Hex())
+	if err != nil {
+		v.logger.Warn().Err(err).Msg("Failed to refresh vehicle identities")
+		return
+	}
+
+	for _, vehicle := range vehicles {
+		if !stale[vehicle.TokenID] {
 			continue
 		}
 
-		vehicle.VIN = vin.Vin
-		vehicle.ConnectionStatus = vin.ConnectionStatus.String
-		vehicle.DisconnectionStatus = vin.DisconnectionStatus.String
-		returnVehicles = append(returnVehicles, vehicle)
+		refreshed, err := v.vs.UpsertVehicleIdentity(ctx, &vehicle)
+		if err != nil {
+			continue
+		}
+		page.Identities[vehicle.TokenID] = refreshed
 	}
+}
 
-	return c.JSON(VehiclesResponse{
-		Vehicles: returnVehicles,
-	})
+type VehicleListItem struct {
+	models.Vehicle
+	OnboardingStatus string `json:"onboardingStatus"`
+	Phase            string `json:"phase"`
+	TelemetryPaused  bool   `json:"telemetryPaused"`
+	ErrorCode        string `json:"errorCode,omitempty"`
 }
 
 type VehiclesResponse struct {
-	Vehicles []models.Vehicle `json:"vehicles"`
+	Vehicles   []VehicleListItem `json:"vehicles"`
+	NextCursor string            `json:"nextCursor,omitempty"`
 }
 
 // GetVehicleByExternalID
 // @Summary Get user's vehicle by external ID
 // @Description Get user's vehicle by external ID (VIN)
 // @Produce json
-// @Success 200
+// @Param externalID path string true "VIN"
+// @Success 200 {object} VehicleResponse
 // @Security     BearerAuth
 // @Router /v1/vehicle/{externalID} [get]
 func (v *VehicleController) GetVehicleByExternalID(c *fiber.Ctx) error {
 	externalID := c.Params("externalID")
 	walletAddress := c.Locals("wallet").(common.Address)
 
-	identityVehicles, err := (v.identity).FetchVehiclesByWalletAddress(walletAddress.String())
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to load vehicles from Identity API",
-		})
-	}
-
 	vin, err := v.vs.GetVehicleByExternalID(c.Context(), externalID)
 	if err != nil {
 		if errors.Is(err, service.ErrVehicleNotFound) {
-			return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicle")
+			return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicle")
 		}
 
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to load vehicle from Database",
-		})
-	}
-
-	vehiclesByTokenID := make(map[int64]models.Vehicle)
-	for _, vehicle := range identityVehicles {
-		vehiclesByTokenID[vehicle.TokenID] = vehicle
+		return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicle from Database")
 	}
 
-	tid, tidErr := vin.VehicleTokenID.Value()
-	if tidErr != nil {
-		return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicle")
+	vehiclesByTokenID, err := v.fetchOwnedVehicles(c.Context(), walletAddress, dbmodels.VinSlice{vin})
+	if err != nil {
+		return apierrors.Wrap(err, apierrors.CodeUpstreamIdentityFailed, "Failed to load vehicles from Identity API")
 	}
 
-	vehicle, ok := vehiclesByTokenID[tid.(int64)]
-	if !ok {
-		return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicle")
+	vehicle, ok := vehiclesByTokenID[vin.VehicleTokenID.Int64]
+	if vin.VehicleTokenID.IsZero() || !ok {
+		return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicle")
 	}
 
 	vehicle.VIN = vin.Vin
@@ -171,8 +289,10 @@ type VehicleResponse struct {
 // RegisterVehicle
 // @Summary Checks and registers existing vehicle in internal oracle mapping DB
 // @Description Checks and registers existing vehicle in internal oracle mapping DB
+// @Accept json
 // @Produce json
-// @Success 200
+// @Param payload body VehicleRegisterPayload true "VIN and vehicle token ID"
+// @Success 200 {object} VehicleResponse
 // @Security     BearerAuth
 // @Router /v1/vehicle/register [post]
 func (v *VehicleController) RegisterVehicle(c *fiber.Ctx) error {
@@ -181,57 +301,49 @@ func (v *VehicleController) RegisterVehicle(c *fiber.Ctx) error {
 	vinToRegister := gjson.GetBytes(c.Body(), "vin")
 	tokenIDToRegister := gjson.GetBytes(c.Body(), "token_id")
 
-	if !vinToRegister.Exists() || len(vinToRegister.String()) != 17 || !tokenIDToRegister.Exists() {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Missing or invalid VIN or Token ID",
-		})
+	if !vinToRegister.Exists() || !tokenIDToRegister.Exists() {
+		return apierrors.New(apierrors.CodeRequestInvalid, "Missing VIN or Token ID")
 	}
 
-	// Check if the vehicle is available in identity-api
-	identityVehicle, err := (v.identity).FetchVehicleByTokenID(tokenIDToRegister.Int())
+	normalizedVin, err := v.normalizeVin(vinToRegister.String())
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to load vehicle from Identity API",
-		})
+		return apierrors.New(apierrors.CodeVinInvalid, fmt.Sprintf("Invalid VIN %q: %s", vinToRegister.String(), err))
 	}
 
-	// we may not find vehicle (graphql still returns 200 and unmarshal creates empty objects)
-	if identityVehicle.TokenID == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Vehicle not found",
-		})
+	// Check if the vehicle is available in identity-api
+	identityVehicle, err := (v.identity).FetchVehicleByTokenID(c.Context(), tokenIDToRegister.Int())
+	if err != nil {
+		if errors.Is(err, service.ErrGraphQLNotFound) {
+			return apierrors.New(apierrors.CodeVinNotFound, "Vehicle not found")
+		}
+
+		return apierrors.Wrap(err, apierrors.CodeUpstreamIdentityFailed, "Failed to load vehicle from Identity API")
 	}
 
 	// vehicle can't be owned by someone else
 	if identityVehicle.Owner != walletAddress.String() {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Vehicle not owned by wallet",
-		})
+		return apierrors.New(apierrors.CodeVinNotOwned, "Vehicle not owned by wallet")
 	}
 
 	var newVin dbmodels.Vin
 	newVin.OnboardingStatus = onboarding.OnboardingStatusSubmitUnknown
-	newVin.Vin = vinToRegister.String()
+	newVin.Vin = normalizedVin
 	newVin.VehicleTokenID = null.Int64From(tokenIDToRegister.Int())
 
 	// check if this VIN is already registered
-	vin, err := v.vs.GetVehicleByExternalID(c.Context(), vinToRegister.String())
+	vin, err := v.vs.GetVehicleByExternalID(c.Context(), normalizedVin)
 
 	if err != nil {
 		// if now found, we're still good, so fail only on other errors
 		if !errors.Is(err, service.ErrVehicleNotFound) {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicle from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicle from Database")
 		}
 	}
 
 	if vin != nil {
 		// If registered VIN has TokenID, but it's different, that's bad
 		if !vin.VehicleTokenID.IsZero() && vin.VehicleTokenID.Int64 != tokenIDToRegister.Int() {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-				"error": "Vehicle VIN assigned to another TokenID",
-			})
+			return apierrors.New(apierrors.CodeStateConflict, "Vehicle VIN assigned to another TokenID")
 		}
 
 		newVin = *vin
@@ -245,15 +357,15 @@ func (v *VehicleController) RegisterVehicle(c *fiber.Ctx) error {
 		newVin.DeviceDefinitionID = null.StringFrom(identityVehicle.Definition.ID)
 	}
 
+	newVin.OwnerAddress = null.StringFrom(walletAddress.Hex())
+
 	// We allow to either insert new row or update Synthetic TokenID for existing row
 	err = v.vs.InsertOrUpdateVin(c.Context(), &newVin)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to register Vehicle",
-		})
+		return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to register Vehicle")
 	}
 
-	identityVehicle.VIN = vinToRegister.String()
+	identityVehicle.VIN = normalizedVin
 
 	return c.JSON(VehicleResponse{
 		Vehicle: *identityVehicle,
@@ -272,39 +384,24 @@ type VinsGetParams struct {
 // GetVerificationStatusForVins
 // @Summary Get verification status for each of the submitted VINs
 // @Produce json
-// @Success 200
+// @Param vins query []string true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
 // @Security     BearerAuth
 // @Router /v1/vehicle/verify [get]
 func (v *VehicleController) GetVerificationStatusForVins(c *fiber.Ctx) error {
 	params := new(VinsGetParams)
 	if err := c.QueryParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse VINs",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
 	}
 
 	localLog := v.logger.With().Interface("vins", params.Vins).Str(logfields.FunctionName, "GetVerificationStatusForVins").Logger()
 	localLog.Debug().Interface("vins", params.Vins).Msg("Checking Verification Status for Vins")
 
-	validVins := make([]string, 0, len(params.Vins))
-	for _, vin := range params.Vins {
-		strippedVin := strings.TrimSpace(vin)
-		if v.isValidVin(strippedVin) {
-			validVins = append(validVins, strippedVin)
-		}
-	}
-
-	if len(validVins) != len(params.Vins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid VINs provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs", len(validVins))
@@ -315,12 +412,10 @@ func (v *VehicleController) GetVerificationStatusForVins(c *fiber.Ctx) error {
 		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
 		if err != nil {
 			if errors.Is(err, service.ErrVehicleNotFound) {
-				return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		indexedVins := make(map[string]*dbmodels.Vin)
@@ -348,12 +443,15 @@ func (v *VehicleController) GetVerificationStatusForVins(c *fiber.Ctx) error {
 
 	return c.JSON(StatusForVinsResponse{
 		Statuses: statuses,
+		Errors:   b.errors,
 	})
 }
 
 type VinWithCountryCode struct {
 	Vin         string `json:"vin"`
 	CountryCode string `json:"countryCode"`
+	// ChainID of the chain profile to mint the vehicle on, the default chain when empty
+	ChainID int64 `json:"chainId,omitempty"`
 }
 type SubmitVinVerificationParams struct {
 	Vins []VinWithCountryCode `json:"vins"`
@@ -371,53 +469,136 @@ func (v *VehicleController) canSubmitVerificationJob(record *dbmodels.Vin) bool
 	return !verified && (failed || !pending)
 }
 
-func (v *VehicleController) isValidVin(vin string) bool {
+// fetchOwnedVehicles looks up the wallet's vehicles directly by the VINs' token IDs, indexed by token ID.
+// Unlike listing all wallet vehicles, it doesn't depend on how many vehicles the wallet owns.
+func (v *VehicleController) fetchOwnedVehicles(ctx context.Context, walletAddress common.Address, dbVins dbmodels.VinSlice) (map[int64]models.Vehicle, error) {
+	tokenIDs := make([]int64, 0, len(dbVins))
+	for _, dbVin := range dbVins {
+		if !dbVin.VehicleTokenID.IsZero() {
+			tokenIDs = append(tokenIDs, dbVin.VehicleTokenID.Int64)
+		}
+	}
+
+	result := make(map[int64]models.Vehicle, len(tokenIDs))
+	if len(tokenIDs) == 0 {
+		return result, nil
+	}
+
+	vehicles, err := v.identity.FetchOwnedVehicles(ctx, walletAddress.Hex(), tokenIDs)
+	if err != nil {
+		v.logger.Error().Err(err).Msg("Failed to fetch owned vehicles from Identity API")
+		return nil, err
+	}
+
+	for _, vehicle := range vehicles {
+		result[vehicle.TokenID] = vehicle
+	}
+
+	return result, nil
+}
+
+// normalizeVin uppercases the VIN, strips whitespace and validates it. Test mode skips the check digit, so made up VINs work.
+func (v *VehicleController) normalizeVin(rawVin string) (string, error) {
+	normalized := vin.Normalize(rawVin)
 	if v.settings.EnableVendorTestMode {
-		return len(vin) == 17
+		return normalized, vin.ValidateFormat(normalized)
 	}
 
-	return vinRegexp.MatchString(vin)
+	return normalized, vin.Validate(normalized)
+}
+
+// normalizeVins normalizes all VINs, invalid and duplicated ones are reported to the batch and left out
+func (v *VehicleController) normalizeVins(b *batch, rawVins []string) []string {
+	normalized := make([]string, 0, len(rawVins))
+	for _, rawVin := range rawVins {
+		normalizedVin, err := v.normalizeVin(rawVin)
+		if err != nil {
+			b.fail(rawVin, apierrors.CodeVinInvalid, err.Error())
+			continue
+		}
+		normalized = append(normalized, normalizedVin)
+	}
+
+	b.rejectDuplicates(normalized)
+
+	return b.valid(normalized)
+}
+
+// transactionsClient returns the client of the chain the VIN is registered on, VINs on a chain without profile fail
+func (v *VehicleController) transactionsClient(b *batch, dbVin *dbmodels.Vin) (*transactions.Client, bool) {
+	tr, err := v.chains.ForRecord(dbVin)
+	if err != nil {
+		b.fail(dbVin.Vin, apierrors.CodeChainUnsupported, err.Error())
+		return nil, false
+	}
+
+	return tr, true
+}
+
+// recordChainID returns the chain the VIN is registered on, the default chain for records without one
+func (v *VehicleController) recordChainID(dbVin *dbmodels.Vin) int64 {
+	if dbVin.ChainID.Valid {
+		return dbVin.ChainID.Int64
+	}
+
+	return v.chains.DefaultChainID()
+}
+
+// ownsUnmintedVin tells if the wallet can submit the VIN, an unminted VIN belongs to the wallet that submitted it first
+func ownsUnmintedVin(record *dbmodels.Vin, walletAddress common.Address) bool {
+	if !record.VehicleTokenID.IsZero() || record.OwnerAddress.String == "" {
+		return true
+	}
+
+	return common.HexToAddress(record.OwnerAddress.String) == walletAddress
 }
 
 // SubmitVerificationForVins
 // @Summary Submits VINs with country codes for verification
 // @Description Decodes the VINs to Device Definitions and validates vendor connectivity
+// @Accept json
 // @Produce json
-// @Success 200
+// @Param payload body SubmitVinVerificationParams true "VINs with country codes"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
 // @Security BearerAuth
 // @Router /v1/vehicle/verify [post]
 func (v *VehicleController) SubmitVerificationForVins(c *fiber.Ctx) error {
+	walletAddress := c.Locals("wallet").(common.Address)
+
 	params := new(SubmitVinVerificationParams)
 	if err := c.BodyParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse VINs",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
 	}
 	localLog := v.logger.With().Interface("vins", params.Vins).Str(logfields.FunctionName, "SubmitVerificationForVins").Logger()
 	localLog.Debug().Interface("vins", params.Vins).Msg("Submitting Verification for VINs")
 
+	b := newBatch(c)
 	validVins := make([]string, 0, len(params.Vins))
 	validVinsWithCountryCode := make([]VinWithCountryCode, 0, len(params.Vins))
 	for _, paramVin := range params.Vins {
-		strippedVin := strings.TrimSpace(paramVin.Vin)
-		strippedCountryCode := strings.TrimSpace(paramVin.CountryCode)
-		if v.isValidVin(strippedVin) {
-			validVins = append(validVins, strippedVin)
-			validVinsWithCountryCode = append(validVinsWithCountryCode, VinWithCountryCode{Vin: strippedVin, CountryCode: strippedCountryCode})
+		normalizedVin, err := v.normalizeVin(paramVin.Vin)
+		if err != nil {
+			b.fail(paramVin.Vin, apierrors.CodeVinInvalid, err.Error())
+			continue
 		}
-	}
 
-	if len(validVins) != len(params.Vins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid VINs provided",
-		})
+		if paramVin.ChainID != 0 {
+			if _, err := v.chains.Client(paramVin.ChainID); err != nil {
+				b.fail(paramVin.Vin, apierrors.CodeChainUnsupported, err.Error())
+				continue
+			}
+		}
+
+		strippedCountryCode := strings.TrimSpace(paramVin.CountryCode)
+		validVins = append(validVins, normalizedVin)
+		validVinsWithCountryCode = append(validVinsWithCountryCode, VinWithCountryCode{Vin: normalizedVin, CountryCode: strippedCountryCode, ChainID: paramVin.ChainID})
 	}
 
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b.rejectDuplicates(validVins)
+	validVins = b.valid(validVins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs", len(validVins))
@@ -428,12 +609,10 @@ func (v *VehicleController) SubmitVerificationForVins(c *fiber.Ctx) error {
 		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
-				return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		indexedDbVins := make(map[string]*dbmodels.Vin)
@@ -441,7 +620,23 @@ func (v *VehicleController) SubmitVerificationForVins(c *fiber.Ctx) error {
 			indexedDbVins[vin.Vin] = vin
 		}
 
+		// check all VINs before submitting anything, so atomic requests fail without side effects
+		for _, vin := range validVins {
+			if dbVin, ok := indexedDbVins[vin]; ok && !ownsUnmintedVin(dbVin, walletAddress) {
+				b.fail(vin, apierrors.CodeVinNotOwned, "VIN not owned")
+			}
+		}
+
+		validVins = b.valid(validVins)
+		if b.shouldAbort(len(validVins)) {
+			return b.rejected()
+		}
+
 		for _, vin := range validVinsWithCountryCode {
+			if b.hasFailed(vin.Vin) {
+				continue
+			}
+
 			dbVin, ok := indexedDbVins[vin.Vin]
 			if !ok {
 				dbVin = &dbmodels.Vin{
@@ -450,11 +645,28 @@ func (v *VehicleController) SubmitVerificationForVins(c *fiber.Ctx) error {
 				}
 			}
 
+			// VINs stay on the chain they were registered on unless asked otherwise, minted ones can't move
+			chainID := v.recordChainID(dbVin)
+			if vin.ChainID != 0 && vin.ChainID != chainID {
+				if !dbVin.VehicleTokenID.IsZero() {
+					b.fail(vin.Vin, apierrors.CodeStateConflict, fmt.Sprintf("VIN is already minted on chain %d", chainID))
+					continue
+				}
+				chainID = vin.ChainID
+			}
+			dbVin.ChainID = null.Int64From(chainID)
+
+			// the submitting wallet owns the VIN until it's minted, then ownership comes from chain
+			if dbVin.VehicleTokenID.IsZero() {
+				dbVin.OwnerAddress = null.StringFrom(walletAddress.Hex())
+			}
+
 			if v.canSubmitVerificationJob(dbVin) {
 				localLog.Debug().Str(logfields.VIN, vin.Vin).Str(logfields.CountryCode, vin.CountryCode).Msg("Submitting VIN verification job")
 				_, err = v.riverClient.Insert(c.Context(), onboarding.VerifyArgs{
 					VIN:         vin.Vin,
 					CountryCode: vin.CountryCode,
+					ChainID:     chainID,
 				}, nil)
 
 				if err != nil {
@@ -484,9 +696,9 @@ func (v *VehicleController) SubmitVerificationForVins(c *fiber.Ctx) error {
 			err = v.vs.InsertOrUpdateVin(c.Context(), dbVin)
 
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": fmt.Sprintf("Failed to submit verification for vin: %s", vin),
-				})
+				localLog.Error().Str(logfields.VIN, vin.Vin).Err(err).Msg("Failed to save VIN status")
+				b.fail(vin.Vin, apierrors.CodeDatabaseFailed, "Failed to save VIN status")
+				continue
 			}
 			localLog.Debug().Str(logfields.VIN, vin.Vin).Str(logfields.CountryCode, vin.CountryCode).Msg("Submitted Verification for VIN")
 		}
@@ -494,6 +706,7 @@ func (v *VehicleController) SubmitVerificationForVins(c *fiber.Ctx) error {
 
 	return c.JSON(StatusForVinsResponse{
 		Statuses: vinStatuses,
+		Errors:   b.errors,
 	})
 }
 
@@ -501,43 +714,37 @@ type VinStatus struct {
 	Vin     string `json:"vin"`
 	Status  string `json:"status"`
 	Details string `json:"details"`
+	// Sacd is the state of the SACD grant requested on mint, signature_required means the owner has to submit it separately
+	Sacd string `json:"sacd,omitempty"`
 }
 
 type StatusForVinsResponse struct {
 	Statuses []VinStatus `json:"statuses"`
+	Errors   []VinError  `json:"errors,omitempty"`
 }
 
+// GetMintDataForVins
+// @Summary Get the typed data to sign for minting each of the submitted VINs
+// @Produce json
+// @Param vins query []string true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} MintDataForVins
+// @Security     BearerAuth
+// @Router /v1/vehicle/mint [get]
 func (v *VehicleController) GetMintDataForVins(c *fiber.Ctx) error {
 	walletAddress := c.Locals("wallet").(common.Address)
 
 	params := new(VinsGetParams)
 	if err := c.QueryParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse VINs",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
 	}
 	localLog := v.logger.With().Interface("vins", params.Vins).Str(logfields.FunctionName, "GetMintDataForVins").Logger()
 	localLog.Debug().Msg("Checking Verification Status for Vins")
 
-	validVins := make([]string, 0, len(params.Vins))
-	for _, vin := range params.Vins {
-		strippedVin := strings.TrimSpace(vin)
-		if v.isValidVin(strippedVin) {
-			validVins = append(validVins, strippedVin)
-		}
-	}
-
-	if len(validVins) != len(params.Vins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid VINs provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs for get mint", len(validVins))
@@ -554,12 +761,10 @@ func (v *VehicleController) GetMintDataForVins(c *fiber.Ctx) error {
 		)
 		if err != nil {
 			if errors.Is(err, service.ErrVehicleNotFound) {
-				return fiber.NewError(fiber.StatusBadRequest, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		mintedVins, err := v.vs.GetVehiclesByVinsAndOnboardingStatus(
@@ -569,9 +774,7 @@ func (v *VehicleController) GetMintDataForVins(c *fiber.Ctx) error {
 		)
 		if err != nil {
 			if !errors.Is(err, service.ErrVehicleNotFound) {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": "Failed to load vehicles from Database",
-				})
+				return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 			}
 		}
 
@@ -584,30 +787,35 @@ func (v *VehicleController) GetMintDataForVins(c *fiber.Ctx) error {
 
 		dbVins = append(dbVins, vendorFailedMintedVins...)
 
-		if len(dbVins) != len(validVins) {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Some of the VINs are not verified or already onboarded",
-			})
-		}
-
 		indexedVins := make(map[string]*dbmodels.Vin)
 		for _, vin := range dbVins {
 			indexedVins[vin.Vin] = vin
 		}
 
-		for _, dbVin := range dbVins {
+		for _, vin := range validVins {
+			dbVin, ok := indexedVins[vin]
+			if !ok {
+				b.fail(vin, apierrors.CodeStateConflict, "VIN is not verified or already onboarded")
+				continue
+			}
+
 			localLog.Debug().Str(logfields.DefinitionID, dbVin.DeviceDefinitionID.String).Msgf("getting definition for vin")
-			definition, err := v.identity.GetDeviceDefinitionByID(dbVin.DeviceDefinitionID.String)
+			definition, err := v.identity.GetDeviceDefinitionByID(c.Context(), dbVin.DeviceDefinitionID.String)
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": "Failed to load device definition",
-				})
+				localLog.Error().Str(logfields.VIN, vin).Err(err).Msg("Failed to load device definition")
+				b.fail(vin, apierrors.CodeUpstreamIdentityFailed, "Failed to load device definition")
+				continue
+			}
+
+			tr, ok := v.transactionsClient(b, dbVin)
+			if !ok {
+				continue
 			}
 
 			var typedData *signer.TypedData
 
 			if dbVin.VehicleTokenID.IsZero() {
-				typedData = v.tr.GetMintVehicleWithDDTypedData(
+				typedData = tr.GetMintVehicleWithDDTypedData(
 					new(big.Int).SetUint64(definition.Manufacturer.TokenID),
 					walletAddress,
 					definition.DeviceDefinitionID,
@@ -633,24 +841,21 @@ func (v *VehicleController) GetMintDataForVins(c *fiber.Ctx) error {
 
 				if v.settings.EnableMintingWithConnectionTokenID {
 					integrationOrConnectionID, ok = new(big.Int).SetString(v.settings.ConnectionTokenID, 10)
-					typedData = v.tr.GetMintSDTypedDataV2(integrationOrConnectionID, big.NewInt(dbVin.VehicleTokenID.Int64))
+					typedData = tr.GetMintSDTypedDataV2(integrationOrConnectionID, big.NewInt(dbVin.VehicleTokenID.Int64))
 				} else {
 					integrationOrConnectionID, ok = new(big.Int).SetString(v.settings.IntegrationTokenID, 10)
-					typedData = v.tr.GetMintSDTypedData(integrationOrConnectionID, big.NewInt(dbVin.VehicleTokenID.Int64))
+					typedData = tr.GetMintSDTypedData(integrationOrConnectionID, big.NewInt(dbVin.VehicleTokenID.Int64))
 				}
 
 				if !ok {
 					v.logger.Error().Err(err).Msg("Failed to set integration or connection token ID")
-					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-						"error": "Failed to set integration or connection token ID",
-					})
+					return apierrors.New(apierrors.CodeInternal, "Failed to set integration or connection token ID")
 				}
 
 			} else {
 				if dbVin.ConnectionStatus.String != kafka.OperationStatusFailed {
-					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-						"error": "VIN already fully minted and connected or connection in progress",
-					})
+					b.fail(vin, apierrors.CodeStateConflict, "VIN already fully minted and connected or connection in progress")
+					continue
 				}
 			}
 
@@ -664,10 +869,15 @@ func (v *VehicleController) GetMintDataForVins(c *fiber.Ctx) error {
 
 			mintingData = append(mintingData, vinMintingData)
 		}
+
+		if b.shouldAbort(len(mintingData)) {
+			return b.rejected()
+		}
 	}
 
 	return c.JSON(MintDataForVins{
 		VinMintingData: mintingData,
+		Errors:         b.errors,
 	})
 }
 
@@ -687,6 +897,7 @@ type VinTransactionData struct {
 type MintDataForVins struct {
 	VinMintingData []VinTransactionData `json:"vinMintingData"`
 	Sacd           SacdInput            `json:"sacd,omitempty"`
+	Errors         []VinError           `json:"errors,omitempty"`
 }
 
 type VinUserOperationData struct {
@@ -698,46 +909,62 @@ type VinUserOperationData struct {
 
 type DisconnectDataForVins struct {
 	VinDisconnectData []VinUserOperationData `json:"vinDisconnectData"`
+	Errors            []VinError             `json:"errors,omitempty"`
+}
+
+// mintSacdStatus returns the state of the SACD grant requested on mint, only a new vehicle NFT gets it in the mint transaction
+func mintSacdStatus(record *dbmodels.Vin, sacd *onboarding.OnboardingSacd) string {
+	if sacd == nil {
+		return ""
+	}
+	if record.VehicleTokenID.Valid {
+		return onboarding.SacdStatusSignatureRequired
+	}
+
+	return onboarding.SacdStatusPending
 }
 
+// SubmitMintDataForVins
+// @Summary Submit the signed minting typed data
+// @Description Optionally grants SACD on the minted vehicles. SACD is only set in the mint transaction when the vehicle NFT
+// @Description is minted too, VINs whose vehicle already exists report sacd signature_required and need a separate signed
+// @Description grant through /v1/vehicle/sacd.
+// @Accept json
+// @Produce json
+// @Param payload body MintDataForVins true "signed typed data"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/mint [post]
 func (v *VehicleController) SubmitMintDataForVins(c *fiber.Ctx) error {
 	walletAddress := c.Locals("wallet").(common.Address)
 
 	params := new(MintDataForVins)
 	if err := c.BodyParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse minting data",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse minting data")
 	}
 
 	localLog := v.logger.With().Str(logfields.FunctionName, "SubmitMintDataForVins").Logger()
 	localLog.Debug().Msg("Submitting VINs to mint")
 
+	b := newBatch(c)
 	validVins := make([]string, 0, len(params.VinMintingData))
 	validVinsMintingData := make([]VinTransactionData, 0, len(params.VinMintingData))
 	for _, paramVin := range params.VinMintingData {
-		validatedVinMintingData, err := v.getValidatedMintingData(&paramVin, walletAddress)
+		validatedVinMintingData, err := v.getValidatedMintingData(c.Context(), &paramVin, walletAddress)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid minting data",
-			})
+			b.fail(paramVin.Vin, vinErrorCode(err), err.Error())
+			continue
 		}
 
 		validVins = append(validVins, validatedVinMintingData.Vin)
 		validVinsMintingData = append(validVinsMintingData, *validatedVinMintingData)
 	}
 
-	if len(validVins) != len(params.VinMintingData) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid minting data provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b.rejectDuplicates(validVins)
+	validVins = b.valid(validVins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs", len(validVins))
@@ -748,12 +975,10 @@ func (v *VehicleController) SubmitMintDataForVins(c *fiber.Ctx) error {
 		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
-				return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		mintedVins, err := v.vs.GetVehiclesByVinsAndOnboardingStatus(
@@ -763,9 +988,7 @@ func (v *VehicleController) SubmitMintDataForVins(c *fiber.Ctx) error {
 		)
 		if err != nil {
 			if !errors.Is(err, service.ErrVehicleNotFound) {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": "Failed to load vehicles from Database",
-				})
+				return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 			}
 		}
 
@@ -784,6 +1007,10 @@ func (v *VehicleController) SubmitMintDataForVins(c *fiber.Ctx) error {
 		}
 
 		for _, mint := range validVinsMintingData {
+			if b.hasFailed(mint.Vin) {
+				continue
+			}
+
 			dbVin, ok := indexedDbVins[mint.Vin]
 			if !ok {
 				dbVin = &dbmodels.Vin{
@@ -826,6 +1053,7 @@ func (v *VehicleController) SubmitMintDataForVins(c *fiber.Ctx) error {
 						Vin:     mint.Vin,
 						Status:  "Pending",
 						Details: onboarding.GetDetailedStatus(onboarding.OnboardingStatusMintSubmitPending),
+						Sacd:    mintSacdStatus(dbVin, sacd),
 					})
 				}
 			} else {
@@ -840,9 +1068,9 @@ func (v *VehicleController) SubmitMintDataForVins(c *fiber.Ctx) error {
 			err = v.vs.InsertOrUpdateVin(c.Context(), dbVin)
 
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": fmt.Sprintf("Failed to submit verification for mint: %v", mint),
-				})
+				localLog.Error().Str(logfields.VIN, mint.Vin).Err(err).Msg("Failed to save VIN status")
+				b.fail(mint.Vin, apierrors.CodeDatabaseFailed, "Failed to save VIN status")
+				continue
 			}
 			localLog.Debug().Str(logfields.VIN, mint.Vin).Msg("Submitted mint for VIN")
 		}
@@ -850,16 +1078,17 @@ func (v *VehicleController) SubmitMintDataForVins(c *fiber.Ctx) error {
 
 	return c.JSON(StatusForVinsResponse{
 		Statuses: statuses,
+		Errors:   b.errors,
 	})
 }
 
-func (v *VehicleController) getValidatedMintingData(data *VinTransactionData, _ common.Address) (*VinTransactionData, error) {
+func (v *VehicleController) getValidatedMintingData(ctx context.Context, data *VinTransactionData, _ common.Address) (*VinTransactionData, error) {
 	result := new(VinTransactionData)
 
 	// Validate VIN
-	strippedVin := strings.TrimSpace(data.Vin)
-	if !v.isValidVin(strippedVin) {
-		return nil, errors.New("invalid VIN")
+	strippedVin, err := v.normalizeVin(data.Vin)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VIN %q: %w", data.Vin, err)
 	}
 
 	// Signature validation would require call to a wallet contract
@@ -884,7 +1113,7 @@ func (v *VehicleController) getValidatedMintingData(data *VinTransactionData, _
 
 	// Validate typed data with device definition (if applicable)
 	if data.TypedData != nil && data.TypedData.PrimaryType == "MintVehicleWithDeviceDefinitionSign" {
-		_, err := v.identity.GetDeviceDefinitionByID(data.TypedData.Message["deviceDefinitionId"].(string))
+		_, err := v.identity.GetDeviceDefinitionByID(ctx, data.TypedData.Message["deviceDefinitionId"].(string))
 		if err != nil {
 			return nil, err
 		}
@@ -903,6 +1132,11 @@ func (v *VehicleController) canSubmitMintingJob(record *dbmodels.Vin) bool {
 		return false
 	}
 
+	// paused VINs are only brought back by resuming, which checks the owner and records the history
+	if onboarding.IsPausePhase(record.OnboardingStatus) {
+		return false
+	}
+
 	minted := onboarding.IsMinted(record.OnboardingStatus)
 	burned := onboarding.IsDisconnected(record.OnboardingStatus)
 	failed := onboarding.IsFailure(record.OnboardingStatus)
@@ -912,36 +1146,27 @@ func (v *VehicleController) canSubmitMintingJob(record *dbmodels.Vin) bool {
 	return (minted && failedConnection) || (!minted || burned) && (failed || !pending)
 }
 
+// GetMintStatusForVins
+// @Summary Get minting status for each of the submitted VINs
+// @Produce json
+// @Param vins query []string true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/mint/status [get]
 func (v *VehicleController) GetMintStatusForVins(c *fiber.Ctx) error {
 	params := new(VinsGetParams)
 	if err := c.QueryParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse VINs",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
 	}
 
 	localLog := v.logger.With().Str(logfields.FunctionName, "GetMintStatusForVins").Interface("validVins", params.Vins).Logger()
 	localLog.Debug().Interface("vins", params.Vins).Msg("Checking Verification Status for Vins")
 
-	validVins := make([]string, 0, len(params.Vins))
-	for _, vin := range params.Vins {
-		strippedVin := strings.TrimSpace(vin)
-		if v.isValidVin(strippedVin) {
-			validVins = append(validVins, strippedVin)
-		}
-	}
-
-	if len(validVins) != len(params.Vins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid VINs provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs", len(validVins))
@@ -952,12 +1177,10 @@ func (v *VehicleController) GetMintStatusForVins(c *fiber.Ctx) error {
 		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
 		if err != nil {
 			if errors.Is(err, service.ErrVehicleNotFound) {
-				return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		indexedVins := make(map[string]*dbmodels.Vin)
@@ -985,21 +1208,22 @@ func (v *VehicleController) GetMintStatusForVins(c *fiber.Ctx) error {
 
 	return c.JSON(StatusForVinsResponse{
 		Statuses: statuses,
+		Errors:   b.errors,
 	})
 }
 
 // GetDisconnectDataForVins
-// @Summary Get verification status for each of the submitted VINs
+// @Summary Get the user operations to sign for burning the synthetic devices of the submitted VINs
 // @Produce json
-// @Success 200
+// @Param vins query []string true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} DisconnectDataForVins
 // @Security     BearerAuth
 // @Router /v1/vehicle/disconnect [get]
 func (v *VehicleController) GetDisconnectDataForVins(c *fiber.Ctx) error {
 	params := new(VinsGetParams)
 	if err := c.QueryParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse VINs",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
 	}
 
 	walletAddress := c.Locals("wallet").(common.Address)
@@ -1007,25 +1231,10 @@ func (v *VehicleController) GetDisconnectDataForVins(c *fiber.Ctx) error {
 	localLog := v.logger.With().Interface("vins", params.Vins).Str(logfields.FunctionName, "GetDisconnectDataForVins").Logger()
 	localLog.Debug().Msg("Getting disconnection data for Vins")
 
-	validVins := make([]string, 0, len(params.Vins))
-	for _, vin := range params.Vins {
-		strippedVin := strings.TrimSpace(vin)
-		if v.isValidVin(strippedVin) {
-			validVins = append(validVins, strippedVin)
-		}
-	}
-
-	if len(validVins) != len(params.Vins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid VINs provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs for disconnection", len(validVins))
@@ -1033,21 +1242,13 @@ func (v *VehicleController) GetDisconnectDataForVins(c *fiber.Ctx) error {
 	disconnectionData := make([]VinUserOperationData, 0, len(validVins))
 
 	if len(validVins) > 0 {
-		dbVins, err := v.vs.GetVehiclesByVinsAndOnboardingStatusRange(c.Context(), validVins, onboarding.OnboardingStatusMintSuccess, onboarding.OnboardingStatusBurnSDFailure, nil)
+		dbVins, err := v.vs.GetVehiclesByVinsAndOnboardingStatusRange(c.Context(), validVins, onboarding.OnboardingStatusMintSuccess, onboarding.OnboardingStatusBurnSDFailure, []int{onboarding.OnboardingStatusPauseFailure, onboarding.OnboardingStatusPauseSuccess, onboarding.OnboardingStatusResumeFailure})
 		if err != nil {
 			if errors.Is(err, service.ErrVehicleNotFound) {
-				return fiber.NewError(fiber.StatusBadRequest, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
-		}
-
-		if len(dbVins) != len(validVins) {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Some of the VINs are not fully onboarded",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		indexedVins := make(map[string]*dbmodels.Vin)
@@ -1055,47 +1256,48 @@ func (v *VehicleController) GetDisconnectDataForVins(c *fiber.Ctx) error {
 			indexedVins[vin.Vin] = vin
 		}
 
-		identityVehicles, err := v.identity.FetchVehiclesByWalletAddress(walletAddress.String())
+		indexedIdentityVehicles, err := v.fetchOwnedVehicles(c.Context(), walletAddress, dbVins)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to fetch identity vehicles",
-			})
+			return apierrors.Wrap(err, apierrors.CodeUpstreamIdentityFailed, "Failed to fetch identity vehicles")
 		}
 
-		indexedIdentityVehicles := make(map[int64]models.Vehicle)
-		for _, identityVehicle := range identityVehicles {
-			indexedIdentityVehicles[identityVehicle.TokenID] = identityVehicle
-		}
+		for _, vin := range validVins {
+			dbVin, ok := indexedVins[vin]
+			if !ok {
+				b.fail(vin, apierrors.CodeStateConflict, "VIN is not fully onboarded")
+				continue
+			}
 
-		for _, dbVin := range dbVins {
 			identityVehicle, ok := indexedIdentityVehicles[dbVin.VehicleTokenID.Int64]
 			if !ok {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"error": "VIN not owned",
-				})
+				b.fail(vin, apierrors.CodeVinNotOwned, "VIN not owned")
+				continue
 			}
 
 			fullyConnected := !dbVin.VehicleTokenID.IsZero() && !dbVin.SyntheticTokenID.IsZero()
 
 			if !fullyConnected {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"error": "VIN not minted",
-				})
+				b.fail(vin, apierrors.CodeStateConflict, "VIN not minted")
+				continue
 			}
 
 			fullyConnectedIdentity := identityVehicle.TokenID == dbVin.VehicleTokenID.Int64 && identityVehicle.SyntheticDevice.TokenID == dbVin.SyntheticTokenID.Int64
 
 			if !fullyConnectedIdentity {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"error": "TokenIDs mismatch",
-				})
+				b.fail(vin, apierrors.CodeTokenMismatch, "TokenIDs mismatch")
+				continue
+			}
+
+			tr, ok := v.transactionsClient(b, dbVin)
+			if !ok {
+				continue
 			}
 
-			op, hash, err := v.tr.GetBurnSDByOwnerUserOperationAndHash(walletAddress, big.NewInt(dbVin.SyntheticTokenID.Int64))
+			op, hash, err := tr.GetBurnSDByOwnerUserOperationAndHash(walletAddress, big.NewInt(dbVin.SyntheticTokenID.Int64))
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": "Failed to get Burn SD operation data",
-				})
+				localLog.Error().Str(logfields.VIN, vin).Err(err).Msg("Failed to get Burn SD operation data")
+				b.fail(vin, apierrors.CodeUpstreamChainFailed, "Failed to get Burn SD operation data")
+				continue
 			}
 
 			vinMintingData := VinUserOperationData{
@@ -1106,51 +1308,56 @@ func (v *VehicleController) GetDisconnectDataForVins(c *fiber.Ctx) error {
 
 			disconnectionData = append(disconnectionData, vinMintingData)
 		}
+
+		if b.shouldAbort(len(disconnectionData)) {
+			return b.rejected()
+		}
 	}
 
 	return c.JSON(DisconnectDataForVins{
 		VinDisconnectData: disconnectionData,
+		Errors:            b.errors,
 	})
 }
 
+// SubmitDisconnectDataForVins
+// @Summary Submit the signed disconnection user operations
+// @Accept json
+// @Produce json
+// @Param payload body DisconnectDataForVins true "signed user operations"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/disconnect [post]
 func (v *VehicleController) SubmitDisconnectDataForVins(c *fiber.Ctx) error {
 	walletAddress := c.Locals("wallet").(common.Address)
 
 	params := new(DisconnectDataForVins)
 	if err := c.BodyParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse disconnection data",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse disconnection data")
 	}
 
 	localLog := v.logger.With().Str(logfields.FunctionName, "SubmitDisconnectDataForVins").Logger()
 	localLog.Debug().Msg("Submitting VINs to disconnect")
 
+	b := newBatch(c)
 	validVins := make([]string, 0, len(params.VinDisconnectData))
 	validVinsDisconnectData := make([]VinUserOperationData, 0, len(params.VinDisconnectData))
 	for _, paramVin := range params.VinDisconnectData {
 		validatedVinMintingData, err := v.getValidatedUserOperationData(&paramVin, walletAddress)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid disconnect data",
-			})
+			b.fail(paramVin.Vin, vinErrorCode(err), err.Error())
+			continue
 		}
 
 		validVins = append(validVins, validatedVinMintingData.Vin)
 		validVinsDisconnectData = append(validVinsDisconnectData, *validatedVinMintingData)
 	}
 
-	if len(validVins) != len(params.VinDisconnectData) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid disconnect data provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b.rejectDuplicates(validVins)
+	validVins = b.valid(validVins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs submitted to disconnect", len(validVins))
@@ -1161,12 +1368,10 @@ func (v *VehicleController) SubmitDisconnectDataForVins(c *fiber.Ctx) error {
 		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
-				return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		indexedDbVins := make(map[string]*dbmodels.Vin)
@@ -1175,6 +1380,10 @@ func (v *VehicleController) SubmitDisconnectDataForVins(c *fiber.Ctx) error {
 		}
 
 		for _, disconnect := range validVinsDisconnectData {
+			if b.hasFailed(disconnect.Vin) {
+				continue
+			}
+
 			dbVin, ok := indexedDbVins[disconnect.Vin]
 			if !ok {
 				dbVin = &dbmodels.Vin{
@@ -1221,9 +1430,9 @@ func (v *VehicleController) SubmitDisconnectDataForVins(c *fiber.Ctx) error {
 			err = v.vs.InsertOrUpdateVin(c.Context(), dbVin)
 
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": fmt.Sprintf("Failed to submit disconnect: %v", disconnect),
-				})
+				localLog.Error().Str(logfields.VIN, disconnect.Vin).Err(err).Msg("Failed to save VIN status")
+				b.fail(disconnect.Vin, apierrors.CodeDatabaseFailed, "Failed to save VIN status")
+				continue
 			}
 			localLog.Debug().Str(logfields.VIN, disconnect.Vin).Msg("Submitted disconnect for VIN")
 		}
@@ -1231,6 +1440,7 @@ func (v *VehicleController) SubmitDisconnectDataForVins(c *fiber.Ctx) error {
 
 	return c.JSON(StatusForVinsResponse{
 		Statuses: statuses,
+		Errors:   b.errors,
 	})
 }
 
@@ -1238,9 +1448,9 @@ func (v *VehicleController) getValidatedUserOperationData(data *VinUserOperation
 	result := new(VinUserOperationData)
 
 	// Validate VIN
-	strippedVin := strings.TrimSpace(data.Vin)
-	if !v.isValidVin(strippedVin) {
-		return nil, errors.New("invalid VIN")
+	strippedVin, err := v.normalizeVin(data.Vin)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VIN %q: %w", data.Vin, err)
 	}
 
 	result.Vin = strippedVin
@@ -1255,43 +1465,35 @@ func (v *VehicleController) canSubmitDisconnectJob(record *dbmodels.Vin) bool {
 		return false
 	}
 
-	minted := onboarding.IsMinted(record.OnboardingStatus)
+	// paused VINs keep the SD, so they can be disconnected too
+	minted := onboarding.CanPause(record.OnboardingStatus) || onboarding.IsPaused(record.OnboardingStatus)
 	failed := onboarding.IsDisconnectFailed(record.OnboardingStatus)
 	pending := onboarding.IsDisconnectPending(record.OnboardingStatus)
 
 	return (minted || failed) && !pending
 }
 
+// GetDisconnectStatusForVins
+// @Summary Get disconnection status for each of the submitted VINs
+// @Produce json
+// @Param vins query []string true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/disconnect/status [get]
 func (v *VehicleController) GetDisconnectStatusForVins(c *fiber.Ctx) error {
 	params := new(VinsGetParams)
 	if err := c.QueryParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse VINs",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
 	}
 
 	localLog := v.logger.With().Str(logfields.FunctionName, "GetDisconnectStatusForVins").Interface("validVins", params.Vins).Logger()
 	localLog.Debug().Interface("vins", params.Vins).Msg("Checking Disconnect Status for Vins")
 
-	validVins := make([]string, 0, len(params.Vins))
-	for _, vin := range params.Vins {
-		strippedVin := strings.TrimSpace(vin)
-		if v.isValidVin(strippedVin) {
-			validVins = append(validVins, strippedVin)
-		}
-	}
-
-	if len(validVins) != len(params.Vins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid VINs provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs", len(validVins))
@@ -1302,12 +1504,10 @@ func (v *VehicleController) GetDisconnectStatusForVins(c *fiber.Ctx) error {
 		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
 		if err != nil {
 			if errors.Is(err, service.ErrVehicleNotFound) {
-				return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		indexedVins := make(map[string]*dbmodels.Vin)
@@ -1335,5 +1535,6 @@ func (v *VehicleController) GetDisconnectStatusForVins(c *fiber.Ctx) error {
 
 	return c.JSON(StatusForVinsResponse{
 		Statuses: statuses,
+		Errors:   b.errors,
 	})
 }
@@ -2,9 +2,8 @@ package controllers
 
 import (
 	"database/sql"
-	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
 	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
-	"github.com/DIMO-Network/oracle-example/internal/models"
 	"github.com/DIMO-Network/oracle-example/internal/onboarding"
 	"github.com/DIMO-Network/oracle-example/internal/service"
 	"github.com/DIMO-Network/shared/pkg/logfields"
@@ -12,20 +11,25 @@ import (
 	"github.com/friendsofgo/errors"
 	"github.com/gofiber/fiber/v2"
 	"math/big"
-	"slices"
-	"strings"
 )
 
 type DeleteDataForVins struct {
 	VinDeleteData []VinUserOperationData `json:"vinDeleteData"`
+	Errors        []VinError             `json:"errors,omitempty"`
 }
 
+// GetDeleteDataForVins
+// @Summary Get the user operations to sign for burning each of the submitted, disconnected VINs
+// @Produce json
+// @Param vins query []string true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} DeleteDataForVins
+// @Security     BearerAuth
+// @Router /v1/vehicle/delete [get]
 func (v *VehicleController) GetDeleteDataForVins(c *fiber.Ctx) error {
 	params := new(VinsGetParams)
 	if err := c.QueryParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse VINs",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
 	}
 
 	walletAddress := c.Locals("wallet").(common.Address)
@@ -33,25 +37,10 @@ func (v *VehicleController) GetDeleteDataForVins(c *fiber.Ctx) error {
 	localLog := v.logger.With().Interface("vins", params.Vins).Str(logfields.FunctionName, "GetDeleteDataForVins").Logger()
 	localLog.Debug().Msg("Getting deletion data for Vins")
 
-	validVins := make([]string, 0, len(params.Vins))
-	for _, vin := range params.Vins {
-		strippedVin := strings.TrimSpace(vin)
-		if v.isValidVin(strippedVin) {
-			validVins = append(validVins, strippedVin)
-		}
-	}
-
-	if len(validVins) != len(params.Vins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid VINs provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs for deletion", len(validVins))
@@ -62,18 +51,10 @@ func (v *VehicleController) GetDeleteDataForVins(c *fiber.Ctx) error {
 		dbVins, err := v.vs.GetVehiclesByVinsAndOnboardingStatusRange(c.Context(), validVins, onboarding.OnboardingStatusBurnSDSuccess, onboarding.OnboardingStatusBurnVehicleFailure, nil)
 		if err != nil {
 			if errors.Is(err, service.ErrVehicleNotFound) {
-				return fiber.NewError(fiber.StatusBadRequest, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
-		}
-
-		if len(dbVins) != len(validVins) {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Some of the VINs are not disconnected",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		indexedVins := make(map[string]*dbmodels.Vin)
@@ -81,47 +62,48 @@ func (v *VehicleController) GetDeleteDataForVins(c *fiber.Ctx) error {
 			indexedVins[vin.Vin] = vin
 		}
 
-		identityVehicles, err := v.identity.FetchVehiclesByWalletAddress(walletAddress.String())
+		indexedIdentityVehicles, err := v.fetchOwnedVehicles(c.Context(), walletAddress, dbVins)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to fetch identity vehicles",
-			})
+			return apierrors.Wrap(err, apierrors.CodeUpstreamIdentityFailed, "Failed to fetch identity vehicles")
 		}
 
-		indexedIdentityVehicles := make(map[int64]models.Vehicle)
-		for _, identityVehicle := range identityVehicles {
-			indexedIdentityVehicles[identityVehicle.TokenID] = identityVehicle
-		}
+		for _, vin := range validVins {
+			dbVin, ok := indexedVins[vin]
+			if !ok {
+				b.fail(vin, apierrors.CodeStateConflict, "VIN is not disconnected")
+				continue
+			}
 
-		for _, dbVin := range dbVins {
 			identityVehicle, ok := indexedIdentityVehicles[dbVin.VehicleTokenID.Int64]
 			if !ok {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"error": "VIN not owned",
-				})
+				b.fail(vin, apierrors.CodeVinNotOwned, "VIN not owned")
+				continue
 			}
 
 			burnable := !dbVin.VehicleTokenID.IsZero() && dbVin.SyntheticTokenID.IsZero()
 
 			if !burnable {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"error": "VIN cannot be burned",
-				})
+				b.fail(vin, apierrors.CodeStateConflict, "VIN cannot be burned")
+				continue
 			}
 
 			burnableIdentity := identityVehicle.TokenID == dbVin.VehicleTokenID.Int64 && identityVehicle.SyntheticDevice.TokenID == 0
 
 			if !burnableIdentity {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"error": "TokenIDs mismatch",
-				})
+				b.fail(vin, apierrors.CodeTokenMismatch, "TokenIDs mismatch")
+				continue
 			}
 
-			op, hash, err := v.tr.GetBurnVehicleByOwnerUserOperationAndHash(walletAddress, big.NewInt(dbVin.VehicleTokenID.Int64))
+			tr, ok := v.transactionsClient(b, dbVin)
+			if !ok {
+				continue
+			}
+
+			op, hash, err := tr.GetBurnVehicleByOwnerUserOperationAndHash(walletAddress, big.NewInt(dbVin.VehicleTokenID.Int64))
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": "Failed to get Burn Vehicle operation data",
-				})
+				localLog.Error().Str(logfields.VIN, vin).Err(err).Msg("Failed to get Burn Vehicle operation data")
+				b.fail(vin, apierrors.CodeUpstreamChainFailed, "Failed to get Burn Vehicle operation data")
+				continue
 			}
 
 			vinMintingData := VinUserOperationData{
@@ -132,51 +114,56 @@ func (v *VehicleController) GetDeleteDataForVins(c *fiber.Ctx) error {
 
 			deletionData = append(deletionData, vinMintingData)
 		}
+
+		if b.shouldAbort(len(deletionData)) {
+			return b.rejected()
+		}
 	}
 
 	return c.JSON(DeleteDataForVins{
 		VinDeleteData: deletionData,
+		Errors:        b.errors,
 	})
 }
 
+// SubmitDeleteDataForVins
+// @Summary Submit the signed deletion user operations
+// @Accept json
+// @Produce json
+// @Param payload body DeleteDataForVins true "signed user operations"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/delete [post]
 func (v *VehicleController) SubmitDeleteDataForVins(c *fiber.Ctx) error {
 	walletAddress := c.Locals("wallet").(common.Address)
 
 	params := new(DeleteDataForVins)
 	if err := c.BodyParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse delete data",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse delete data")
 	}
 
 	localLog := v.logger.With().Str(logfields.FunctionName, "SubmitDeleteDataForVins").Logger()
 	localLog.Debug().Msg("Submitting VINs to delete")
 
+	b := newBatch(c)
 	validVins := make([]string, 0, len(params.VinDeleteData))
 	validVinsDeleteData := make([]VinUserOperationData, 0, len(params.VinDeleteData))
 	for _, paramVin := range params.VinDeleteData {
 		validatedVinDeleteData, err := v.getValidatedUserOperationData(&paramVin, walletAddress)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid delete data",
-			})
+			b.fail(paramVin.Vin, vinErrorCode(err), err.Error())
+			continue
 		}
 
 		validVins = append(validVins, validatedVinDeleteData.Vin)
 		validVinsDeleteData = append(validVinsDeleteData, *validatedVinDeleteData)
 	}
 
-	if len(validVins) != len(params.VinDeleteData) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid delete data provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b.rejectDuplicates(validVins)
+	validVins = b.valid(validVins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs submitted to delete", len(validVins))
@@ -187,12 +174,10 @@ func (v *VehicleController) SubmitDeleteDataForVins(c *fiber.Ctx) error {
 		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
-				return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		indexedDbVins := make(map[string]*dbmodels.Vin)
@@ -201,6 +186,10 @@ func (v *VehicleController) SubmitDeleteDataForVins(c *fiber.Ctx) error {
 		}
 
 		for _, deleteVehicle := range validVinsDeleteData {
+			if b.hasFailed(deleteVehicle.Vin) {
+				continue
+			}
+
 			dbVin, ok := indexedDbVins[deleteVehicle.Vin]
 			if !ok {
 				dbVin = &dbmodels.Vin{
@@ -247,9 +236,9 @@ func (v *VehicleController) SubmitDeleteDataForVins(c *fiber.Ctx) error {
 			err = v.vs.InsertOrUpdateVin(c.Context(), dbVin)
 
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": fmt.Sprintf("Failed to submit deleteVehicle: %v", deleteVehicle),
-				})
+				localLog.Error().Str(logfields.VIN, deleteVehicle.Vin).Err(err).Msg("Failed to save VIN status")
+				b.fail(deleteVehicle.Vin, apierrors.CodeDatabaseFailed, "Failed to save VIN status")
+				continue
 			}
 			localLog.Debug().Str(logfields.VIN, deleteVehicle.Vin).Msg("Submitted deleteVehicle for VIN")
 		}
@@ -257,6 +246,7 @@ func (v *VehicleController) SubmitDeleteDataForVins(c *fiber.Ctx) error {
 
 	return c.JSON(StatusForVinsResponse{
 		Statuses: statuses,
+		Errors:   b.errors,
 	})
 }
 
@@ -272,36 +262,27 @@ func (v *VehicleController) canSubmitDeleteJob(record *dbmodels.Vin) bool {
 	return (disconnected || failed) && !pending
 }
 
+// GetDeleteStatusForVins
+// @Summary Get deletion status for each of the submitted VINs
+// @Produce json
+// @Param vins query []string true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/delete/status [get]
 func (v *VehicleController) GetDeleteStatusForVins(c *fiber.Ctx) error {
 	params := new(VinsGetParams)
 	if err := c.QueryParser(params); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Failed to parse VINs",
-		})
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
 	}
 
 	localLog := v.logger.With().Str(logfields.FunctionName, "GetDeleteStatusForVins").Interface("validVins", params.Vins).Logger()
 	localLog.Debug().Interface("vins", params.Vins).Msg("Checking Delete Status for Vins")
 
-	validVins := make([]string, 0, len(params.Vins))
-	for _, vin := range params.Vins {
-		strippedVin := strings.TrimSpace(vin)
-		if v.isValidVin(strippedVin) {
-			validVins = append(validVins, strippedVin)
-		}
-	}
-
-	if len(validVins) != len(params.Vins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid VINs provided",
-		})
-	}
-
-	compactedVins := slices.Compact(validVins)
-	if len(validVins) != len(compactedVins) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Duplicated VINs",
-		})
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
 	}
 
 	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs", len(validVins))
@@ -312,12 +293,10 @@ func (v *VehicleController) GetDeleteStatusForVins(c *fiber.Ctx) error {
 		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
 		if err != nil {
 			if errors.Is(err, service.ErrVehicleNotFound) {
-				return fiber.NewError(fiber.StatusNotFound, "Could not find Vehicles")
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
 			}
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load vehicles from Database",
-			})
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
 		}
 
 		indexedVins := make(map[string]*dbmodels.Vin)
@@ -345,5 +324,6 @@ func (v *VehicleController) GetDeleteStatusForVins(c *fiber.Ctx) error {
 
 	return c.JSON(StatusForVinsResponse{
 		Statuses: statuses,
+		Errors:   b.errors,
 	})
 }
@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/onboarding"
+	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/friendsofgo/errors"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SubmitPauseForVins
+// @Summary Pause telemetry for each of the submitted VINs
+// @Description Suspends the vendor connection and stops forwarding data, vehicle and synthetic device stay minted.
+// @Accept json
+// @Produce json
+// @Param payload body VinsGetParams true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/pause [post]
+func (v *VehicleController) SubmitPauseForVins(c *fiber.Ctx) error {
+	return v.submitPauseJobs(c, false)
+}
+
+// SubmitResumeForVins
+// @Summary Resume telemetry for each of the submitted VINs
+// @Accept json
+// @Produce json
+// @Param payload body VinsGetParams true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/resume [post]
+func (v *VehicleController) SubmitResumeForVins(c *fiber.Ctx) error {
+	return v.submitPauseJobs(c, true)
+}
+
+func (v *VehicleController) submitPauseJobs(c *fiber.Ctx, resume bool) error {
+	walletAddress := c.Locals("wallet").(common.Address)
+
+	params := new(VinsGetParams)
+	if err := c.BodyParser(params); err != nil {
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
+	}
+
+	localLog := v.logger.With().Str(logfields.FunctionName, "submitPauseJobs").Bool("resume", resume).Logger()
+	localLog.Debug().Interface("vins", params.Vins).Msg("Submitting VINs to pause or resume")
+
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
+	}
+
+	statuses := make([]VinStatus, 0, len(validVins))
+
+	if len(validVins) > 0 {
+		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
+		if err != nil {
+			if errors.Is(err, service.ErrVehicleNotFound) {
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
+			}
+
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
+		}
+
+		indexedDbVins := make(map[string]*dbmodels.Vin)
+		for _, vin := range dbVins {
+			indexedDbVins[vin.Vin] = vin
+		}
+
+		ownedVehicles, err := v.fetchOwnedVehicles(c.Context(), walletAddress, dbVins)
+		if err != nil {
+			return apierrors.Wrap(err, apierrors.CodeUpstreamIdentityFailed, "Failed to fetch identity vehicles")
+		}
+
+		// check all VINs before submitting anything, so atomic requests fail without side effects
+		for _, vin := range validVins {
+			if dbVin, ok := indexedDbVins[vin]; ok {
+				if _, owned := ownedVehicles[dbVin.VehicleTokenID.Int64]; !owned {
+					b.fail(vin, apierrors.CodeVinNotOwned, "VIN not owned")
+				}
+			}
+		}
+
+		validVins = b.valid(validVins)
+		if b.shouldAbort(len(validVins)) {
+			return b.rejected()
+		}
+
+		for _, vin := range validVins {
+			dbVin, ok := indexedDbVins[vin]
+			if !ok {
+				statuses = append(statuses, VinStatus{
+					Vin:     vin,
+					Status:  "Unknown",
+					Details: "Unknown",
+				})
+				continue
+			}
+
+			canSubmit := onboarding.CanPause(dbVin.OnboardingStatus)
+			pendingStatus, failureStatus := onboarding.OnboardingStatusPausePending, onboarding.OnboardingStatusPauseFailure
+			if resume {
+				canSubmit = onboarding.CanResume(dbVin.OnboardingStatus)
+				pendingStatus, failureStatus = onboarding.OnboardingStatusResumePending, onboarding.OnboardingStatusResumeFailure
+			}
+
+			if !canSubmit {
+				localLog.Debug().Str(logfields.VIN, vin).Msg("Skipping pause job submission")
+				statuses = append(statuses, VinStatus{
+					Vin:     vin,
+					Status:  onboarding.GetPauseStatus(dbVin.OnboardingStatus),
+					Details: onboarding.GetDetailedStatus(dbVin.OnboardingStatus),
+				})
+				continue
+			}
+
+			// set pending before the job is inserted, so the job result isn't overwritten
+			dbVin.OnboardingStatus = pendingStatus
+			if err = v.vs.InsertOrUpdateVin(c.Context(), dbVin); err != nil {
+				localLog.Error().Str(logfields.VIN, vin).Err(err).Msg("Failed to update VIN status")
+				b.fail(vin, apierrors.CodeDatabaseFailed, "Failed to update VIN status")
+				continue
+			}
+
+			_, err = v.riverClient.Insert(c.Context(), onboarding.PauseArgs{
+				VIN:    vin,
+				Resume: resume,
+			}, nil)
+			if err != nil {
+				localLog.Error().Str(logfields.VIN, vin).Err(err).Msg("Failed to submit pause job")
+				dbVin.OnboardingStatus = failureStatus
+				if err = v.vs.InsertOrUpdateVin(c.Context(), dbVin); err != nil {
+					localLog.Error().Str(logfields.VIN, vin).Err(err).Msg("Failed to update VIN status")
+				}
+
+				statuses = append(statuses, VinStatus{
+					Vin:     vin,
+					Status:  "Failure",
+					Details: onboarding.GetDetailedStatus(failureStatus),
+				})
+				continue
+			}
+
+			statuses = append(statuses, VinStatus{
+				Vin:     vin,
+				Status:  "Pending",
+				Details: onboarding.GetDetailedStatus(pendingStatus),
+			})
+		}
+	}
+
+	return c.JSON(StatusForVinsResponse{
+		Statuses: statuses,
+		Errors:   b.errors,
+	})
+}
+
+// GetPauseStatusForVins
+// @Summary Get pause status for each of the submitted VINs
+// @Description Status is one of Paused, Active, Pending, Failure or Unknown
+// @Produce json
+// @Param vins query []string true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/pause/status [get]
+func (v *VehicleController) GetPauseStatusForVins(c *fiber.Ctx) error {
+	params := new(VinsGetParams)
+	if err := c.QueryParser(params); err != nil {
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
+	}
+
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
+	}
+
+	statuses := make([]VinStatus, 0, len(validVins))
+
+	if len(validVins) > 0 {
+		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
+		if err != nil {
+			if errors.Is(err, service.ErrVehicleNotFound) {
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
+			}
+
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
+		}
+
+		indexedVins := make(map[string]*dbmodels.Vin)
+		for _, vin := range dbVins {
+			indexedVins[vin.Vin] = vin
+		}
+
+		for _, vin := range validVins {
+			dbVin, ok := indexedVins[vin]
+			if !ok {
+				statuses = append(statuses, VinStatus{
+					Vin:     vin,
+					Status:  "Unknown",
+					Details: "Unknown",
+				})
+			} else {
+				statuses = append(statuses, VinStatus{
+					Vin:     vin,
+					Status:  onboarding.GetPauseStatus(dbVin.OnboardingStatus),
+					Details: onboarding.GetDetailedStatus(dbVin.OnboardingStatus),
+				})
+			}
+		}
+	}
+
+	return c.JSON(StatusForVinsResponse{
+		Statuses: statuses,
+		Errors:   b.errors,
+	})
+}
@@ -0,0 +1,513 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"github.com/DIMO-Network/go-zerodev"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/onboarding"
+	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/friendsofgo/errors"
+	"github.com/gofiber/fiber/v2"
+	"math/big"
+	"time"
+)
+
+type SacdGetParams struct {
+	Vins        []string `json:"vins" query:"vins"`
+	Grantee     string   `json:"grantee" query:"grantee"`
+	Permissions int64    `json:"permissions" query:"permissions"`
+	Expiration  int64    `json:"expiration" query:"expiration"`
+	Source      string   `json:"source" query:"source"`
+}
+
+type VinSacdData struct {
+	Vin           string                 `json:"vin"`
+	Sacd          SacdInput              `json:"sacd"`
+	UserOperation *zerodev.UserOperation `json:"userOperation"`
+	Hash          common.Hash            `json:"hash"`
+	Signature     hexutil.Bytes          `json:"signature,omitempty"`
+}
+
+type SacdDataForVins struct {
+	VinSacdData []VinSacdData `json:"vinSacdData"`
+	Errors      []VinError    `json:"errors,omitempty"`
+}
+
+type VinSacdStatus struct {
+	Vin         string    `json:"vin"`
+	Grantee     string    `json:"grantee"`
+	Permissions string    `json:"permissions"`
+	Expiration  int64     `json:"expiration"`
+	Source      string    `json:"source,omitempty"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type SacdStatusForVinsResponse struct {
+	Sacds  []VinSacdStatus `json:"sacds"`
+	Errors []VinError      `json:"errors,omitempty"`
+}
+
+func toOnboardingSacd(input SacdInput) onboarding.OnboardingSacd {
+	return onboarding.OnboardingSacd{
+		Grantee:     input.Grantee,
+		Permissions: big.NewInt(input.Permissions),
+		Expiration:  big.NewInt(input.Expiration),
+		Source:      input.Source,
+	}
+}
+
+// isValidSacd checks the grant, zero permissions (revoke) don't need an expiration
+func isValidSacd(input SacdInput) bool {
+	if input.Grantee == (common.Address{}) || input.Permissions < 0 {
+		return false
+	}
+
+	if input.Permissions == 0 {
+		return input.Expiration >= 0
+	}
+
+	return input.Expiration > time.Now().Unix()
+}
+
+// GetSacdDataForVins
+// @Summary Get SACD payloads to sign
+// @Description Builds the user operation the owner signs to grant, extend or revoke (zero permissions) SACD on already minted vehicles.
+// @Description Without a grantee, the grant requested at mint time and still waiting for the owner's signature is used.
+// @Produce json
+// @Param vins query []string true "VINs"
+// @Param grantee query string false "grantee address"
+// @Param permissions query int false "permissions bitmap, 0 revokes"
+// @Param expiration query int false "expiration unix timestamp"
+// @Param source query string false "source URI of the grant"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} SacdDataForVins
+// @Security     BearerAuth
+// @Router /v1/vehicle/sacd [get]
+func (v *VehicleController) GetSacdDataForVins(c *fiber.Ctx) error {
+	params := new(SacdGetParams)
+	if err := c.QueryParser(params); err != nil {
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse SACD params")
+	}
+
+	walletAddress := c.Locals("wallet").(common.Address)
+
+	localLog := v.logger.With().Interface("vins", params.Vins).Str(logfields.FunctionName, "GetSacdDataForVins").Logger()
+	localLog.Debug().Msg("Getting SACD data for Vins")
+
+	var requested *SacdInput
+	if params.Grantee != "" {
+		if !common.IsHexAddress(params.Grantee) {
+			return apierrors.New(apierrors.CodeRequestInvalid, "Invalid grantee")
+		}
+
+		requested = &SacdInput{
+			Grantee:     common.HexToAddress(params.Grantee),
+			Permissions: params.Permissions,
+			Expiration:  params.Expiration,
+			Source:      params.Source,
+		}
+
+		if !isValidSacd(*requested) {
+			return apierrors.New(apierrors.CodeRequestInvalid, "Invalid SACD permissions or expiration")
+		}
+	}
+
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
+	}
+
+	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs for SACD", len(validVins))
+
+	sacdData := make([]VinSacdData, 0, len(validVins))
+
+	if len(validVins) > 0 {
+		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
+		if err != nil {
+			if errors.Is(err, service.ErrVehicleNotFound) {
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
+			}
+
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
+		}
+
+		indexedDbVins := make(map[string]*dbmodels.Vin)
+		for _, vin := range dbVins {
+			indexedDbVins[vin.Vin] = vin
+		}
+
+		requestedGrants := make(map[string]*dbmodels.VinSacd)
+		if requested == nil {
+			grants, err := v.vs.GetVinSacdsByVins(c.Context(), validVins)
+			if err != nil {
+				return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load SACD grants from Database")
+			}
+
+			for _, grant := range grants {
+				if grant.Status == onboarding.SacdStatusSignatureRequired {
+					requestedGrants[grant.Vin] = grant
+				}
+			}
+		}
+
+		indexedIdentityVehicles, err := v.fetchOwnedVehicles(c.Context(), walletAddress, dbVins)
+		if err != nil {
+			return apierrors.Wrap(err, apierrors.CodeUpstreamIdentityFailed, "Failed to fetch identity vehicles")
+		}
+
+		for _, vin := range validVins {
+			dbVin, ok := indexedDbVins[vin]
+			if !ok {
+				b.fail(vin, apierrors.CodeVinNotFound, "VIN is not onboarded")
+				continue
+			}
+
+			if dbVin.VehicleTokenID.IsZero() {
+				b.fail(vin, apierrors.CodeStateConflict, "VIN not minted")
+				continue
+			}
+
+			if _, ok := indexedIdentityVehicles[dbVin.VehicleTokenID.Int64]; !ok {
+				b.fail(vin, apierrors.CodeVinNotOwned, "VIN not owned")
+				continue
+			}
+
+			sacd := requested
+			if sacd == nil {
+				grant, ok := requestedGrants[dbVin.Vin]
+				if !ok {
+					b.fail(vin, apierrors.CodeDataInvalid, "No SACD grantee provided or requested for VIN")
+					continue
+				}
+
+				permissions, ok := new(big.Int).SetString(grant.Permissions, 10)
+				if !ok || !permissions.IsInt64() {
+					b.fail(vin, apierrors.CodeInternal, "Invalid SACD permissions stored for VIN")
+					continue
+				}
+
+				sacd = &SacdInput{
+					Grantee:     common.HexToAddress(grant.Grantee),
+					Permissions: permissions.Int64(),
+					Expiration:  grant.Expiration,
+					Source:      grant.Source.String,
+				}
+			}
+
+			tr, ok := v.transactionsClient(b, dbVin)
+			if !ok {
+				continue
+			}
+
+			op, hash, err := onboarding.GetSetSacdUserOperationAndHash(tr, walletAddress, big.NewInt(dbVin.VehicleTokenID.Int64), toOnboardingSacd(*sacd))
+			if err != nil {
+				localLog.Error().Str(logfields.VIN, vin).Err(err).Msg("Failed to get SACD operation data")
+				b.fail(vin, apierrors.CodeUpstreamChainFailed, "Failed to get SACD operation data")
+				continue
+			}
+
+			sacdData = append(sacdData, VinSacdData{
+				Vin:           dbVin.Vin,
+				Sacd:          *sacd,
+				UserOperation: op,
+				Hash:          *hash,
+			})
+		}
+
+		if b.shouldAbort(len(sacdData)) {
+			return b.rejected()
+		}
+	}
+
+	return c.JSON(SacdDataForVins{
+		VinSacdData: sacdData,
+		Errors:      b.errors,
+	})
+}
+
+// SubmitSacdDataForVins
+// @Summary Submit signed SACD payloads
+// @Accept json
+// @Produce json
+// @Param payload body SacdDataForVins true "signed SACD user operations"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} StatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/sacd [post]
+func (v *VehicleController) SubmitSacdDataForVins(c *fiber.Ctx) error {
+	params := new(SacdDataForVins)
+	if err := c.BodyParser(params); err != nil {
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse SACD data")
+	}
+
+	walletAddress := c.Locals("wallet").(common.Address)
+
+	localLog := v.logger.With().Str(logfields.FunctionName, "SubmitSacdDataForVins").Logger()
+	localLog.Debug().Msg("Submitting VINs SACD")
+
+	b := newBatch(c)
+	validVins := make([]string, 0, len(params.VinSacdData))
+	for i, paramVin := range params.VinSacdData {
+		strippedVin, err := v.normalizeVin(paramVin.Vin)
+		if err != nil {
+			b.fail(paramVin.Vin, apierrors.CodeVinInvalid, err.Error())
+			continue
+		}
+
+		params.VinSacdData[i].Vin = strippedVin
+		if paramVin.UserOperation == nil || len(paramVin.Signature) == 0 || !isValidSacd(paramVin.Sacd) {
+			b.fail(strippedVin, apierrors.CodeDataInvalid, "Invalid SACD data")
+			continue
+		}
+
+		validVins = append(validVins, strippedVin)
+	}
+
+	b.rejectDuplicates(validVins)
+	validVins = b.valid(validVins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
+	}
+
+	localLog.Debug().Interface("validVins", validVins).Msgf("Got %d valid VINs submitted for SACD", len(validVins))
+
+	statuses := make([]VinStatus, 0, len(params.VinSacdData))
+
+	if len(validVins) > 0 {
+		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
+		if err != nil {
+			if errors.Is(err, service.ErrVehicleNotFound) {
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
+			}
+
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
+		}
+
+		indexedDbVins := make(map[string]*dbmodels.Vin)
+		for _, vin := range dbVins {
+			indexedDbVins[vin.Vin] = vin
+		}
+
+		grants, err := v.vs.GetVinSacdsByVins(c.Context(), validVins)
+		if err != nil {
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load SACD grants from Database")
+		}
+
+		indexedGrants := make(map[string]*dbmodels.VinSacd)
+		for _, grant := range grants {
+			indexedGrants[grant.Vin+grant.Grantee] = grant
+		}
+
+		indexedIdentityVehicles, err := v.fetchOwnedVehicles(c.Context(), walletAddress, dbVins)
+		if err != nil {
+			return apierrors.Wrap(err, apierrors.CodeUpstreamIdentityFailed, "Failed to fetch identity vehicles")
+		}
+
+		// check all VINs before submitting anything, so atomic requests fail without side effects
+		for _, sacdVehicle := range params.VinSacdData {
+			if b.hasFailed(sacdVehicle.Vin) {
+				continue
+			}
+
+			dbVin, ok := indexedDbVins[sacdVehicle.Vin]
+			if !ok || dbVin.VehicleTokenID.IsZero() {
+				b.fail(sacdVehicle.Vin, apierrors.CodeStateConflict, "VIN not minted")
+				continue
+			}
+
+			if _, ok := indexedIdentityVehicles[dbVin.VehicleTokenID.Int64]; !ok {
+				b.fail(sacdVehicle.Vin, apierrors.CodeVinNotOwned, "VIN not owned")
+				continue
+			}
+
+			tr, err := v.chains.ForRecord(dbVin)
+			if err != nil {
+				b.fail(sacdVehicle.Vin, apierrors.CodeChainUnsupported, err.Error())
+				continue
+			}
+
+			// make sure the signed operation sets the grant we are going to track
+			expectedCallData, err := onboarding.GetSetSacdCallData(tr, big.NewInt(dbVin.VehicleTokenID.Int64), toOnboardingSacd(sacdVehicle.Sacd))
+			if err != nil || !bytes.Equal(*expectedCallData, sacdVehicle.UserOperation.CallData) {
+				b.fail(sacdVehicle.Vin, apierrors.CodeDataInvalid, "User operation does not match SACD")
+			}
+		}
+
+		if b.shouldAbort(len(b.valid(validVins))) {
+			return b.rejected()
+		}
+
+		for _, sacdVehicle := range params.VinSacdData {
+			if b.hasFailed(sacdVehicle.Vin) {
+				continue
+			}
+
+			sacd := toOnboardingSacd(sacdVehicle.Sacd)
+
+			if grant, ok := indexedGrants[sacdVehicle.Vin+sacd.Grantee.Hex()]; ok && onboarding.IsSacdPending(grant.Status) {
+				localLog.Debug().Str(logfields.VIN, sacdVehicle.Vin).Msg("Skipping SACD job submission")
+				statuses = append(statuses, VinStatus{
+					Vin:     sacdVehicle.Vin,
+					Status:  "Pending",
+					Details: grant.Status,
+				})
+				continue
+			}
+
+			op := sacdVehicle.UserOperation
+			op.Signature = sacdVehicle.Signature
+
+			status := onboarding.SacdStatusPending
+			_, err = v.riverClient.Insert(c.Context(), onboarding.SacdArgs{
+				VIN:           sacdVehicle.Vin,
+				Sacd:          sacd,
+				UserOperation: op,
+			}, nil)
+
+			if err != nil {
+				localLog.Error().Str(logfields.VIN, sacdVehicle.Vin).Err(err).Msg("Failed to submit SACD job")
+				status = onboarding.SacdStatusFailed
+				statuses = append(statuses, VinStatus{
+					Vin:     sacdVehicle.Vin,
+					Status:  "Failure",
+					Details: status,
+				})
+			} else {
+				localLog.Debug().Str(logfields.VIN, sacdVehicle.Vin).Msg("SACD job submitted")
+				statuses = append(statuses, VinStatus{
+					Vin:     sacdVehicle.Vin,
+					Status:  "Pending",
+					Details: status,
+				})
+			}
+
+			if err = v.vs.UpsertVinSacd(c.Context(), onboarding.NewVinSacdRecord(sacdVehicle.Vin, sacd, status, err)); err != nil {
+				localLog.Error().Str(logfields.VIN, sacdVehicle.Vin).Err(err).Msg("Failed to save SACD status")
+				b.fail(sacdVehicle.Vin, apierrors.CodeDatabaseFailed, "Failed to save SACD status")
+			}
+		}
+	}
+
+	return c.JSON(StatusForVinsResponse{
+		Statuses: statuses,
+		Errors:   b.errors,
+	})
+}
+
+// GetSacdStatusForVins
+// @Summary Get SACD grant state for each of the submitted VINs
+// @Produce json
+// @Param vins query []string true "VINs"
+// @Param atomic query bool false "fail the whole request if any VIN can't be processed"
+// @Success 200 {object} SacdStatusForVinsResponse
+// @Security     BearerAuth
+// @Router /v1/vehicle/sacd/status [get]
+func (v *VehicleController) GetSacdStatusForVins(c *fiber.Ctx) error {
+	params := new(VinsGetParams)
+	if err := c.QueryParser(params); err != nil {
+		return apierrors.New(apierrors.CodeRequestInvalid, "Failed to parse VINs")
+	}
+
+	walletAddress := c.Locals("wallet").(common.Address)
+
+	b := newBatch(c)
+	validVins := v.normalizeVins(b, params.Vins)
+	if b.shouldAbort(len(validVins)) {
+		return b.rejected()
+	}
+
+	response := SacdStatusForVinsResponse{
+		Sacds: make([]VinSacdStatus, 0, len(validVins)),
+	}
+
+	if len(validVins) > 0 {
+		dbVins, err := v.vs.GetVehiclesByVins(c.Context(), validVins)
+		if err != nil {
+			if errors.Is(err, service.ErrVehicleNotFound) {
+				return apierrors.New(apierrors.CodeVinNotFound, "Could not find Vehicles")
+			}
+
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load vehicles from Database")
+		}
+
+		owned, err := v.sacdOwnedVins(c.Context(), walletAddress, dbVins)
+		if err != nil {
+			return apierrors.Wrap(err, apierrors.CodeUpstreamIdentityFailed, "Failed to fetch identity vehicles")
+		}
+
+		indexedDbVins := make(map[string]*dbmodels.Vin)
+		for _, vin := range dbVins {
+			indexedDbVins[vin.Vin] = vin
+		}
+
+		ownedVins := make([]string, 0, len(validVins))
+		for _, vin := range validVins {
+			if _, ok := indexedDbVins[vin]; !ok {
+				b.fail(vin, apierrors.CodeVinNotFound, "VIN is not onboarded")
+				continue
+			}
+
+			if !owned[vin] {
+				b.fail(vin, apierrors.CodeVinNotOwned, "VIN not owned")
+				continue
+			}
+
+			ownedVins = append(ownedVins, vin)
+		}
+
+		if b.shouldAbort(len(ownedVins)) {
+			return b.rejected()
+		}
+
+		grants, err := v.vs.GetVinSacdsByVins(c.Context(), ownedVins)
+		if err != nil {
+			return apierrors.Wrap(err, apierrors.CodeDatabaseFailed, "Failed to load SACD grants from Database")
+		}
+
+		for _, grant := range grants {
+			response.Sacds = append(response.Sacds, VinSacdStatus{
+				Vin:         grant.Vin,
+				Grantee:     grant.Grantee,
+				Permissions: grant.Permissions,
+				Expiration:  grant.Expiration,
+				Source:      grant.Source.String,
+				Status:      grant.Status,
+				Error:       grant.Error.String,
+				UpdatedAt:   grant.UpdatedAt,
+			})
+		}
+	}
+
+	response.Errors = b.errors
+
+	return c.JSON(response)
+}
+
+// sacdOwnedVins returns which VINs the wallet owns: minted ones are checked on Identity API, the others against the owner
+// stored when they were submitted for verification
+func (v *VehicleController) sacdOwnedVins(ctx context.Context, walletAddress common.Address, dbVins dbmodels.VinSlice) (map[string]bool, error) {
+	indexedIdentityVehicles, err := v.fetchOwnedVehicles(ctx, walletAddress, dbVins)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make(map[string]bool, len(dbVins))
+	for _, dbVin := range dbVins {
+		if dbVin.VehicleTokenID.IsZero() {
+			owned[dbVin.Vin] = dbVin.OwnerAddress.Valid && common.HexToAddress(dbVin.OwnerAddress.String) == walletAddress
+			continue
+		}
+
+		_, owned[dbVin.Vin] = indexedIdentityVehicles[dbVin.VehicleTokenID.Int64]
+	}
+
+	return owned, nil
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"encoding/json"
+	"github.com/DIMO-Network/go-transactions"
+	"github.com/DIMO-Network/go-zerodev"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/mocks"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/oracle-example/internal/onboarding"
+	"github.com/DIMO-Network/oracle-example/internal/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"gotest.tools/v3/assert"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	sacdOwner    = common.HexToAddress("0x1")
+	sacdStranger = common.HexToAddress("0x2")
+	sacdGrantee  = common.HexToAddress("0x3")
+)
+
+// sacdApp serves the SACD endpoints for the wallet, the owner's vehicle 456 is minted for ABCDEFG1234567811
+func (s *VehicleControllerTestSuite) sacdApp(wallet common.Address) *fiber.App {
+	mockDeps := createMockDependencies(s.T())
+	identity := mocks.NewIdentityAPIMock([]models.Vehicle{{TokenID: 456, Owner: sacdOwner.Hex()}}, nil)
+
+	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, identity, s.vs, s.river, nil, onboarding.NewChains(80002, map[int64]*transactions.Client{80002: {}}))
+	app := fiber.New(fiber.Config{
+		EnableSplittingOnParsers: true,
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return apierrors.Send(c, apierrors.NewProblem(c, err, false))
+		},
+	})
+	app.Get("/vehicle/sacd/status", test.AuthInjectorTestHandler("testUserID", nil), test.WalletInjectorTestHandler(wallet), c.GetSacdStatusForVins)
+	app.Post("/vehicle/sacd", test.AuthInjectorTestHandler("testUserID", nil), test.WalletInjectorTestHandler(wallet), c.SubmitSacdDataForVins)
+
+	return app
+}
+
+func (s *VehicleControllerTestSuite) insertSacdVins() {
+	t := s.T()
+
+	minted := dbmodels.Vin{
+		Vin:              "ABCDEFG1234567811",
+		OnboardingStatus: onboarding.OnboardingStatusMintSuccess,
+		VehicleTokenID:   null.Int64From(456),
+		SyntheticTokenID: null.Int64From(789),
+	}
+	require.NoError(t, minted.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	unminted := dbmodels.Vin{
+		Vin:              "ABCDEFG1234567812",
+		OnboardingStatus: onboarding.OnboardingStatusMintPending,
+		OwnerAddress:     null.StringFrom(sacdOwner.Hex()),
+	}
+	require.NoError(t, unminted.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	sacd := onboarding.OnboardingSacd{
+		Grantee:     sacdGrantee,
+		Permissions: big.NewInt(3),
+		Expiration:  big.NewInt(time.Now().Add(time.Hour).Unix()),
+	}
+	for _, vin := range []string{minted.Vin, unminted.Vin} {
+		require.NoError(t, s.vs.UpsertVinSacd(s.ctx, onboarding.NewVinSacdRecord(vin, sacd, onboarding.SacdStatusSignatureRequired, nil)))
+	}
+}
+
+func (s *VehicleControllerTestSuite) TestGetSacdStatusForVins_Ownership() {
+	t := s.T()
+	s.insertSacdVins()
+
+	s.Run("Owner gets the grants of minted and unminted VINs", func() {
+		response, _ := s.sacdApp(sacdOwner).Test(test.BuildRequest("GET", "/vehicle/sacd/status?vins=ABCDEFG1234567811,ABCDEFG1234567812", ""))
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		body, _ := io.ReadAll(response.Body)
+
+		var result SacdStatusForVinsResponse
+		assert.NilError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 2, len(result.Sacds))
+		assert.Equal(t, 0, len(result.Errors))
+	})
+
+	s.Run("Other wallets don't see the grants", func() {
+		response, _ := s.sacdApp(sacdStranger).Test(test.BuildRequest("GET", "/vehicle/sacd/status?vins=ABCDEFG1234567811,ABCDEFG1234567812", ""))
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+		body, _ := io.ReadAll(response.Body)
+
+		var problem apierrors.Problem
+		assert.NilError(t, json.Unmarshal(body, &problem))
+		assert.Equal(t, apierrors.CodeBatchRejected, problem.Code)
+		assert.Equal(t, 2, strings.Count(string(body), string(apierrors.CodeVinNotOwned)))
+	})
+
+	s.Run("Unknown VINs are reported", func() {
+		response, _ := s.sacdApp(sacdOwner).Test(test.BuildRequest("GET", "/vehicle/sacd/status?vins=ABCDEFG1234567811,ABCDEFG1234567813", ""))
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		body, _ := io.ReadAll(response.Body)
+
+		var result SacdStatusForVinsResponse
+		assert.NilError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result.Sacds))
+		assert.Equal(t, "ABCDEFG1234567811", result.Sacds[0].Vin)
+		assert.Equal(t, 1, len(result.Errors))
+		assert.Equal(t, "ABCDEFG1234567813", result.Errors[0].Vin)
+		assert.Equal(t, apierrors.CodeVinNotFound, result.Errors[0].Code)
+	})
+}
+
+func (s *VehicleControllerTestSuite) TestSubmitSacdDataForVins_Ownership() {
+	t := s.T()
+	s.insertSacdVins()
+
+	payload := SacdDataForVins{
+		VinSacdData: []VinSacdData{
+			{
+				Vin: "ABCDEFG1234567811",
+				Sacd: SacdInput{
+					Grantee:     sacdGrantee,
+					Permissions: 3,
+					Expiration:  time.Now().Add(time.Hour).Unix(),
+				},
+				UserOperation: &zerodev.UserOperation{},
+				Signature:     []byte{1},
+			},
+		},
+	}
+	payloadJSON, err := json.Marshal(payload)
+	assert.NilError(t, err)
+
+	s.Run("Other wallets can't submit SACD", func() {
+		response, _ := s.sacdApp(sacdStranger).Test(test.BuildRequest("POST", "/vehicle/sacd", string(payloadJSON)))
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+		body, _ := io.ReadAll(response.Body)
+
+		var problem apierrors.Problem
+		assert.NilError(t, json.Unmarshal(body, &problem))
+		assert.Equal(t, apierrors.CodeBatchRejected, problem.Code)
+		assert.Assert(t, strings.Contains(string(body), string(apierrors.CodeVinNotOwned)), string(body))
+
+		grants, err := s.vs.GetVinSacdsByVins(s.ctx, []string{"ABCDEFG1234567811"})
+		assert.NilError(t, err)
+		assert.Equal(t, 1, len(grants))
+		assert.Equal(t, onboarding.SacdStatusSignatureRequired, grants[0].Status)
+	})
+}
+
+func (s *VehicleControllerTestSuite) TestMintSacdStatus() {
+	t := s.T()
+	sacd := &onboarding.OnboardingSacd{Grantee: sacdGrantee, Permissions: big.NewInt(3), Expiration: big.NewInt(1893456000)}
+
+	assert.Equal(t, "", mintSacdStatus(&dbmodels.Vin{}, nil))
+	assert.Equal(t, onboarding.SacdStatusPending, mintSacdStatus(&dbmodels.Vin{}, sacd))
+	// only the Synthetic Device is minted, the owner has to sign the grant
+	assert.Equal(t, onboarding.SacdStatusSignatureRequired, mintSacdStatus(&dbmodels.Vin{VehicleTokenID: null.Int64From(456)}, sacd))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/DIMO-Network/go-transactions"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
 	"github.com/DIMO-Network/oracle-example/internal/config"
 	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
 	"github.com/DIMO-Network/oracle-example/internal/mocks"
@@ -12,6 +14,7 @@ import (
 	"github.com/DIMO-Network/oracle-example/internal/service"
 	"github.com/DIMO-Network/oracle-example/internal/test"
 	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -21,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"gotest.tools/v3/assert"
 	"io"
@@ -48,7 +52,7 @@ const migrationsDirRelPath = "../db/migrations"
 func (s *VehicleControllerTestSuite) SetupSuite() {
 	s.ctx = context.Background()
 	s.pdb, s.container, s.settings = test.StartContainerDatabase(context.Background(), s.T(), migrationsDirRelPath)
-	s.vs = service.NewVehicleService(&s.pdb, s.logger)
+	s.vs = service.NewVehicleService(&s.pdb, s.logger, &s.settings)
 
 	workers := river.NewWorkers()
 
@@ -62,9 +66,7 @@ func (s *VehicleControllerTestSuite) SetupSuite() {
 	dbPool, _ := pgxpool.New(s.ctx, dbURL)
 
 	riverClient, _ := river.NewClient(riverpgxv5.New(dbPool), &river.Config{
-		Queues: map[string]river.QueueConfig{
-			river.QueueDefault: {MaxWorkers: 10},
-		},
+		Queues:  onboarding.RiverQueues(&s.settings),
 		Workers: workers,
 	})
 
@@ -120,9 +122,12 @@ func (s *VehicleControllerTestSuite) TestGetVerificationStatusForVins() {
 	t := s.T()
 	mockDeps := createMockDependencies(t)
 
-	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, mockDeps.identity, s.vs, s.river, nil, nil)
+	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, mockDeps.identity, s.vs, s.river, nil, onboarding.NewChains(80002, map[int64]*transactions.Client{80002: {}}))
 	app := fiber.New(fiber.Config{
 		EnableSplittingOnParsers: true,
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return apierrors.Send(c, apierrors.NewProblem(c, err, false))
+		},
 	})
 	app.Get("/vehicle/verify", test.AuthInjectorTestHandler("testUserID", nil), c.GetVerificationStatusForVins)
 
@@ -235,6 +240,44 @@ func (s *VehicleControllerTestSuite) TestGetVerificationStatusForVins() {
 		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
 	})
 
+	s.Run("Reports invalid VINs with the statuses of valid ones", func() {
+		req, _ := http.NewRequest(
+			"GET",
+			"/vehicle/verify?vins=ABCDEFG1234567811,A345",
+			strings.NewReader(""),
+		)
+		response, _ := app.Test(req)
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		body, _ := io.ReadAll(response.Body)
+
+		var result StatusForVinsResponse
+		assert.NilError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result.Statuses))
+		assert.Equal(t, "ABCDEFG1234567811", result.Statuses[0].Vin)
+		assert.Equal(t, 1, len(result.Errors))
+		assert.Equal(t, "A345", result.Errors[0].Vin)
+		assert.Equal(t, apierrors.CodeVinInvalid, result.Errors[0].Code)
+	})
+
+	s.Run("Fails atomic request with invalid VINs", func() {
+		req, _ := http.NewRequest(
+			"GET",
+			"/vehicle/verify?atomic=true&vins=ABCDEFG1234567811,A345",
+			strings.NewReader(""),
+		)
+		response, _ := app.Test(req)
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+		assert.Equal(t, apierrors.MIMEApplicationProblemJSON, response.Header.Get(fiber.HeaderContentType))
+
+		body, _ := io.ReadAll(response.Body)
+
+		var problem apierrors.Problem
+		assert.NilError(t, json.Unmarshal(body, &problem))
+		assert.Equal(t, apierrors.CodeBatchRejected, problem.Code)
+		assert.Equal(t, "/vehicle/verify", problem.Instance)
+	})
+
 	dbVin := dbmodels.Vin{
 		Vin:              "ABCDEFG1234567812",
 		OnboardingStatus: onboarding.OnboardingStatusMintSuccess,
@@ -314,11 +357,11 @@ func (s *VehicleControllerTestSuite) TestSubmitVerificationForVins_InvalidVins()
 	t := s.T()
 	mockDeps := createMockDependencies(t)
 
-	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, mockDeps.identity, s.vs, s.river, nil, nil)
+	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, mockDeps.identity, s.vs, s.river, nil, onboarding.NewChains(80002, map[int64]*transactions.Client{80002: {}}))
 	app := fiber.New(fiber.Config{
 		EnableSplittingOnParsers: true,
 	})
-	app.Post("/vehicle/verify", test.AuthInjectorTestHandler("testUserID", nil), c.SubmitVerificationForVins)
+	app.Post("/vehicle/verify", test.AuthInjectorTestHandler("testUserID", nil), test.WalletInjectorTestHandler(common.HexToAddress("0x1")), c.SubmitVerificationForVins)
 
 	s.Run("Submit empty VIN list", func() {
 		payload := SubmitVinVerificationParams{
@@ -395,11 +438,11 @@ func (s *VehicleControllerTestSuite) TestSubmitVerificationForVins_ValidVins() {
 	t := s.T()
 	mockDeps := createMockDependencies(t)
 
-	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, mockDeps.identity, s.vs, s.river, nil, nil)
+	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, mockDeps.identity, s.vs, s.river, nil, onboarding.NewChains(80002, map[int64]*transactions.Client{80002: {}}))
 	app := fiber.New(fiber.Config{
 		EnableSplittingOnParsers: true,
 	})
-	app.Post("/vehicle/verify", test.AuthInjectorTestHandler("testUserID", nil), c.SubmitVerificationForVins)
+	app.Post("/vehicle/verify", test.AuthInjectorTestHandler("testUserID", nil), test.WalletInjectorTestHandler(common.HexToAddress("0x1")), c.SubmitVerificationForVins)
 
 	s.Run("Get verification status for list of valid, unknown VINs", func() {
 		payload := SubmitVinVerificationParams{
@@ -449,3 +492,160 @@ func (s *VehicleControllerTestSuite) TestSubmitVerificationForVins_ValidVins() {
 		assert.Equal(t, string(body), string(expectedJSON))
 	})
 }
+
+func (s *VehicleControllerTestSuite) TestRegisterVehicle_NormalizedVin() {
+	t := s.T()
+	mockDeps := createMockDependencies(t)
+	wallet := common.HexToAddress("0x1")
+	identity := mocks.NewIdentityAPIMock([]models.Vehicle{{TokenID: 456, Owner: wallet.Hex()}}, nil)
+
+	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, identity, s.vs, s.river, nil, onboarding.NewChains(80002, map[int64]*transactions.Client{80002: {}}))
+	app := fiber.New(fiber.Config{
+		EnableSplittingOnParsers: true,
+	})
+	app.Post("/vehicle/register", test.AuthInjectorTestHandler("testUserID", nil), test.WalletInjectorTestHandler(wallet), c.RegisterVehicle)
+
+	response, _ := app.Test(test.BuildRequest("POST", "/vehicle/register", `{"vin": " 1hgcm82633a004352 ", "token_id": 456}`))
+	assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+	result := VehicleResponse{}
+	body, _ := io.ReadAll(response.Body)
+	assert.NilError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "1HGCM82633A004352", result.Vehicle.VIN)
+
+	stored, err := dbmodels.FindVin(s.ctx, s.pdb.DBS().Reader, "1HGCM82633A004352")
+	assert.NilError(t, err)
+	assert.Equal(t, int64(456), stored.VehicleTokenID.Int64)
+}
+
+func (s *VehicleControllerTestSuite) TestSubmitVerificationForVins_OtherWalletsVin() {
+	t := s.T()
+	mockDeps := createMockDependencies(t)
+
+	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, mockDeps.identity, s.vs, s.river, nil, onboarding.NewChains(80002, map[int64]*transactions.Client{80002: {}}))
+	app := fiber.New(fiber.Config{
+		EnableSplittingOnParsers: true,
+	})
+	app.Post("/vehicle/verify", test.AuthInjectorTestHandler("testUserID", nil), test.WalletInjectorTestHandler(common.HexToAddress("0x1")), c.SubmitVerificationForVins)
+
+	// submitted by another wallet, not minted yet
+	record := dbmodels.Vin{
+		Vin:              "ABCDEFG1234567811",
+		OnboardingStatus: onboarding.OnboardingStatusSubmitFailure,
+		OwnerAddress:     null.StringFrom(common.HexToAddress("0x2").Hex()),
+	}
+	require.NoError(t, record.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	payloadJSON, err := json.Marshal(SubmitVinVerificationParams{
+		Vins: []VinWithCountryCode{
+			{Vin: record.Vin, CountryCode: "USA"},
+			{Vin: "ABCDEFG1234567812", CountryCode: "USA"},
+		},
+	})
+	assert.NilError(t, err)
+
+	response, _ := app.Test(test.BuildRequest("POST", "/vehicle/verify", string(payloadJSON)))
+	assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+	result := StatusForVinsResponse{}
+	body, _ := io.ReadAll(response.Body)
+	assert.NilError(t, json.Unmarshal(body, &result))
+	assert.DeepEqual(t, []VinError{{Vin: record.Vin, Code: apierrors.CodeVinNotOwned, Message: "VIN not owned"}}, result.Errors)
+	assert.Equal(t, 1, len(result.Statuses))
+	assert.Equal(t, "ABCDEFG1234567812", result.Statuses[0].Vin)
+
+	stored, err := dbmodels.FindVin(s.ctx, s.pdb.DBS().Reader, record.Vin)
+	assert.NilError(t, err)
+	assert.Equal(t, common.HexToAddress("0x2").Hex(), stored.OwnerAddress.String)
+	assert.Equal(t, onboarding.OnboardingStatusSubmitFailure, stored.OnboardingStatus)
+}
+
+func (s *VehicleControllerTestSuite) mintApp(wallet common.Address) *fiber.App {
+	mockDeps := createMockDependencies(s.T())
+
+	c := NewVehiclesController(&config.Settings{Port: "3000"}, &mockDeps.logger, mockDeps.identity, s.vs, s.river, nil, onboarding.NewChains(80002, map[int64]*transactions.Client{80002: {}}))
+	app := fiber.New(fiber.Config{
+		EnableSplittingOnParsers: true,
+	})
+	app.Post("/vehicle/mint", test.AuthInjectorTestHandler("testUserID", nil), test.WalletInjectorTestHandler(wallet), c.SubmitMintDataForVins)
+
+	return app
+}
+
+func (s *VehicleControllerTestSuite) mintJobs() int {
+	var count int
+	row := s.pdb.DBS().Reader.QueryRowContext(s.ctx, fmt.Sprintf("SELECT count(*) FROM %s.river_job WHERE kind = $1", s.settings.DB.Name), onboarding.OnboardingArgs{}.Kind())
+	require.NoError(s.T(), row.Scan(&count))
+
+	return count
+}
+
+func (s *VehicleControllerTestSuite) TestSubmitMintDataForVins_PausedVins() {
+	t := s.T()
+
+	for _, status := range []int{
+		onboarding.OnboardingStatusPausePending,
+		onboarding.OnboardingStatusPauseFailure,
+		onboarding.OnboardingStatusPauseSuccess,
+		onboarding.OnboardingStatusResumeFailure,
+	} {
+		s.Run(onboarding.GetDetailedStatus(status), func() {
+			defer test.TruncateTables(s.pdb.DBS().Writer.DB, t)
+
+			record := dbmodels.Vin{
+				Vin:              "ABCDEFG1234567811",
+				OnboardingStatus: status,
+				VehicleTokenID:   null.Int64From(456),
+				SyntheticTokenID: null.Int64From(789),
+				TelemetryPaused:  onboarding.IsPaused(status),
+				ConnectionStatus: null.StringFrom("failed"),
+			}
+			require.NoError(t, record.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+			payloadJSON, err := json.Marshal(MintDataForVins{VinMintingData: []VinTransactionData{{Vin: "ABCDEFG1234567811"}}})
+			assert.NilError(t, err)
+
+			response, _ := s.mintApp(common.HexToAddress("0x1")).Test(test.BuildRequest("POST", "/vehicle/mint", string(payloadJSON)))
+			assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+			body, _ := io.ReadAll(response.Body)
+			assert.Assert(t, !strings.Contains(string(body), "MintSubmitPending"), string(body))
+			assert.Equal(t, 0, s.mintJobs())
+
+			stored, err := dbmodels.FindVin(s.ctx, s.pdb.DBS().Reader, record.Vin)
+			assert.NilError(t, err)
+			assert.Equal(t, status, stored.OnboardingStatus)
+			assert.Equal(t, onboarding.IsPaused(status), stored.TelemetryPaused)
+		})
+	}
+}
+
+func (s *VehicleControllerTestSuite) TestSubmitMintDataForVins_TransferredVin() {
+	t := s.T()
+
+	// the disconnect transfer policy left the VIN paused for the new owner
+	record := dbmodels.Vin{
+		Vin:                 "ABCDEFG1234567811",
+		OnboardingStatus:    onboarding.OnboardingStatusPauseSuccess,
+		VehicleTokenID:      null.Int64From(456),
+		SyntheticTokenID:    null.Int64From(789),
+		OwnerAddress:        null.StringFrom(common.HexToAddress("0x2").Hex()),
+		TelemetryPaused:     true,
+		DisconnectionStatus: null.StringFrom("succeeded"),
+	}
+	require.NoError(t, record.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	payloadJSON, err := json.Marshal(MintDataForVins{VinMintingData: []VinTransactionData{{Vin: record.Vin}}})
+	assert.NilError(t, err)
+
+	for _, wallet := range []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")} {
+		response, _ := s.mintApp(wallet).Test(test.BuildRequest("POST", "/vehicle/mint", string(payloadJSON)))
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+	}
+
+	assert.Equal(t, 0, s.mintJobs())
+	stored, err := dbmodels.FindVin(s.ctx, s.pdb.DBS().Reader, record.Vin)
+	assert.NilError(t, err)
+	assert.Equal(t, onboarding.OnboardingStatusPauseSuccess, stored.OnboardingStatus)
+	assert.Assert(t, stored.TelemetryPaused)
+}
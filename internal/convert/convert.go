@@ -12,12 +12,12 @@ import (
 	"time"
 )
 
-// SetProducerAndSubject sets the producer and consumer fields in the CloudEvent
-func SetProducerAndSubject(veh dbmodels.Vin, ce *cloudevent.CloudEvent[json.RawMessage], settings config.Settings) error {
+// SetProducerAndSubject sets the producer and consumer fields in the CloudEvent, the DIDs are on the chain of the VIN
+func SetProducerAndSubject(veh dbmodels.Vin, ce *cloudevent.CloudEvent[json.RawMessage], profile config.ChainProfile) error {
 	// Construct the producer DID
 	producer := cloudevent.NFTDID{
-		ChainID:         uint64(settings.ChainID),
-		ContractAddress: settings.SyntheticNftAddress,
+		ChainID:         uint64(profile.ChainID),
+		ContractAddress: profile.SyntheticNftAddress,
 		TokenID:         uint32(veh.SyntheticTokenID.Int64),
 	}.String()
 
@@ -26,8 +26,8 @@ func SetProducerAndSubject(veh dbmodels.Vin, ce *cloudevent.CloudEvent[json.RawM
 	vehTokenId := uint32(veh.VehicleTokenID.Int64)
 	if vehTokenId != 0 {
 		subject = cloudevent.NFTDID{
-			ChainID:         uint64(settings.ChainID),
-			ContractAddress: settings.VehicleNftAddress,
+			ChainID:         uint64(profile.ChainID),
+			ContractAddress: profile.VehicleNftAddress,
 			TokenID:         vehTokenId,
 		}.String()
 	}
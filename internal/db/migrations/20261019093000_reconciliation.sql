@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+alter table oracle_example.vins
+    add owner_address varchar(42);
+
+CREATE TABLE oracle_example.vin_drifts
+(
+    vin         VARCHAR(17) NOT NULL,
+    kind        VARCHAR(30) NOT NULL,
+    db_value    VARCHAR(255),
+    chain_value VARCHAR(255),
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMPTZ,
+    CONSTRAINT vin_drifts_pk PRIMARY KEY (vin, kind)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE oracle_example.vin_drifts;
+
+alter table oracle_example.vins
+    drop column owner_address;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+alter table oracle_example.vins
+    add telemetry_paused boolean default false not null;
+
+CREATE TABLE oracle_example.vin_history
+(
+    id             BIGSERIAL
+        CONSTRAINT vin_history_pk
+            PRIMARY KEY,
+    vin            VARCHAR(17) NOT NULL,
+    event          VARCHAR(30) NOT NULL,
+    previous_owner VARCHAR(42),
+    new_owner      VARCHAR(42),
+    details        VARCHAR(512),
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX vin_history_vin_idx ON oracle_example.vin_history (vin);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE oracle_example.vin_history;
+
+alter table oracle_example.vins
+    drop column telemetry_paused;
+
+-- +goose StatementEnd
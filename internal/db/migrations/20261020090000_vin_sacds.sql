@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE oracle_example.vin_sacds
+(
+    vin         VARCHAR(17) NOT NULL,
+    grantee     VARCHAR(42) NOT NULL,
+    permissions VARCHAR(78) NOT NULL,
+    expiration  BIGINT      NOT NULL,
+    source      VARCHAR(255),
+    status      VARCHAR(30) NOT NULL,
+    error       VARCHAR(512),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT vin_sacds_pk
+        PRIMARY KEY (vin, grantee)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE oracle_example.vin_sacds;
+
+-- +goose StatementEnd
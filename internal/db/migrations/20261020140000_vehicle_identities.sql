@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE oracle_example.vehicle_identities
+(
+    vehicle_token_id   BIGINT
+        CONSTRAINT vehicle_identities_pk
+            PRIMARY KEY,
+    owner              VARCHAR(42),
+    definition_id      TEXT,
+    make               VARCHAR(100),
+    model              VARCHAR(100),
+    year               INTEGER,
+    synthetic_token_id BIGINT,
+    minted_at          VARCHAR(64),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX vins_owner_address_vin_idx ON oracle_example.vins (owner_address, vin);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP INDEX oracle_example.vins_owner_address_vin_idx;
+
+DROP TABLE oracle_example.vehicle_identities;
+
+-- +goose StatementEnd
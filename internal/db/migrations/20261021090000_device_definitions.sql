@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE oracle_example.device_definitions
+(
+    device_definition_id  TEXT
+        CONSTRAINT device_definitions_pk
+            PRIMARY KEY,
+    manufacturer_name     VARCHAR(100) NOT NULL,
+    manufacturer_token_id BIGINT       NOT NULL,
+    model                 VARCHAR(100) NOT NULL,
+    year                  INTEGER      NOT NULL,
+    updated_at            TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE oracle_example.device_definitions;
+
+-- +goose StatementEnd
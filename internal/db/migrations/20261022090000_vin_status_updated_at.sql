@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+alter table oracle_example.vins
+    add onboarding_status_updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- the column is owned by the trigger, so every code path updating the status keeps it right
+CREATE FUNCTION oracle_example.vins_onboarding_status_updated_at() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.onboarding_status IS DISTINCT FROM OLD.onboarding_status THEN
+        NEW.onboarding_status_updated_at = now();
+    ELSE
+        NEW.onboarding_status_updated_at = OLD.onboarding_status_updated_at;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER vins_onboarding_status_updated_at
+    BEFORE INSERT OR UPDATE
+    ON oracle_example.vins
+    FOR EACH ROW
+EXECUTE FUNCTION oracle_example.vins_onboarding_status_updated_at();
+
+CREATE INDEX vins_onboarding_status_idx ON oracle_example.vins (onboarding_status, onboarding_status_updated_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP INDEX oracle_example.vins_onboarding_status_idx;
+
+DROP TRIGGER vins_onboarding_status_updated_at ON oracle_example.vins;
+
+DROP FUNCTION oracle_example.vins_onboarding_status_updated_at();
+
+alter table oracle_example.vins
+    drop column onboarding_status_updated_at;
+
+-- +goose StatementEnd
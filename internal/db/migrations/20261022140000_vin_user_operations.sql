@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE oracle_example.vin_user_operations
+(
+    id             BIGSERIAL
+        CONSTRAINT vin_user_operations_pk
+            PRIMARY KEY,
+    vin            VARCHAR(17)  NOT NULL,
+    step           VARCHAR(30)  NOT NULL,
+    hash           VARCHAR(66)  NOT NULL
+        CONSTRAINT vin_user_operations_hash_key
+            UNIQUE,
+    user_operation TEXT         NOT NULL,
+    status         VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+-- a step never has more than one user operation that may still be mined
+CREATE UNIQUE INDEX vin_user_operations_pending_idx ON oracle_example.vin_user_operations (vin, step) WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE oracle_example.vin_user_operations;
+
+-- +goose StatementEnd
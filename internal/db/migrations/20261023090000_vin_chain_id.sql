@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- chain the vehicle and synthetic device are minted on, NULL for VINs registered before chain profiles, on the default chain
+alter table oracle_example.vins
+    add chain_id BIGINT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+alter table oracle_example.vins
+    drop column chain_id;
+
+-- +goose StatementEnd
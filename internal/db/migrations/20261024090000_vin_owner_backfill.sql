@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- owners of VINs onboarded before owners were tracked, where the Identity API cache knows them. The rest are claimed by
+-- their owner's wallet when it lists its vehicles.
+UPDATE oracle_example.vins
+SET owner_address = vi.owner
+FROM oracle_example.vehicle_identities vi
+WHERE vins.owner_address IS NULL
+  AND vi.vehicle_token_id = vins.vehicle_token_id
+  AND vi.owner IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+-- owners found on chain are kept
+
+-- +goose StatementEnd
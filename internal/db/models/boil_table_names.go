@@ -4,9 +4,21 @@
 package models
 
 var TableNames = struct {
-	Access string
-	Vins   string
+	Access            string
+	DeviceDefinitions string
+	VehicleIdentities string
+	VinDrifts         string
+	VinHistory        string
+	VinSacds          string
+	VinUserOperations string
+	Vins              string
 }{
-	Access: "access",
-	Vins:   "vins",
+	Access:            "access",
+	DeviceDefinitions: "device_definitions",
+	VehicleIdentities: "vehicle_identities",
+	VinDrifts:         "vin_drifts",
+	VinHistory:        "vin_history",
+	VinSacds:          "vin_sacds",
+	VinUserOperations: "vin_user_operations",
+	Vins:              "vins",
 }
@@ -36,6 +36,10 @@ type Vin struct {
 	OperationErrorCode        null.String `boil:"operation_error_code" json:"operation_error_code,omitempty" toml:"operation_error_code" yaml:"operation_error_code,omitempty"`
 	OperationErrorType        null.String `boil:"operation_error_type" json:"operation_error_type,omitempty" toml:"operation_error_type" yaml:"operation_error_type,omitempty"`
 	OperationErrorDescription null.String `boil:"operation_error_description" json:"operation_error_description,omitempty" toml:"operation_error_description" yaml:"operation_error_description,omitempty"`
+	OwnerAddress              null.String `boil:"owner_address" json:"owner_address,omitempty" toml:"owner_address" yaml:"owner_address,omitempty"`
+	TelemetryPaused           bool        `boil:"telemetry_paused" json:"telemetry_paused" toml:"telemetry_paused" yaml:"telemetry_paused"`
+	OnboardingStatusUpdatedAt time.Time   `boil:"onboarding_status_updated_at" json:"onboarding_status_updated_at" toml:"onboarding_status_updated_at" yaml:"onboarding_status_updated_at"`
+	ChainID                   null.Int64  `boil:"chain_id" json:"chain_id,omitempty" toml:"chain_id" yaml:"chain_id,omitempty"`
 
 	R *vinR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L vinL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -54,6 +58,10 @@ var VinColumns = struct {
 	OperationErrorCode        string
 	OperationErrorType        string
 	OperationErrorDescription string
+	OwnerAddress              string
+	TelemetryPaused           string
+	OnboardingStatusUpdatedAt string
+	ChainID                   string
 }{
 	Vin:                       "vin",
 	VehicleTokenID:            "vehicle_token_id",
@@ -67,6 +75,10 @@ var VinColumns = struct {
 	OperationErrorCode:        "operation_error_code",
 	OperationErrorType:        "operation_error_type",
 	OperationErrorDescription: "operation_error_description",
+	OwnerAddress:              "owner_address",
+	TelemetryPaused:           "telemetry_paused",
+	OnboardingStatusUpdatedAt: "onboarding_status_updated_at",
+	ChainID:                   "chain_id",
 }
 
 var VinTableColumns = struct {
@@ -82,6 +94,10 @@ var VinTableColumns = struct {
 	OperationErrorCode        string
 	OperationErrorType        string
 	OperationErrorDescription string
+	OwnerAddress              string
+	TelemetryPaused           string
+	OnboardingStatusUpdatedAt string
+	ChainID                   string
 }{
 	Vin:                       "vins.vin",
 	VehicleTokenID:            "vins.vehicle_token_id",
@@ -95,120 +111,22 @@ var VinTableColumns = struct {
 	OperationErrorCode:        "vins.operation_error_code",
 	OperationErrorType:        "vins.operation_error_type",
 	OperationErrorDescription: "vins.operation_error_description",
+	OwnerAddress:              "vins.owner_address",
+	TelemetryPaused:           "vins.telemetry_paused",
+	OnboardingStatusUpdatedAt: "vins.onboarding_status_updated_at",
+	ChainID:                   "vins.chain_id",
 }
 
 // Generated where
 
-type whereHelpernull_Int64 struct{ field string }
+type whereHelperbool struct{ field string }
 
-func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" ILIKE ?", x)
-}
-func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT ILIKE ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelperint struct{ field string }
-
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint) IN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint) NIN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
 var VinWhere = struct {
 	Vin                       whereHelperstring
@@ -223,6 +141,10 @@ var VinWhere = struct {
 	OperationErrorCode        whereHelpernull_String
 	OperationErrorType        whereHelpernull_String
 	OperationErrorDescription whereHelpernull_String
+	OwnerAddress              whereHelpernull_String
+	TelemetryPaused           whereHelperbool
+	OnboardingStatusUpdatedAt whereHelpertime_Time
+	ChainID                   whereHelpernull_Int64
 }{
 	Vin:                       whereHelperstring{field: "\"oracle_example\".\"vins\".\"vin\""},
 	VehicleTokenID:            whereHelpernull_Int64{field: "\"oracle_example\".\"vins\".\"vehicle_token_id\""},
@@ -236,6 +158,10 @@ var VinWhere = struct {
 	OperationErrorCode:        whereHelpernull_String{field: "\"oracle_example\".\"vins\".\"operation_error_code\""},
 	OperationErrorType:        whereHelpernull_String{field: "\"oracle_example\".\"vins\".\"operation_error_type\""},
 	OperationErrorDescription: whereHelpernull_String{field: "\"oracle_example\".\"vins\".\"operation_error_description\""},
+	OwnerAddress:              whereHelpernull_String{field: "\"oracle_example\".\"vins\".\"owner_address\""},
+	TelemetryPaused:           whereHelperbool{field: "\"oracle_example\".\"vins\".\"telemetry_paused\""},
+	OnboardingStatusUpdatedAt: whereHelpertime_Time{field: "\"oracle_example\".\"vins\".\"onboarding_status_updated_at\""},
+	ChainID:                   whereHelpernull_Int64{field: "\"oracle_example\".\"vins\".\"chain_id\""},
 }
 
 // VinRels is where relationship names are stored.
@@ -255,9 +181,9 @@ func (*vinR) NewStruct() *vinR {
 type vinL struct{}
 
 var (
-	vinAllColumns            = []string{"vin", "vehicle_token_id", "synthetic_token_id", "external_id", "connection_status", "onboarding_status", "device_definition_id", "wallet_index", "disconnection_status", "operation_error_code", "operation_error_type", "operation_error_description"}
+	vinAllColumns            = []string{"vin", "vehicle_token_id", "synthetic_token_id", "external_id", "connection_status", "onboarding_status", "device_definition_id", "wallet_index", "disconnection_status", "operation_error_code", "operation_error_type", "operation_error_description", "owner_address", "telemetry_paused", "onboarding_status_updated_at", "chain_id"}
 	vinColumnsWithoutDefault = []string{"vin"}
-	vinColumnsWithDefault    = []string{"vehicle_token_id", "synthetic_token_id", "external_id", "connection_status", "onboarding_status", "device_definition_id", "wallet_index", "disconnection_status", "operation_error_code", "operation_error_type", "operation_error_description"}
+	vinColumnsWithDefault    = []string{"vehicle_token_id", "synthetic_token_id", "external_id", "connection_status", "onboarding_status", "device_definition_id", "wallet_index", "disconnection_status", "operation_error_code", "operation_error_type", "operation_error_description", "owner_address", "telemetry_paused", "onboarding_status_updated_at", "chain_id"}
 	vinPrimaryKeyColumns     = []string{"vin"}
 	vinGeneratedColumns      = []string{}
 )
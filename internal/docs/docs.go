@@ -0,0 +1,60 @@
+// Package docs serves the OpenAPI document of the API and a Swagger UI to browse it.
+// openapi.yaml is maintained by hand next to the handlers' annotations, app tests check it against the routes and types.
+package docs
+
+import (
+	_ "embed"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/filesystem"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"net/http"
+)
+
+const (
+	UIPath     = "/docs"
+	SpecPath   = "/docs/openapi.yaml"
+	AssetsPath = "/docs/assets"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// swagger UI assets are embedded in the binary, the docs work without internet access and no third party code is loaded
+const uiPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8"/>
+  <title>Oracle Example API</title>
+  <link rel="stylesheet" href="` + AssetsPath + `/swagger-ui.css"/>
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="` + AssetsPath + `/swagger-ui-bundle.js"></script>
+<script>
+  window.onload = () => {
+    window.ui = SwaggerUIBundle({url: "` + SpecPath + `", dom_id: "#swagger-ui"});
+  };
+</script>
+</body>
+</html>`
+
+// Spec returns the OpenAPI 3 document as YAML
+func Spec() []byte {
+	return spec
+}
+
+// Register adds the Swagger UI, its assets and the raw document routes, they don't require auth
+func Register(app *fiber.App) {
+	app.Get(UIPath, func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(uiPage)
+	})
+	app.Get(SpecPath, func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "application/yaml")
+		return c.Send(spec)
+	})
+	app.Use(AssetsPath, filesystem.New(filesystem.Config{
+		Root:   http.FS(swaggerFiles.FS),
+		MaxAge: 86400,
+	}))
+}
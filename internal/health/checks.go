@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"time"
+)
+
+// DBCheck pings the reader and writer connections
+func DBCheck(dbs *db.Store) CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		// the connections are only set once both connected
+		if !dbs.IsReady() {
+			return "", errors.New("not connected")
+		}
+		if err := dbs.DBS().Writer.PingContext(ctx); err != nil {
+			return "", fmt.Errorf("failed to ping writer: %w", err)
+		}
+		if err := dbs.DBS().Reader.PingContext(ctx); err != nil {
+			return "", fmt.Errorf("failed to ping reader: %w", err)
+		}
+		return "", nil
+	}
+}
+
+// RiverCheck checks river can reach its tables. A client that works jobs must also be started and not stopped.
+func RiverCheck(client *river.Client[pgx.Tx], working bool) CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		if working {
+			// Stopped is nil until the client starts
+			stopped := client.Stopped()
+			if stopped == nil {
+				return "", errors.New("client not started")
+			}
+			select {
+			case <-stopped:
+				return "", errors.New("client stopped")
+			default:
+			}
+		}
+
+		queues, err := client.QueueList(ctx, river.NewQueueListParams().First(100))
+		if err != nil {
+			return "", fmt.Errorf("failed to list queues: %w", err)
+		}
+
+		paused := 0
+		for _, queue := range queues.Queues {
+			if queue.PausedAt != nil {
+				paused++
+			}
+		}
+		if working {
+			return fmt.Sprintf("started, %d queues, %d paused", len(queues.Queues), paused), nil
+		}
+		return fmt.Sprintf("insert only, %d queues, %d paused", len(queues.Queues), paused), nil
+	}
+}
+
+// ChainIDCheck checks the RPC endpoint is up and serves the configured chain
+func ChainIDCheck(client *rpc.Client, chainID int64) CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		var result hexutil.Big
+		if err := client.CallContext(ctx, &result, "eth_chainId"); err != nil {
+			return "", fmt.Errorf("failed to get chain ID: %w", err)
+		}
+		if result.ToInt().Int64() != chainID {
+			return "", fmt.Errorf("RPC serves chain %s, expected %d", result.ToInt(), chainID)
+		}
+		return fmt.Sprintf("chain %d", chainID), nil
+	}
+}
+
+// CertificateCheck checks the client certificate, the first one of the PEM chain, is valid now. It reports the
+// expiry, so it shows up in the readiness output well before the certificate stops working.
+func CertificateCheck(certPEM string) CheckFunc {
+	return func(context.Context) (string, error) {
+		cert, err := parseCertificate(certPEM)
+		if err != nil {
+			return "", err
+		}
+
+		now := time.Now()
+		if now.Before(cert.NotBefore) {
+			return "", fmt.Errorf("certificate not valid before %s", cert.NotBefore.Format(time.RFC3339))
+		}
+		if now.After(cert.NotAfter) {
+			return "", fmt.Errorf("certificate expired at %s", cert.NotAfter.Format(time.RFC3339))
+		}
+
+		return fmt.Sprintf("expires at %s, in %d days", cert.NotAfter.Format(time.RFC3339), int(cert.NotAfter.Sub(now).Hours()/24)), nil
+	}
+}
+
+// CertificateExpiry returns when the client certificate, the first one of the PEM chain, expires
+func CertificateExpiry(certPEM string) (time.Time, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
+
+func parseCertificate(certPEM string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	return cert, nil
+}
@@ -0,0 +1,172 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/gofiber/fiber/v2"
+	"sync"
+	"time"
+)
+
+// Status of the service and of each of its components
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CheckFunc checks a dependency, returning details worth showing when it's up, e.g. a certificate's expiry
+type CheckFunc func(ctx context.Context) (string, error)
+
+// Component is the last result of a dependency check
+type Component struct {
+	Status     string    `json:"status"`
+	Details    string    `json:"details,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checkedAt"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// Report is the health of the service, down if any of its components is down
+type Report struct {
+	Status     string               `json:"status"`
+	Components map[string]Component `json:"components,omitempty"`
+}
+
+// Checker runs the readiness checks of the components a process mode runs. Results are cached, so frequent probes
+// and several pods don't hammer the dependencies, and every check is cut off by a timeout.
+type Checker struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	m      sync.RWMutex
+	checks map[string]*check
+}
+
+type check struct {
+	fn CheckFunc
+
+	m       sync.Mutex
+	result  Component
+	expires time.Time
+}
+
+// NewChecker returns a checker without checks, with the timeout and cache TTL from settings
+func NewChecker(settings *config.Settings) *Checker {
+	timeout := time.Duration(settings.HealthCheckTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	cacheTTL := time.Duration(settings.HealthCheckCacheSeconds) * time.Second
+	if cacheTTL <= 0 {
+		cacheTTL = 10 * time.Second
+	}
+
+	return &Checker{
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+		checks:   make(map[string]*check),
+	}
+}
+
+// Register adds the check of a component to readiness, replacing any check with the same name
+func (c *Checker) Register(name string, fn CheckFunc) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.checks[name] = &check{fn: fn}
+}
+
+// Ready runs the checks, or returns their cached results, concurrently
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.m.RLock()
+	checks := make(map[string]*check, len(c.checks))
+	for name, chk := range c.checks {
+		checks[name] = chk
+	}
+	c.m.RUnlock()
+
+	report := Report{Status: StatusUp, Components: make(map[string]Component, len(checks))}
+	var m sync.Mutex
+	var wg sync.WaitGroup
+	for name, chk := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := chk.run(ctx, c.timeout, c.cacheTTL)
+
+			m.Lock()
+			defer m.Unlock()
+			report.Components[name] = result
+			if result.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+// run returns the cached result, or runs the check. Concurrent probes wait for the same run.
+func (chk *check) run(ctx context.Context, timeout, cacheTTL time.Duration) Component {
+	chk.m.Lock()
+	defer chk.m.Unlock()
+
+	if time.Now().Before(chk.expires) {
+		return chk.result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		details string
+		err     error
+	}
+	// buffered, so a check that ignores its context doesn't leak the goroutine once it returns
+	done := make(chan outcome, 1)
+	started := time.Now()
+	go func() {
+		details, err := chk.fn(ctx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	var result outcome
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result = outcome{err: fmt.Errorf("check timed out after %s", timeout)}
+	}
+
+	chk.result = Component{
+		Status:     StatusUp,
+		Details:    result.details,
+		CheckedAt:  started,
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	if result.err != nil {
+		chk.result.Status = StatusDown
+		chk.result.Error = result.err.Error()
+	}
+	chk.expires = time.Now().Add(cacheTTL)
+
+	return chk.result
+}
+
+// LiveHandler reports the process as up as long as it serves requests, dependencies are left to readiness so
+// an outage of one doesn't get every pod restarted
+func (c *Checker) LiveHandler(ctx *fiber.Ctx) error {
+	return ctx.JSON(Report{Status: StatusUp})
+}
+
+// ReadyHandler reports the status of every component, with 503 if any is down
+func (c *Checker) ReadyHandler(ctx *fiber.Ctx) error {
+	report := c.Ready(ctx.UserContext())
+
+	status := fiber.StatusOK
+	if report.Status != StatusUp {
+		status = fiber.StatusServiceUnavailable
+	}
+
+	return ctx.Status(status).JSON(report)
+}
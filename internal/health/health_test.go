@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/stretchr/testify/suite"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
+
+func (s *HealthTestSuite) TestReadyReportsEveryComponent() {
+	checker := NewChecker(&config.Settings{})
+	checker.Register("database", func(context.Context) (string, error) { return "", nil })
+	checker.Register("rpc", func(context.Context) (string, error) { return "", errors.New("connection refused") })
+
+	report := checker.Ready(context.Background())
+
+	s.Equal(StatusDown, report.Status)
+	s.Equal(StatusUp, report.Components["database"].Status)
+	s.Equal(StatusDown, report.Components["rpc"].Status)
+	s.Equal("connection refused", report.Components["rpc"].Error)
+}
+
+func (s *HealthTestSuite) TestReadyCachesResults() {
+	checker := NewChecker(&config.Settings{})
+	var runs atomic.Int32
+	checker.Register("database", func(context.Context) (string, error) {
+		runs.Add(1)
+		return "", nil
+	})
+
+	s.Equal(StatusUp, checker.Ready(context.Background()).Status)
+	s.Equal(StatusUp, checker.Ready(context.Background()).Status)
+	s.Equal(int32(1), runs.Load())
+}
+
+func (s *HealthTestSuite) TestReadyTimesOut() {
+	checker := NewChecker(&config.Settings{HealthCheckTimeoutSeconds: 1})
+	// ignores its context, like a client without one
+	checker.Register("dimoAuth", func(context.Context) (string, error) {
+		time.Sleep(3 * time.Second)
+		return "", nil
+	})
+
+	started := time.Now()
+	report := checker.Ready(context.Background())
+
+	s.Less(time.Since(started), 2*time.Second)
+	s.Equal(StatusDown, report.Status)
+	s.Contains(report.Components["dimoAuth"].Error, "timed out")
+}
+
+func (s *HealthTestSuite) TestCertificateCheck() {
+	_, err := CertificateCheck("not a certificate")(context.Background())
+	s.Error(err)
+}
@@ -3,10 +3,13 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/DIMO-Network/oracle-example/internal/models"
 	"github.com/DIMO-Network/oracle-example/internal/service"
 	"github.com/IBM/sarama"
 	"github.com/rs/zerolog"
+	"sync"
 	"time"
 )
 
@@ -43,6 +46,61 @@ func (h MessageHandlerOperations) Cleanup(_ sarama.ConsumerGroupSession) error {
 	return nil
 }
 
+// ConsumerStatus tracks whether a consumer group member holds a session, for readiness checks
+type ConsumerStatus struct {
+	m       sync.RWMutex
+	active  bool
+	since   time.Time
+	lastErr error
+}
+
+// Check reports the consumer as down while it has no session, e.g. while the brokers are unreachable
+func (s *ConsumerStatus) Check(context.Context) (string, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if !s.active {
+		if s.lastErr != nil {
+			return "", fmt.Errorf("no consumer group session: %w", s.lastErr)
+		}
+		return "", errors.New("no consumer group session")
+	}
+	return fmt.Sprintf("session since %s", s.since.Format(time.RFC3339)), nil
+}
+
+func (s *ConsumerStatus) setActive(active bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.active = active
+	if active {
+		s.since = time.Now()
+		s.lastErr = nil
+	}
+}
+
+func (s *ConsumerStatus) setError(err error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.active = false
+	s.lastErr = err
+}
+
+// statusHandler records the session of the wrapped handler in its status
+type statusHandler struct {
+	sarama.ConsumerGroupHandler
+	status *ConsumerStatus
+}
+
+func (h statusHandler) Setup(session sarama.ConsumerGroupSession) error {
+	h.status.setActive(true)
+	return h.ConsumerGroupHandler.Setup(session)
+}
+
+func (h statusHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	h.status.setActive(false)
+	return h.ConsumerGroupHandler.Cleanup(session)
+}
+
 const (
 	initialRetryInterval = 5 * time.Second // Initial retry interval
 	maxRetryInterval     = 1 * time.Minute // Maximum retry interval
@@ -55,7 +113,7 @@ func SetupKafkaConsumer(
 	topic string,
 	consumerGroupID string,
 	handler sarama.ConsumerGroupHandler,
-) error {
+) (*ConsumerStatus, error) {
 	logger.Info().
 		Strs("brokers", brokerList).
 		Str("topic", topic).
@@ -66,11 +124,14 @@ func SetupKafkaConsumer(
 	consumer, err := sarama.NewConsumerGroup(brokerList, consumerGroupID, getSaramaConfig())
 	if err != nil {
 		logger.Error().Err(err).Msgf("Failed to create Sarama consumer group")
-		return err
+		return nil, err
 	}
 
 	logger.Info().Msgf("Sarama consumer group %s created successfully.", consumerGroupID)
 
+	status := &ConsumerStatus{}
+	handler = statusHandler{ConsumerGroupHandler: handler, status: status}
+
 	// Context for consumer
 	go func() {
 		retryInterval := initialRetryInterval
@@ -78,6 +139,7 @@ func SetupKafkaConsumer(
 			// Retry with exponential backoff
 			if err := consumer.Consume(ctx, []string{topic}, handler); err != nil {
 				logger.Error().Err(err).Msg("Error consuming messages from Kafka, retrying...")
+				status.setError(err)
 				time.Sleep(retryInterval)
 				retryInterval *= 2
 				if retryInterval > maxRetryInterval {
@@ -105,7 +167,7 @@ func SetupKafkaConsumer(
 		}
 	}()
 
-	return nil
+	return status, nil
 }
 
 func getSaramaConfig() *sarama.Config {
@@ -1,13 +1,16 @@
 package mocks
 
 import (
+	"context"
 	"errors"
 	"github.com/DIMO-Network/oracle-example/internal/models"
+	"slices"
 )
 
 type IdentityAPIMock struct {
 	Vehicles          []models.Vehicle
 	DeviceDefinitions []models.DeviceDefinition
+	Sacds             map[int64][]models.Sacd
 }
 
 func NewIdentityAPIMock(v []models.Vehicle, dd []models.DeviceDefinition) *IdentityAPIMock {
@@ -37,7 +40,7 @@ func (m *IdentityAPIMock) GetCachedVehicleByTokenID(tokenID int64) (*models.Vehi
 	return nil, errors.New("vehicle not found")
 }
 
-func (m *IdentityAPIMock) FetchVehicleByTokenID(tokenID int64) (*models.Vehicle, error) {
+func (m *IdentityAPIMock) FetchVehicleByTokenID(_ context.Context, tokenID int64) (*models.Vehicle, error) {
 	for _, vehicle := range m.Vehicles {
 		if vehicle.TokenID == tokenID {
 			return &vehicle, nil
@@ -47,7 +50,11 @@ func (m *IdentityAPIMock) FetchVehicleByTokenID(tokenID int64) (*models.Vehicle,
 	return nil, errors.New("vehicle not found")
 }
 
-func (m *IdentityAPIMock) FetchVehiclesByWalletAddress(address string) ([]models.Vehicle, error) {
+func (m *IdentityAPIMock) GetVehiclesByWalletAddress(ctx context.Context, address string) ([]models.Vehicle, error) {
+	return m.FetchVehiclesByWalletAddress(ctx, address)
+}
+
+func (m *IdentityAPIMock) FetchVehiclesByWalletAddress(_ context.Context, address string) ([]models.Vehicle, error) {
 	returnVal := []models.Vehicle{}
 	for _, vehicle := range m.Vehicles {
 		if vehicle.Owner == address {
@@ -57,7 +64,29 @@ func (m *IdentityAPIMock) FetchVehiclesByWalletAddress(address string) ([]models
 	return returnVal, nil
 }
 
-func (m *IdentityAPIMock) GetDeviceDefinitionByID(id string) (*models.DeviceDefinition, error) {
+func (m *IdentityAPIMock) StreamVehiclesByWalletAddress(ctx context.Context, address string, fn func(page []models.Vehicle) error) error {
+	vehicles, err := m.FetchVehiclesByWalletAddress(ctx, address)
+	if err != nil {
+		return err
+	}
+	return fn(vehicles)
+}
+
+func (m *IdentityAPIMock) FetchOwnedVehicles(_ context.Context, owner string, tokenIDs []int64) ([]models.Vehicle, error) {
+	returnVal := []models.Vehicle{}
+	for _, vehicle := range m.Vehicles {
+		if vehicle.Owner == owner && slices.Contains(tokenIDs, vehicle.TokenID) {
+			returnVal = append(returnVal, vehicle)
+		}
+	}
+	return returnVal, nil
+}
+
+func (m *IdentityAPIMock) GetVehicleSacds(_ context.Context, tokenID int64) ([]models.Sacd, error) {
+	return m.Sacds[tokenID], nil
+}
+
+func (m *IdentityAPIMock) GetDeviceDefinitionByID(_ context.Context, id string) (*models.DeviceDefinition, error) {
 	for _, definition := range m.DeviceDefinitions {
 		if definition.DeviceDefinitionID == id {
 			return &definition, nil
@@ -77,7 +106,7 @@ func (m *IdentityAPIMock) GetCachedDeviceDefinitionByID(id string) (*models.Devi
 	return nil, errors.New("device definition not found")
 }
 
-func (m *IdentityAPIMock) FetchDeviceDefinitionByID(id string) (*models.DeviceDefinition, error) {
+func (m *IdentityAPIMock) FetchDeviceDefinitionByID(_ context.Context, id string) (*models.DeviceDefinition, error) {
 	for _, definition := range m.DeviceDefinitions {
 		if definition.DeviceDefinitionID == id {
 			return &definition, nil
@@ -1,9 +1,5 @@
 package models
 
-type GraphQLRequest struct {
-	Query string `json:"query"`
-}
-
 type Vehicle struct {
 	VIN                 string          `json:"vin"`
 	ID                  string          `json:"id"`
@@ -53,6 +49,27 @@ type PagedVehicles struct {
 	VehicleNodes PagedVehiclesNodes `json:"vehicles"`
 }
 
+type Sacd struct {
+	Grantee     string `json:"grantee"`
+	Permissions string `json:"permissions"` // hex encoded, 2 bits per privilege
+	Source      string `json:"source"`
+	CreatedAt   string `json:"createdAt"`
+	ExpiresAt   string `json:"expiresAt"`
+}
+
+type SacdNodes struct {
+	Nodes    []Sacd   `json:"nodes"`
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+type VehicleSacds struct {
+	Sacds SacdNodes `json:"sacds"`
+}
+
+type SingleVehicleSacds struct {
+	Vehicle VehicleSacds `json:"vehicle"`
+}
+
 type SingleDeviceDefinition struct {
 	DeviceDefinition DeviceDefinition `json:"deviceDefinition"`
 }
@@ -69,10 +86,6 @@ type Manufacturer struct {
 	Name    string `json:"name"`
 }
 
-type GraphQlData[T any] struct {
-	Data T `json:"data"`
-}
-
 type Data struct {
 	Location  Location `json:"location"`
 	Speed     Signal   `json:"speed"`
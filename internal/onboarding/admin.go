@@ -0,0 +1,210 @@
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"slices"
+)
+
+const (
+	VinHistoryEventOperatorReset   = "operator_reset"
+	VinHistoryEventOperatorEnqueue = "operator_enqueue"
+)
+
+// EnqueueableKinds are the job kinds operators can re-enqueue for a VIN
+var EnqueueableKinds = []string{
+	VerifyArgs{}.Kind(),
+	OnboardingArgs{}.Kind(),
+	DisconnectArgs{}.Kind(),
+	DeleteArgs{}.Kind(),
+}
+
+var (
+	ErrVinNotFound      = errors.New("VIN not found")
+	ErrStatusNotAllowed = errors.New("status is not a retryable status")
+	ErrJobNotFound      = errors.New("no job found for VIN")
+	ErrKindNotAllowed   = errors.New("job kind can't be re-enqueued")
+)
+
+// VinAdmin repairs VIN records for operators. Every change is recorded in VIN history with the operator's name,
+// dry runs only report what would change.
+type VinAdmin struct {
+	settings *config.Settings
+	logger   zerolog.Logger
+	dbs      *db.Store
+	river    *river.Client[pgx.Tx]
+}
+
+func NewVinAdmin(settings *config.Settings, logger zerolog.Logger, dbs *db.Store, riverClient *river.Client[pgx.Tx]) *VinAdmin {
+	return &VinAdmin{
+		settings: settings,
+		logger:   logger,
+		dbs:      dbs,
+		river:    riverClient,
+	}
+}
+
+// Get returns the VIN record and its history, oldest first
+func (a *VinAdmin) Get(ctx context.Context, vin string) (*dbmodels.Vin, dbmodels.VinHistorySlice, error) {
+	record, err := dbmodels.FindVin(ctx, a.dbs.DBS().Reader, vin)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrVinNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to load VIN record: %w", err)
+	}
+
+	history, err := dbmodels.VinHistories(
+		dbmodels.VinHistoryWhere.Vin.EQ(vin),
+		qm.OrderBy(dbmodels.VinHistoryColumns.ID),
+	).All(ctx, a.dbs.DBS().Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load VIN history: %w", err)
+	}
+
+	return record, history, nil
+}
+
+// List returns up to limit VINs with any of the statuses, ordered by VIN
+func (a *VinAdmin) List(ctx context.Context, statuses []int, limit int) (dbmodels.VinSlice, error) {
+	mods := []qm.QueryMod{qm.OrderBy(dbmodels.VinColumns.Vin), qm.Limit(limit)}
+	if len(statuses) > 0 {
+		mods = append(mods, dbmodels.VinWhere.OnboardingStatus.IN(statuses))
+	}
+
+	vins, err := dbmodels.Vins(mods...).All(ctx, a.dbs.DBS().Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VINs: %w", err)
+	}
+
+	return vins, nil
+}
+
+// Reset sets the onboarding status of the VIN to one of the RetryableStatuses and returns its previous status
+func (a *VinAdmin) Reset(ctx context.Context, vin string, status int, operator string, dryRun bool) (int, error) {
+	if !IsRetryable(status) {
+		return 0, fmt.Errorf("%w: %s", ErrStatusNotAllowed, GetDetailedStatus(status))
+	}
+
+	tx, err := a.dbs.DBS().Writer.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		a.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil || dryRun {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				a.logger.Error().Err(rbErr).Msg("Failed to rollback transaction")
+			}
+		}
+	}()
+
+	record, err := dbmodels.Vins(dbmodels.VinWhere.Vin.EQ(vin), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrVinNotFound
+		}
+		return 0, fmt.Errorf("failed to load VIN record: %w", err)
+	}
+
+	previous := record.OnboardingStatus
+	if dryRun {
+		return previous, nil
+	}
+
+	record.OnboardingStatus = status
+	if _, err = record.Update(ctx, tx, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus)); err != nil {
+		return 0, fmt.Errorf("failed to update VIN record: %w", err)
+	}
+
+	details := fmt.Sprintf("operator: %s, status: %s -> %s", operator, GetDetailedStatus(previous), GetDetailedStatus(status))
+	if err = a.audit(ctx, tx, record, VinHistoryEventOperatorReset, details); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		a.logger.Error().Err(err).Msg("Failed to commit transaction")
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	a.logger.Info().Str(logfields.VIN, vin).Str("operator", operator).Msgf("VIN reset to %s", GetDetailedStatus(status))
+
+	return previous, nil
+}
+
+// Enqueue makes the last job of the kind for the VIN available again, with its original args, so signed payloads
+// don't have to be requested from the owner again. Running jobs are left alone.
+func (a *VinAdmin) Enqueue(ctx context.Context, vin string, kind string, operator string, dryRun bool) (*rivertype.JobRow, error) {
+	if !slices.Contains(EnqueueableKinds, kind) {
+		return nil, fmt.Errorf("%w: %s", ErrKindNotAllowed, kind)
+	}
+
+	record, err := dbmodels.FindVin(ctx, a.dbs.DBS().Reader, vin)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVinNotFound
+		}
+		return nil, fmt.Errorf("failed to load VIN record: %w", err)
+	}
+
+	lastJob, err := lastVinJob(ctx, a.dbs.DBS().Reader, a.settings.DB.Name, kind, vin)
+	if err != nil {
+		return nil, err
+	}
+	if lastJob == nil {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, kind)
+	}
+
+	if dryRun {
+		job, err := a.river.JobGet(ctx, lastJob.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job %d: %w", lastJob.ID, err)
+		}
+		return job, nil
+	}
+
+	job, err := a.river.JobRetry(ctx, lastJob.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry job %d: %w", lastJob.ID, err)
+	}
+	if job.State == rivertype.JobStateRunning {
+		return job, nil
+	}
+
+	details := fmt.Sprintf("operator: %s, job: %s %d", operator, kind, job.ID)
+	if err = a.audit(ctx, a.dbs.DBS().Writer, record, VinHistoryEventOperatorEnqueue, details); err != nil {
+		return nil, err
+	}
+
+	a.logger.Info().Str(logfields.VIN, vin).Str("operator", operator).Int64("jobId", job.ID).Msgf("Re-enqueued %s job", kind)
+
+	return job, nil
+}
+
+func (a *VinAdmin) audit(ctx context.Context, exec boil.ContextExecutor, record *dbmodels.Vin, event, details string) error {
+	history := dbmodels.VinHistory{
+		Vin:           record.Vin,
+		Event:         event,
+		PreviousOwner: record.OwnerAddress,
+		NewOwner:      record.OwnerAddress,
+		Details:       null.StringFrom(details),
+	}
+	if err := history.Insert(ctx, exec, boil.Infer()); err != nil {
+		return fmt.Errorf("failed to insert VIN history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package onboarding
+
+import (
+	"context"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/test"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"testing"
+)
+
+const adminTestVin = "1HGCM82633A004352"
+
+type VinAdminTestSuite struct {
+	suite.Suite
+	pdb       db.Store
+	container testcontainers.Container
+	ctx       context.Context
+	settings  config.Settings
+	river     *river.Client[pgx.Tx]
+	admin     *VinAdmin
+}
+
+func TestVinAdminTestSuite(t *testing.T) {
+	suite.Run(t, new(VinAdminTestSuite))
+}
+
+// SetupSuite starts container db
+func (s *VinAdminTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container, s.settings = test.StartContainerDatabase(s.ctx, s.T(), migrationsDirRelPath)
+
+	dbPool, err := pgxpool.New(s.ctx, s.settings.DB.BuildConnectionString(true))
+	s.Require().NoError(err)
+
+	// insert only client, jobs are never worked
+	s.river, err = river.NewClient(riverpgxv5.New(dbPool), &river.Config{SkipUnknownJobCheck: true})
+	s.Require().NoError(err)
+
+	s.admin = NewVinAdmin(&s.settings, zerolog.Nop(), &s.pdb, s.river)
+}
+
+func (s *VinAdminTestSuite) SetupTest() {
+	record := dbmodels.Vin{
+		Vin:              adminTestVin,
+		OnboardingStatus: OnboardingStatusMintPending,
+	}
+	s.Require().NoError(record.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+}
+
+// TearDownTest after each test truncate tables
+func (s *VinAdminTestSuite) TearDownTest() {
+	test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+// TearDownSuite cleanup at end by terminating container
+func (s *VinAdminTestSuite) TearDownSuite() {
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func (s *VinAdminTestSuite) status() int {
+	record, err := dbmodels.FindVin(s.ctx, s.pdb.DBS().Reader, adminTestVin)
+	s.Require().NoError(err)
+
+	return record.OnboardingStatus
+}
+
+func (s *VinAdminTestSuite) history() dbmodels.VinHistorySlice {
+	_, history, err := s.admin.Get(s.ctx, adminTestVin)
+	s.Require().NoError(err)
+
+	return history
+}
+
+func (s *VinAdminTestSuite) TestResetDryRun() {
+	previous, err := s.admin.Reset(s.ctx, adminTestVin, OnboardingStatusMintFailure, "alice", true)
+	s.Require().NoError(err)
+	s.Equal(OnboardingStatusMintPending, previous)
+	s.Equal(OnboardingStatusMintPending, s.status())
+	s.Empty(s.history())
+}
+
+func (s *VinAdminTestSuite) TestReset() {
+	previous, err := s.admin.Reset(s.ctx, adminTestVin, OnboardingStatusMintFailure, "alice", false)
+	s.Require().NoError(err)
+	s.Equal(OnboardingStatusMintPending, previous)
+	s.Equal(OnboardingStatusMintFailure, s.status())
+
+	history := s.history()
+	s.Require().Len(history, 1)
+	s.Equal(VinHistoryEventOperatorReset, history[0].Event)
+	s.Equal("operator: alice, status: MintPending -> MintFailure", history[0].Details.String)
+}
+
+func (s *VinAdminTestSuite) TestResetRejected() {
+	_, err := s.admin.Reset(s.ctx, adminTestVin, OnboardingStatusMintPending, "alice", false)
+	s.ErrorIs(err, ErrStatusNotAllowed)
+
+	_, err = s.admin.Reset(s.ctx, "1HGCM82633A004353", OnboardingStatusMintFailure, "alice", false)
+	s.ErrorIs(err, ErrVinNotFound)
+
+	s.Equal(OnboardingStatusMintPending, s.status())
+	s.Empty(s.history())
+}
+
+func (s *VinAdminTestSuite) TestEnqueueDryRun() {
+	inserted, err := s.river.Insert(s.ctx, VerifyArgs{VIN: adminTestVin, CountryCode: "USA"}, nil)
+	s.Require().NoError(err)
+
+	job, err := s.admin.Enqueue(s.ctx, adminTestVin, VerifyArgs{}.Kind(), "alice", true)
+	s.Require().NoError(err)
+	s.Equal(inserted.Job.ID, job.ID)
+	s.Empty(s.history())
+}
+
+func (s *VinAdminTestSuite) TestEnqueue() {
+	_, err := s.river.Insert(s.ctx, VerifyArgs{VIN: "1HGCM82633A004353", CountryCode: "USA"}, nil)
+	s.Require().NoError(err)
+	inserted, err := s.river.Insert(s.ctx, VerifyArgs{VIN: adminTestVin, CountryCode: "USA"}, nil)
+	s.Require().NoError(err)
+
+	job, err := s.admin.Enqueue(s.ctx, adminTestVin, VerifyArgs{}.Kind(), "alice", false)
+	s.Require().NoError(err)
+	s.Equal(inserted.Job.ID, job.ID)
+
+	history := s.history()
+	s.Require().Len(history, 1)
+	s.Equal(VinHistoryEventOperatorEnqueue, history[0].Event)
+	s.Contains(history[0].Details.String, "operator: alice, job: verify")
+}
+
+func (s *VinAdminTestSuite) TestEnqueueRejected() {
+	_, err := s.admin.Enqueue(s.ctx, adminTestVin, VerifyArgs{}.Kind(), "alice", false)
+	s.ErrorIs(err, ErrJobNotFound)
+
+	_, err = s.admin.Enqueue(s.ctx, adminTestVin, RecoverStuckArgs{}.Kind(), "alice", false)
+	s.ErrorIs(err, ErrKindNotAllowed)
+
+	s.Empty(s.history())
+}
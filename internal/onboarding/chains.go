@@ -0,0 +1,70 @@
+package onboarding
+
+import (
+	"fmt"
+	"github.com/DIMO-Network/go-transactions"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"slices"
+)
+
+// Chains holds a transactions client per chain profile. VIN records store the chain they are registered on, so
+// records of one deployment can be minted on different chains, eg. Amoy testnet and Polygon mainnet.
+type Chains struct {
+	defaultChainID int64
+	clients        map[int64]*transactions.Client
+}
+
+func NewChains(defaultChainID int64, clients map[int64]*transactions.Client) *Chains {
+	return &Chains{
+		defaultChainID: defaultChainID,
+		clients:        clients,
+	}
+}
+
+// NewChainsFromProfiles creates a transactions client for every chain profile, all sending from the developer AA wallet
+func NewChainsFromProfiles(settings *config.Settings, profiles config.ChainProfiles) (*Chains, error) {
+	clients := make(map[int64]*transactions.Client, len(profiles))
+	for _, profile := range profiles {
+		profileSettings := settings.WithChainProfile(profile)
+		client, err := NewTransactionsClient(&profileSettings)
+		if err != nil {
+			return nil, fmt.Errorf("chain profile %s: %w", profile.Name, err)
+		}
+		clients[profile.ChainID] = client
+	}
+
+	return NewChains(profiles.Default().ChainID, clients), nil
+}
+
+// DefaultChainID is the chain of new VINs that don't ask for one, and of records registered before chain profiles
+func (c *Chains) DefaultChainID() int64 {
+	return c.defaultChainID
+}
+
+// IDs returns the chain IDs of the profiles, sorted
+func (c *Chains) IDs() []int64 {
+	ids := make([]int64, 0, len(c.clients))
+	for id := range c.clients {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Client returns the transactions client of the chain, 0 is the default chain
+func (c *Chains) Client(chainID int64) (*transactions.Client, error) {
+	if chainID == 0 {
+		chainID = c.defaultChainID
+	}
+	client, ok := c.clients[chainID]
+	if !ok {
+		return nil, fmt.Errorf("no chain profile for chain %d", chainID)
+	}
+	return client, nil
+}
+
+// ForRecord returns the transactions client of the chain the VIN is registered on
+func (c *Chains) ForRecord(record *dbmodels.Vin) (*transactions.Client, error) {
+	return c.Client(record.ChainID.Int64)
+}
@@ -0,0 +1,56 @@
+package onboarding
+
+import (
+	"github.com/DIMO-Network/go-transactions"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/stretchr/testify/suite"
+	"github.com/volatiletech/null/v8"
+	"testing"
+)
+
+type ChainsTestSuite struct {
+	suite.Suite
+	amoy    *transactions.Client
+	polygon *transactions.Client
+	chains  *Chains
+}
+
+func TestChainsTestSuite(t *testing.T) {
+	suite.Run(t, new(ChainsTestSuite))
+}
+
+func (s *ChainsTestSuite) SetupTest() {
+	s.amoy = &transactions.Client{}
+	s.polygon = &transactions.Client{}
+	s.chains = NewChains(80002, map[int64]*transactions.Client{80002: s.amoy, 137: s.polygon})
+}
+
+func (s *ChainsTestSuite) TestClient() {
+	client, err := s.chains.Client(137)
+	s.Require().NoError(err)
+	s.Same(s.polygon, client)
+
+	// 0 is the default chain
+	client, err = s.chains.Client(0)
+	s.Require().NoError(err)
+	s.Same(s.amoy, client)
+
+	_, err = s.chains.Client(1)
+	s.ErrorContains(err, "no chain profile for chain 1")
+}
+
+func (s *ChainsTestSuite) TestForRecord() {
+	// records registered before chain profiles have no chain
+	client, err := s.chains.ForRecord(&dbmodels.Vin{})
+	s.Require().NoError(err)
+	s.Same(s.amoy, client)
+
+	client, err = s.chains.ForRecord(&dbmodels.Vin{ChainID: null.Int64From(137)})
+	s.Require().NoError(err)
+	s.Same(s.polygon, client)
+}
+
+func (s *ChainsTestSuite) TestIDs() {
+	s.Equal(int64(80002), s.chains.DefaultChainID())
+	s.Equal([]int64{137, 80002}, s.chains.IDs())
+}
@@ -30,7 +30,8 @@ func (a DeleteArgs) Kind() string {
 }
 func (a DeleteArgs) InsertOpts() river.InsertOpts {
 	return river.InsertOpts{
-		MaxAttempts: 1,
+		Queue:       QueueChain,
+		MaxAttempts: chainStepMaxAttempts,
 		UniqueOpts: river.UniqueOpts{
 			ByArgs: false,
 		},
@@ -42,7 +43,7 @@ type DeleteWorker struct {
 	logger   zerolog.Logger
 	identity service.IdentityAPI
 	dbs      *db.Store
-	tr       *transactions.Client
+	chains   *Chains
 	ws       service.SDWalletsAPI
 	m        sync.RWMutex
 	vendor   VendorOnboardingAPI
@@ -50,13 +51,13 @@ type DeleteWorker struct {
 	river.WorkerDefaults[DeleteArgs]
 }
 
-func NewDeleteWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dbs *db.Store, tr *transactions.Client, ws service.SDWalletsAPI, vendor VendorOnboardingAPI) *DeleteWorker {
+func NewDeleteWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dbs *db.Store, chains *Chains, ws service.SDWalletsAPI, vendor VendorOnboardingAPI) *DeleteWorker {
 	return &DeleteWorker{
 		settings: settings,
 		logger:   logger,
 		identity: identity,
 		dbs:      dbs,
-		tr:       tr,
+		chains:   chains,
 		ws:       ws,
 		vendor:   vendor,
 	}
@@ -65,6 +66,12 @@ func NewDeleteWorker(settings *config.Settings, logger zerolog.Logger, identity
 func (w *DeleteWorker) Timeout(*river.Job[DeleteArgs]) time.Duration { return 30 * time.Minute }
 
 func (w *DeleteWorker) Work(ctx context.Context, job *river.Job[DeleteArgs]) error {
+	normalizedVin, err := normalizeJobVin(job.Args.VIN)
+	if err != nil {
+		return err
+	}
+	job.Args.VIN = normalizedVin
+
 	w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Msg("Delete VIN")
 
 	// Check if the VIN record exists
@@ -82,6 +89,11 @@ func (w *DeleteWorker) Work(ctx context.Context, job *river.Job[DeleteArgs]) err
 		return nil
 	}
 
+	tr, err := w.chains.ForRecord(record)
+	if err != nil {
+		return river.JobCancel(err)
+	}
+
 	// If SD Token ID is valid - fail, can't burn
 	if record.SyntheticTokenID.Valid {
 		w.logger.Error().Str(logfields.VIN, job.Args.VIN).Msg("SD defined, can't burn")
@@ -91,7 +103,7 @@ func (w *DeleteWorker) Work(ctx context.Context, job *river.Job[DeleteArgs]) err
 	// If Vehicle Token ID is valid - burn
 	if record.VehicleTokenID.Valid {
 		w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Msg("Burning Vehicle")
-		_, err = w.BurnVehicleAndUpdate(ctx, record, job.Args)
+		_, err = w.BurnVehicleAndUpdate(ctx, tr, record, job.Args)
 		if err != nil {
 			return err
 		}
@@ -110,7 +122,7 @@ func (w *DeleteWorker) GetVinRecord(ctx context.Context, vinToSearch string) (*d
 	return vin, nil
 }
 
-func (w *DeleteWorker) BurnVehicleAndUpdate(ctx context.Context, record *dbmodels.Vin, args DeleteArgs) (*dbmodels.Vin, error) {
+func (w *DeleteWorker) BurnVehicleAndUpdate(ctx context.Context, tr *transactions.Client, record *dbmodels.Vin, args DeleteArgs) (*dbmodels.Vin, error) {
 	// make sure we save status update (and possible new DD)
 	defer (func() {
 		_ = w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus, dbmodels.VinColumns.VehicleTokenID))
@@ -118,24 +130,27 @@ func (w *DeleteWorker) BurnVehicleAndUpdate(ctx context.Context, record *dbmodel
 
 	w.logger.Debug().Str(logfields.VIN, args.VIN).Msg("Burning Vehicle")
 
+	record.OnboardingStatus = OnboardingStatusBurnVehiclePending
+	if err := w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus)); err != nil {
+		return nil, err
+	}
+
 	w.m.Lock()
-	opResult, err := w.tr.SendSignedUserOperation(args.UserOperation, true)
+	opResult, err := newUserOperations(w.logger, w.dbs, tr).sendSigned(ctx, args.VIN, userOperationStepBurnVehicle, args.UserOperation)
+	w.m.Unlock()
 	if err != nil {
-		w.m.Unlock()
 		w.logger.Error().Err(err).Msg("Failed to Burn Vehicle")
-		record.OnboardingStatus = OnboardingStatusBurnVehicleFailure
+		record.OnboardingStatus, err = chainStepFailure(err, OnboardingStatusBurnVehiclePending, OnboardingStatusBurnVehicleFailure)
 		return nil, err
 	}
 
-	result, err := w.tr.GetBurnVehicleByOwnerResult(opResult)
+	result, err := tr.GetBurnVehicleByOwnerResult(opResult)
 	if err != nil {
-		w.m.Unlock()
 		w.logger.Error().Err(err).Msg("Failed to get burn Vehicle result")
 		record.OnboardingStatus = OnboardingStatusBurnVehicleFailure
+		return nil, river.JobCancel(err)
 	}
 
-	w.m.Unlock()
-
 	record.VehicleTokenID = null.NewInt64(0, false)
 	record.OnboardingStatus = OnboardingStatusBurnVehicleSuccess
 
@@ -29,7 +29,8 @@ func (a DisconnectArgs) Kind() string {
 }
 func (a DisconnectArgs) InsertOpts() river.InsertOpts {
 	return river.InsertOpts{
-		MaxAttempts: 1,
+		Queue:       QueueChain,
+		MaxAttempts: chainStepMaxAttempts,
 		UniqueOpts: river.UniqueOpts{
 			ByArgs: false,
 		},
@@ -41,29 +42,37 @@ type DisconnectWorker struct {
 	logger   zerolog.Logger
 	identity service.IdentityAPI
 	dbs      *db.Store
-	tr       *transactions.Client
+	chains   *Chains
 	ws       service.SDWalletsAPI
 	m        sync.RWMutex
 	vendor   VendorOnboardingAPI
+	switches *VendorSwitches
 
 	river.WorkerDefaults[DisconnectArgs]
 }
 
-func NewDisconnectWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dbs *db.Store, tr *transactions.Client, ws service.SDWalletsAPI, vendor VendorOnboardingAPI) *DisconnectWorker {
+func NewDisconnectWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dbs *db.Store, chains *Chains, ws service.SDWalletsAPI, vendor VendorOnboardingAPI, switches *VendorSwitches) *DisconnectWorker {
 	return &DisconnectWorker{
 		settings: settings,
 		logger:   logger,
 		identity: identity,
 		dbs:      dbs,
-		tr:       tr,
+		chains:   chains,
 		ws:       ws,
 		vendor:   vendor,
+		switches: switches,
 	}
 }
 
 func (w *DisconnectWorker) Timeout(*river.Job[DisconnectArgs]) time.Duration { return 30 * time.Minute }
 
 func (w *DisconnectWorker) Work(ctx context.Context, job *river.Job[DisconnectArgs]) error {
+	normalizedVin, err := normalizeJobVin(job.Args.VIN)
+	if err != nil {
+		return err
+	}
+	job.Args.VIN = normalizedVin
+
 	w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Msg("Disconnection VIN")
 
 	// Check if the VIN record exists
@@ -81,6 +90,11 @@ func (w *DisconnectWorker) Work(ctx context.Context, job *river.Job[DisconnectAr
 		return nil
 	}
 
+	tr, err := w.chains.ForRecord(record)
+	if err != nil {
+		return river.JobCancel(err)
+	}
+
 	// Disconnect to external vendor
 	record, err = w.DisconnectFromVendorAndUpdate(ctx, record, job.Args)
 	if err != nil {
@@ -90,7 +104,7 @@ func (w *DisconnectWorker) Work(ctx context.Context, job *river.Job[DisconnectAr
 	// If SD is valid - burn
 	if record.SyntheticTokenID.Valid {
 		w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Msg("Burning SD")
-		_, err = w.BurnSDAndUpdate(ctx, record, job.Args)
+		_, err = w.BurnSDAndUpdate(ctx, tr, record, job.Args)
 		if err != nil {
 			return err
 		}
@@ -117,7 +131,7 @@ func (w *DisconnectWorker) DisconnectFromVendorAndUpdate(ctx context.Context, re
 
 	record.OnboardingStatus = OnboardingStatusDisconnectUnknown
 
-	if w.settings.EnableVendorConnection {
+	if w.switches.Connection() {
 		connection, err := w.vendor.Disconnect([]string{args.VIN})
 		if err != nil {
 			w.logger.Error().Err(err).Msg("Failed to disconnect from vendor")
@@ -152,7 +166,7 @@ func (w *DisconnectWorker) DisconnectFromVendorAndUpdate(ctx context.Context, re
 	return record, nil
 }
 
-func (w *DisconnectWorker) BurnSDAndUpdate(ctx context.Context, record *dbmodels.Vin, args DisconnectArgs) (*dbmodels.Vin, error) {
+func (w *DisconnectWorker) BurnSDAndUpdate(ctx context.Context, tr *transactions.Client, record *dbmodels.Vin, args DisconnectArgs) (*dbmodels.Vin, error) {
 	// make sure we save status update (and possible new DD)
 	defer (func() {
 		_ = w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus, dbmodels.VinColumns.SyntheticTokenID, dbmodels.VinColumns.WalletIndex))
@@ -160,24 +174,27 @@ func (w *DisconnectWorker) BurnSDAndUpdate(ctx context.Context, record *dbmodels
 
 	w.logger.Debug().Str(logfields.VIN, args.VIN).Msg("Burning SD")
 
+	record.OnboardingStatus = OnboardingStatusBurnSDPending
+	if err := w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus)); err != nil {
+		return nil, err
+	}
+
 	w.m.Lock()
-	opResult, err := w.tr.SendSignedUserOperation(args.UserOperation, true)
+	opResult, err := newUserOperations(w.logger, w.dbs, tr).sendSigned(ctx, args.VIN, userOperationStepBurnSD, args.UserOperation)
+	w.m.Unlock()
 	if err != nil {
-		w.m.Unlock()
 		w.logger.Error().Err(err).Msg("Failed to Burn SD")
-		record.OnboardingStatus = OnboardingStatusBurnSDFailure
+		record.OnboardingStatus, err = chainStepFailure(err, OnboardingStatusBurnSDPending, OnboardingStatusBurnSDFailure)
 		return nil, err
 	}
 
-	result, err := w.tr.GetBurnSDByOwnerResult(opResult)
+	result, err := tr.GetBurnSDByOwnerResult(opResult)
 	if err != nil {
-		w.m.Unlock()
 		w.logger.Error().Err(err).Msg("Failed to get burn SD result")
 		record.OnboardingStatus = OnboardingStatusBurnSDFailure
+		return nil, river.JobCancel(err)
 	}
 
-	w.m.Unlock()
-
 	record.WalletIndex = null.NewInt64(0, false)
 	record.SyntheticTokenID = null.NewInt64(0, false)
 	record.OnboardingStatus = OnboardingStatusBurnSDSuccess
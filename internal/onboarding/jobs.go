@@ -0,0 +1,47 @@
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/friendsofgo/errors"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"slices"
+	"time"
+)
+
+// vinJob is the river job of a VIN, loaded straight from river_job since river can't filter jobs by args
+type vinJob struct {
+	ID        int64       `boil:"id"`
+	State     string      `boil:"state"`
+	Owner     null.String `boil:"owner"`
+	CreatedAt time.Time   `boil:"created_at"`
+}
+
+// IsLive reports whether river will still work the job
+func (j *vinJob) IsLive() bool {
+	return slices.Contains([]rivertype.JobState{
+		rivertype.JobStateAvailable,
+		rivertype.JobStatePending,
+		rivertype.JobStateRetryable,
+		rivertype.JobStateRunning,
+		rivertype.JobStateScheduled,
+	}, rivertype.JobState(j.State))
+}
+
+// lastVinJob returns the last job of the kind for the VIN, or nil if there is none
+func lastVinJob(ctx context.Context, exec boil.ContextExecutor, schema, kind, vin string) (*vinJob, error) {
+	job := &vinJob{}
+	qry := fmt.Sprintf("SELECT id, state, args->>'owner' AS owner, created_at FROM %s.river_job WHERE kind = $1 AND args->>'vin' = $2 ORDER BY id DESC LIMIT 1;", schema)
+	if err := queries.Raw(qry, kind, vin).Bind(ctx, exec, job); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find last %s job: %w", kind, err)
+	}
+
+	return job, nil
+}
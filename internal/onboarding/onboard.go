@@ -97,7 +97,8 @@ func (a OnboardingArgs) Kind() string {
 }
 func (a OnboardingArgs) InsertOpts() river.InsertOpts {
 	return river.InsertOpts{
-		MaxAttempts: 1,
+		Queue:       QueueChain,
+		MaxAttempts: chainStepMaxAttempts,
 		UniqueOpts: river.UniqueOpts{
 			ByArgs: false,
 		},
@@ -109,29 +110,37 @@ type OnboardingWorker struct {
 	logger   zerolog.Logger
 	identity service.IdentityAPI
 	dbs      *db.Store
-	tr       *transactions.Client
+	chains   *Chains
 	ws       service.SDWalletsAPI
 	m        sync.RWMutex
 	vendor   VendorOnboardingAPI
+	switches *VendorSwitches
 
 	river.WorkerDefaults[OnboardingArgs]
 }
 
-func NewOnboardingWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dbs *db.Store, tr *transactions.Client, ws service.SDWalletsAPI, vendor VendorOnboardingAPI) *OnboardingWorker {
+func NewOnboardingWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dbs *db.Store, chains *Chains, ws service.SDWalletsAPI, vendor VendorOnboardingAPI, switches *VendorSwitches) *OnboardingWorker {
 	return &OnboardingWorker{
 		settings: settings,
 		logger:   logger,
 		identity: identity,
 		dbs:      dbs,
-		tr:       tr,
+		chains:   chains,
 		ws:       ws,
 		vendor:   vendor,
+		switches: switches,
 	}
 }
 
 func (w *OnboardingWorker) Timeout(*river.Job[OnboardingArgs]) time.Duration { return 30 * time.Minute }
 
 func (w *OnboardingWorker) Work(ctx context.Context, job *river.Job[OnboardingArgs]) error {
+	normalizedVin, err := normalizeJobVin(job.Args.VIN)
+	if err != nil {
+		return err
+	}
+	job.Args.VIN = normalizedVin
+
 	w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Msg("Minting VIN")
 
 	// Check if the VIN record exists
@@ -144,6 +153,16 @@ func (w *OnboardingWorker) Work(ctx context.Context, job *river.Job[OnboardingAr
 		return fmt.Errorf("insufficient verification status")
 	}
 
+	// connecting would unpause telemetry without the owner's resume
+	if IsPausePhase(record.OnboardingStatus) {
+		return river.JobCancel(fmt.Errorf("VIN is paused, it can only be resumed"))
+	}
+
+	tr, err := w.chains.ForRecord(record)
+	if err != nil {
+		return river.JobCancel(err)
+	}
+
 	// Check onboarding status, if successful, just return
 	if record.OnboardingStatus == OnboardingStatusMintSuccess && record.ConnectionStatus.String != "failed" {
 		return nil
@@ -158,7 +177,7 @@ func (w *OnboardingWorker) Work(ctx context.Context, job *river.Job[OnboardingAr
 	// If there's no Vehicle Token ID, mint all
 	if !record.VehicleTokenID.Valid {
 		w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Msg("No Vehicle Token ID, minting all")
-		record, err = w.MintVehicleWithSDAndUpdate(ctx, record, job.Args)
+		record, err = w.MintVehicleWithSDAndUpdate(ctx, tr, record, job.Args)
 		if err != nil {
 			return err
 		}
@@ -167,7 +186,7 @@ func (w *OnboardingWorker) Work(ctx context.Context, job *river.Job[OnboardingAr
 	// If only SD token ID is missing, mint SD
 	if !record.SyntheticTokenID.Valid {
 		w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Msg("No Synthetic Device Token ID, minting SD")
-		_, err = w.MintSDAndUpdate(ctx, record, job.Args)
+		_, err = w.MintSDAndUpdate(ctx, tr, record, job.Args)
 		if err != nil {
 			return err
 		}
@@ -192,32 +211,71 @@ func (w *OnboardingWorker) GetVinRecord(ctx context.Context, vinToSearch string)
 	return vin, nil
 }
 
-func (w *OnboardingWorker) MintVehicleWithSDAndUpdate(ctx context.Context, record *dbmodels.Vin, args OnboardingArgs) (*dbmodels.Vin, error) {
+func (w *OnboardingWorker) MintVehicleWithSDAndUpdate(ctx context.Context, tr *transactions.Client, record *dbmodels.Vin, args OnboardingArgs) (*dbmodels.Vin, error) {
 	// make sure we save status update (and possible new DD)
 	defer (func() {
-		_ = w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus, dbmodels.VinColumns.VehicleTokenID, dbmodels.VinColumns.SyntheticTokenID, dbmodels.VinColumns.WalletIndex))
+		_ = w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus, dbmodels.VinColumns.VehicleTokenID, dbmodels.VinColumns.SyntheticTokenID, dbmodels.VinColumns.WalletIndex, dbmodels.VinColumns.OwnerAddress))
 	})()
 
 	w.logger.Debug().Str(logfields.VIN, args.VIN).Msg("Minting Vehicle with SD")
 
-	deviceDefinition, err := w.identity.FetchDeviceDefinitionByID(record.DeviceDefinitionID.String)
+	record.OnboardingStatus = OnboardingStatusMintPending
+	if err := w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus)); err != nil {
+		return nil, err
+	}
+
+	w.m.Lock()
+	opResult, err := newUserOperations(w.logger, w.dbs, tr).sendOwn(ctx, args.VIN, userOperationStepMintVehicle, func() ([]byte, error) {
+		return w.mintVehicleWithSDCallData(ctx, tr, record, args)
+	})
+	w.m.Unlock()
 	if err != nil {
-		w.logger.Error().Err(err).Msg("Failed to fetch device definition")
-		record.OnboardingStatus = OnboardingStatusMintFailure
+		w.logger.Error().Err(err).Msg("Failed to mint vehicle and SD")
+		record.OnboardingStatus, err = chainStepFailure(err, OnboardingStatusMintPending, OnboardingStatusMintFailure)
 		return nil, err
 	}
 
-	sdIndex, err := w.GetNextSDWalletIndex(ctx)
+	result, err := tr.GetMintVehicleAndSDWithDDResult(opResult)
 	if err != nil {
-		w.logger.Error().Err(err).Msg("Failed to get next SD wallet index")
+		// the operation was mined, minting again would create a second vehicle
+		w.logger.Error().Err(err).Msg("Failed to get mint vehicle and SD result")
 		record.OnboardingStatus = OnboardingStatusMintFailure
+		return nil, river.JobCancel(err)
+	}
+
+	record.VehicleTokenID = null.Int64From(result.VehicleId.Int64())
+	record.OwnerAddress = null.StringFrom(args.Owner.Hex())
+	record.SyntheticTokenID = null.Int64From(result.SyntheticDeviceNode.Int64())
+	record.OnboardingStatus = OnboardingStatusMintSuccess
+
+	if args.Sacd != nil {
+		if err := SaveSacdGrant(ctx, w.dbs.DBS().Writer, args.VIN, *args.Sacd, SacdStatusGranted, nil); err != nil {
+			w.logger.Error().Err(err).Str(logfields.VIN, args.VIN).Msg("Failed to save SACD grant")
+		}
+	}
+
+	w.logger.Debug().Str(logfields.VIN, args.VIN).Int64(logfields.VehicleTokenID, record.VehicleTokenID.Int64).Msg("Vehicle minted")
+	w.logger.Debug().Str(logfields.VIN, args.VIN).Int64("syntheticDeviceTokenId", record.SyntheticTokenID.Int64).Msg("SD minted")
+
+	return record, nil
+}
+
+// mintVehicleWithSDCallData builds the registry call minting the vehicle with its SD, and its SACD if requested
+func (w *OnboardingWorker) mintVehicleWithSDCallData(ctx context.Context, tr *transactions.Client, record *dbmodels.Vin, args OnboardingArgs) ([]byte, error) {
+	deviceDefinition, err := w.identity.GetDeviceDefinitionByID(ctx, record.DeviceDefinitionID.String)
+	if err != nil {
+		w.logger.Error().Err(err).Msg("Failed to fetch device definition")
 		return nil, err
 	}
 
-	sdAddress, err := w.ws.GetAddress(sdIndex.NextVal)
+	sdIndex, err := w.sdWalletIndex(ctx, record, args)
+	if err != nil {
+		return nil, err
+	}
+
+	sdAddress, err := w.ws.GetAddress(sdIndex)
 	if err != nil {
 		w.logger.Error().Err(err).Msg("Failed to get SD wallet address")
-		record.OnboardingStatus = OnboardingStatusMintFailure
 		return nil, err
 	}
 
@@ -227,22 +285,20 @@ func (w *OnboardingWorker) MintVehicleWithSDAndUpdate(ctx context.Context, recor
 	ok := false
 	if w.settings.EnableMintingWithConnectionTokenID {
 		integrationOrConnectionID, ok = new(big.Int).SetString(w.settings.ConnectionTokenID, 10)
-		sdTypedData = w.tr.GetMintVehicleAndSDTypedDataV2(integrationOrConnectionID)
+		sdTypedData = tr.GetMintVehicleAndSDTypedDataV2(integrationOrConnectionID)
 	} else {
 		integrationOrConnectionID, ok = new(big.Int).SetString(w.settings.IntegrationTokenID, 10)
-		sdTypedData = w.tr.GetMintVehicleAndSDTypedData(integrationOrConnectionID)
+		sdTypedData = tr.GetMintVehicleAndSDTypedData(integrationOrConnectionID)
 	}
 
 	if !ok {
-		w.logger.Error().Err(err).Msg("Failed to set integration or connection token ID")
-		record.OnboardingStatus = OnboardingStatusMintFailure
-		return nil, err
+		w.logger.Error().Msg("Failed to set integration or connection token ID")
+		return nil, errors.New("invalid integration or connection token ID")
 	}
 
-	sdSignature, err := w.ws.SignTypedData(*sdTypedData, sdIndex.NextVal)
+	sdSignature, err := w.ws.SignTypedData(*sdTypedData, sdIndex)
 	if err != nil {
 		w.logger.Error().Err(err).Msg("Failed to sign SD typed data")
-		record.OnboardingStatus = OnboardingStatusMintFailure
 		return nil, err
 	}
 
@@ -274,54 +330,22 @@ func (w *OnboardingWorker) MintVehicleWithSDAndUpdate(ctx context.Context, recor
 		Interface("mintInput", mintInput).Msg("Minting Vehicle with SD Input")
 
 	if args.Sacd == nil {
-		w.m.Lock()
-		_, result, err := w.tr.MintVehicleAndSDWithDD(&mintInput, true, true)
-		if err != nil {
-			w.m.Unlock()
-			w.logger.Error().Err(err).Msg("Failed to mint vehicle and SD")
-			record.OnboardingStatus = OnboardingStatusMintFailure
-			return nil, err
-		}
-		w.m.Unlock()
-
-		record.WalletIndex = null.Int64From(int64(sdIndex.NextVal))
-		record.VehicleTokenID = null.Int64From(result.VehicleId.Int64())
-		record.SyntheticTokenID = null.Int64From(result.SyntheticDeviceNode.Int64())
-		record.OnboardingStatus = OnboardingStatusMintSuccess
-	} else {
-		sacdInput := registry.SacdInput{
-			Grantee:     args.Sacd.Grantee,
-			Permissions: args.Sacd.Permissions,
-			Expiration:  args.Sacd.Expiration,
-		}
-
-		w.logger.Debug().Str(logfields.VIN, args.VIN).Str(logfields.FunctionName, "MintVehicleWithSDAndUpdate").
-			Interface("sacd", sacdInput).Msg("SACD provided")
-
-		w.m.Lock()
-
-		_, result, err := w.tr.MintVehicleAndSDWithDDAndSACD(&mintInput, sacdInput, true, true)
-		if err != nil {
-			w.m.Unlock()
-			w.logger.Error().Err(err).Msg("Failed to mint vehicle and SD and SACD")
-			record.OnboardingStatus = OnboardingStatusMintFailure
-			return nil, err
-		}
-		w.m.Unlock()
+		return tr.Registry.PackMintVehicleAndSdWithDeviceDefinitionSign(mintInput), nil
+	}
 
-		record.WalletIndex = null.Int64From(int64(sdIndex.NextVal))
-		record.VehicleTokenID = null.Int64From(result.VehicleId.Int64())
-		record.SyntheticTokenID = null.Int64From(result.SyntheticDeviceNode.Int64())
-		record.OnboardingStatus = OnboardingStatusMintSuccess
+	sacdInput := registry.SacdInput{
+		Grantee:     args.Sacd.Grantee,
+		Permissions: args.Sacd.Permissions,
+		Expiration:  args.Sacd.Expiration,
 	}
 
-	w.logger.Debug().Str(logfields.VIN, args.VIN).Int64(logfields.VehicleTokenID, record.VehicleTokenID.Int64).Msg("Vehicle minted")
-	w.logger.Debug().Str(logfields.VIN, args.VIN).Int64("syntheticDeviceTokenId", record.SyntheticTokenID.Int64).Msg("SD minted")
+	w.logger.Debug().Str(logfields.VIN, args.VIN).Str(logfields.FunctionName, "MintVehicleWithSDAndUpdate").
+		Interface("sacd", sacdInput).Msg("SACD provided")
 
-	return record, nil
+	return tr.Registry.PackMintVehicleAndSdWithDeviceDefinitionSignAndSacd(mintInput, sacdInput), nil
 }
 
-func (w *OnboardingWorker) MintSDAndUpdate(ctx context.Context, record *dbmodels.Vin, args OnboardingArgs) (vinRecord *dbmodels.Vin, err error) {
+func (w *OnboardingWorker) MintSDAndUpdate(ctx context.Context, tr *transactions.Client, record *dbmodels.Vin, args OnboardingArgs) (vinRecord *dbmodels.Vin, err error) {
 	// make sure we save status update (and possible new DD)
 	defer (func() {
 		_ = w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus, dbmodels.VinColumns.SyntheticTokenID, dbmodels.VinColumns.WalletIndex))
@@ -329,17 +353,56 @@ func (w *OnboardingWorker) MintSDAndUpdate(ctx context.Context, record *dbmodels
 
 	w.logger.Debug().Str(logfields.VIN, args.VIN).Msg("Minting SD")
 
-	sdIndex, err := w.GetNextSDWalletIndex(ctx)
+	record.OnboardingStatus = OnboardingStatusMintPending
+	if err := w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.OnboardingStatus)); err != nil {
+		return nil, err
+	}
+
+	w.m.Lock()
+	opResult, err := newUserOperations(w.logger, w.dbs, tr).sendOwn(ctx, args.VIN, userOperationStepMintSD, func() ([]byte, error) {
+		return w.mintSDCallData(ctx, tr, record, args)
+	})
+	w.m.Unlock()
 	if err != nil {
-		w.logger.Error().Err(err).Msg("Failed to get next SD wallet index")
+		w.logger.Error().Err(err).Msg("Failed to mint SD")
+		record.OnboardingStatus, err = chainStepFailure(err, OnboardingStatusMintPending, OnboardingStatusMintFailure)
+		return nil, err
+	}
+
+	result, err := tr.GetMintSDResult(opResult)
+	if err != nil {
+		// the operation was mined, minting again would create a second SD
+		w.logger.Error().Err(err).Msg("Failed to get mint SD result")
 		record.OnboardingStatus = OnboardingStatusMintFailure
+		return nil, river.JobCancel(err)
+	}
+
+	record.SyntheticTokenID = null.Int64From(result.SyntheticDeviceNode.Int64())
+	record.OnboardingStatus = OnboardingStatusMintSuccess
+
+	w.logger.Debug().Str(logfields.VIN, args.VIN).Int64("syntheticDeviceTokenId", record.SyntheticTokenID.Int64).Msg("SD minted")
+
+	// The vehicle already exists, so SACD can't be set in the mint transaction and only the owner can set it afterward.
+	// Keep the requested grant so the owner can sign it through the SACD endpoints.
+	if args.Sacd != nil {
+		if err := SaveSacdGrant(ctx, w.dbs.DBS().Writer, args.VIN, *args.Sacd, SacdStatusSignatureRequired, nil); err != nil {
+			w.logger.Error().Err(err).Str(logfields.VIN, args.VIN).Msg("Failed to save requested SACD grant")
+		}
+	}
+
+	return record, nil
+}
+
+// mintSDCallData builds the registry call minting an SD for the existing vehicle
+func (w *OnboardingWorker) mintSDCallData(ctx context.Context, tr *transactions.Client, record *dbmodels.Vin, args OnboardingArgs) ([]byte, error) {
+	sdIndex, err := w.sdWalletIndex(ctx, record, args)
+	if err != nil {
 		return nil, err
 	}
 
-	sdAddress, err := w.ws.GetAddress(sdIndex.NextVal)
+	sdAddress, err := w.ws.GetAddress(sdIndex)
 	if err != nil {
 		w.logger.Error().Err(err).Msg("Failed to get SD wallet address")
-		record.OnboardingStatus = OnboardingStatusMintFailure
 		return nil, err
 	}
 
@@ -349,22 +412,20 @@ func (w *OnboardingWorker) MintSDAndUpdate(ctx context.Context, record *dbmodels
 	ok := false
 	if w.settings.EnableMintingWithConnectionTokenID {
 		integrationOrConnectionID, ok = new(big.Int).SetString(w.settings.ConnectionTokenID, 10)
-		sdTypedData = w.tr.GetMintSDTypedDataV2(integrationOrConnectionID, big.NewInt(record.VehicleTokenID.Int64))
+		sdTypedData = tr.GetMintSDTypedDataV2(integrationOrConnectionID, big.NewInt(record.VehicleTokenID.Int64))
 	} else {
 		integrationOrConnectionID, ok = new(big.Int).SetString(w.settings.IntegrationTokenID, 10)
-		sdTypedData = w.tr.GetMintSDTypedData(integrationOrConnectionID, big.NewInt(record.VehicleTokenID.Int64))
+		sdTypedData = tr.GetMintSDTypedData(integrationOrConnectionID, big.NewInt(record.VehicleTokenID.Int64))
 	}
 
 	if !ok {
-		w.logger.Error().Err(err).Msg("Failed to set integration or connection token ID")
-		record.OnboardingStatus = OnboardingStatusMintFailure
-		return nil, err
+		w.logger.Error().Msg("Failed to set integration or connection token ID")
+		return nil, errors.New("invalid integration or connection token ID")
 	}
 
-	sdSignature, err := w.ws.SignTypedData(*sdTypedData, sdIndex.NextVal)
+	sdSignature, err := w.ws.SignTypedData(*sdTypedData, sdIndex)
 	if err != nil {
 		w.logger.Error().Err(err).Msg("Failed to sign SD typed data")
-		record.OnboardingStatus = OnboardingStatusMintFailure
 		return nil, err
 	}
 
@@ -377,23 +438,28 @@ func (w *OnboardingWorker) MintSDAndUpdate(ctx context.Context, record *dbmodels
 		VehicleNode:         big.NewInt(record.VehicleTokenID.Int64),
 	}
 
-	w.m.Lock()
-	_, result, err := w.tr.MintSD(&mintInput, true, true)
+	return tr.Registry.PackMintSyntheticDeviceSign(mintInput), nil
+}
+
+// sdWalletIndex returns the SD wallet index reserved for the record, reserving the next one if no attempt did yet.
+// Retries reuse the index, so an operation resumed after a retry and the record agree on the SD wallet.
+func (w *OnboardingWorker) sdWalletIndex(ctx context.Context, record *dbmodels.Vin, args OnboardingArgs) (uint32, error) {
+	if record.WalletIndex.Valid {
+		return uint32(record.WalletIndex.Int64), nil
+	}
+
+	sdIndex, err := w.GetNextSDWalletIndex(ctx)
 	if err != nil {
-		w.m.Unlock()
-		w.logger.Error().Err(err).Msg("Failed to mint SD")
-		record.OnboardingStatus = OnboardingStatusMintFailure
-		return nil, err
+		w.logger.Error().Err(err).Msg("Failed to get next SD wallet index")
+		return 0, err
 	}
-	w.m.Unlock()
 
 	record.WalletIndex = null.Int64From(int64(sdIndex.NextVal))
-	record.SyntheticTokenID = null.Int64From(result.SyntheticDeviceNode.Int64())
-	record.OnboardingStatus = OnboardingStatusMintSuccess
-
-	w.logger.Debug().Str(logfields.VIN, args.VIN).Int64("syntheticDeviceTokenId", record.SyntheticTokenID.Int64).Msg("SD minted")
+	if err := w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.WalletIndex)); err != nil {
+		return 0, err
+	}
 
-	return record, nil
+	return sdIndex.NextVal, nil
 }
 
 func (w *OnboardingWorker) ConnectToVendorAndUpdate(ctx context.Context, record *dbmodels.Vin, args OnboardingArgs) (*dbmodels.Vin, error) {
@@ -404,7 +470,7 @@ func (w *OnboardingWorker) ConnectToVendorAndUpdate(ctx context.Context, record
 
 	record.OnboardingStatus = OnboardingStatusConnectUnknown
 
-	if w.settings.EnableVendorConnection {
+	if w.switches.Connection() {
 		connection, err := w.vendor.Connect([]string{args.VIN})
 		if err != nil {
 			w.logger.Error().Err(err).Msg("Failed to connect to vendor")
@@ -414,7 +480,8 @@ func (w *OnboardingWorker) ConnectToVendorAndUpdate(ctx context.Context, record
 
 		record.ConnectionStatus = null.String{String: "succeeded", Valid: true}
 		record.DisconnectionStatus = null.String{String: "", Valid: false}
-		err = w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.ConnectionStatus, dbmodels.VinColumns.DisconnectionStatus))
+		record.TelemetryPaused = false
+		err = w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.ConnectionStatus, dbmodels.VinColumns.DisconnectionStatus, dbmodels.VinColumns.TelemetryPaused))
 		if err != nil {
 			w.logger.Error().Err(err).Msg("Failed to update connection status")
 			record.OnboardingStatus = OnboardingStatusConnectFailure
@@ -426,7 +493,8 @@ func (w *OnboardingWorker) ConnectToVendorAndUpdate(ctx context.Context, record
 		w.logger.Debug().Str(logfields.VIN, args.VIN).Msg("Vendor connection is disabled, skipping")
 		record.ConnectionStatus = null.String{String: "succeeded", Valid: true}
 		record.DisconnectionStatus = null.String{String: "", Valid: false}
-		err := w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.ConnectionStatus, dbmodels.VinColumns.DisconnectionStatus))
+		record.TelemetryPaused = false
+		err := w.update(ctx, record, args, boil.Whitelist(dbmodels.VinColumns.ConnectionStatus, dbmodels.VinColumns.DisconnectionStatus, dbmodels.VinColumns.TelemetryPaused))
 		if err != nil {
 			w.logger.Error().Err(err).Msg("Failed to update connection status")
 			record.OnboardingStatus = OnboardingStatusConnectFailure
@@ -0,0 +1,217 @@
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/riverqueue/river"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"time"
+)
+
+// PauseArgs pauses (or resumes) telemetry of a VIN without touching the on-chain identity
+type PauseArgs struct {
+	VIN    string `json:"vin"`
+	Resume bool   `json:"resume"`
+}
+
+func (a PauseArgs) Kind() string {
+	return "pause"
+}
+func (a PauseArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue:       QueueVendor,
+		MaxAttempts: 1,
+		UniqueOpts: river.UniqueOpts{
+			ByArgs: false,
+		},
+	}
+}
+
+type PauseWorker struct {
+	settings *config.Settings
+	logger   zerolog.Logger
+	dbs      *db.Store
+	vendor   VendorOnboardingAPI
+	switches *VendorSwitches
+
+	river.WorkerDefaults[PauseArgs]
+}
+
+func NewPauseWorker(settings *config.Settings, logger zerolog.Logger, dbs *db.Store, vendor VendorOnboardingAPI, switches *VendorSwitches) *PauseWorker {
+	return &PauseWorker{
+		settings: settings,
+		logger:   logger,
+		dbs:      dbs,
+		vendor:   vendor,
+		switches: switches,
+	}
+}
+
+func (w *PauseWorker) Timeout(*river.Job[PauseArgs]) time.Duration { return 5 * time.Minute }
+
+func (w *PauseWorker) Work(ctx context.Context, job *river.Job[PauseArgs]) error {
+	normalizedVin, err := normalizeJobVin(job.Args.VIN)
+	if err != nil {
+		return err
+	}
+	job.Args.VIN = normalizedVin
+
+	operation := VinHistoryEventPause
+	if job.Args.Resume {
+		operation = VinHistoryEventResume
+	}
+
+	w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Str("operation", operation).Msg("Pausing VIN")
+
+	record, err := dbmodels.Vins(dbmodels.VinWhere.Vin.EQ(job.Args.VIN)).One(ctx, w.dbs.DBS().Reader)
+	if err != nil {
+		return err
+	}
+
+	if job.Args.Resume {
+		err = w.resume(ctx, record)
+	} else {
+		err = w.pause(ctx, record)
+	}
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	pauseOperationsCntr.WithLabelValues(operation, result).Inc()
+
+	return err
+}
+
+func (w *PauseWorker) pause(ctx context.Context, record *dbmodels.Vin) error {
+	if IsPaused(record.OnboardingStatus) {
+		return nil
+	}
+
+	if record.OnboardingStatus != OnboardingStatusPauseUnknown && record.OnboardingStatus != OnboardingStatusPausePending && !CanPause(record.OnboardingStatus) {
+		return fmt.Errorf("VIN can't be paused in status %s", GetDetailedStatus(record.OnboardingStatus))
+	}
+
+	if w.switches.Connection() {
+		connection, err := w.vendor.Suspend([]string{record.Vin})
+		if err != nil {
+			w.logger.Error().Err(err).Str(logfields.VIN, record.Vin).Msg("Failed to suspend vendor connection")
+			record.OnboardingStatus = OnboardingStatusPauseFailure
+			_ = w.update(ctx, record, "")
+			return err
+		}
+
+		w.logger.Debug().Str(logfields.VIN, record.Vin).Interface("suspend-result", connection).Msg("Vendor connection suspended")
+	} else {
+		w.logger.Debug().Str(logfields.VIN, record.Vin).Msg("Vendor connection is disabled, skipping")
+	}
+
+	record.TelemetryPaused = true
+	record.OnboardingStatus = OnboardingStatusPauseSuccess
+
+	return w.update(ctx, record, VinHistoryEventPause)
+}
+
+func (w *PauseWorker) resume(ctx context.Context, record *dbmodels.Vin) error {
+	if IsMinted(record.OnboardingStatus) {
+		return nil
+	}
+
+	if record.OnboardingStatus != OnboardingStatusResumeUnknown && record.OnboardingStatus != OnboardingStatusResumePending && !CanResume(record.OnboardingStatus) {
+		return fmt.Errorf("VIN can't be resumed in status %s", GetDetailedStatus(record.OnboardingStatus))
+	}
+
+	// the disconnect transfer policy disconnected the VIN in the vendor system, resuming it connects it again
+	reconnect := record.DisconnectionStatus.String == "succeeded"
+
+	if w.switches.Connection() {
+		resumeVendor := w.vendor.Resume
+		if reconnect {
+			resumeVendor = w.vendor.Connect
+		}
+
+		connection, err := resumeVendor([]string{record.Vin})
+		if err != nil {
+			w.logger.Error().Err(err).Str(logfields.VIN, record.Vin).Bool("reconnect", reconnect).Msg("Failed to resume vendor connection")
+			record.OnboardingStatus = OnboardingStatusResumeFailure
+			_ = w.update(ctx, record, "")
+			return err
+		}
+
+		w.logger.Debug().Str(logfields.VIN, record.Vin).Bool("reconnect", reconnect).Interface("resume-result", connection).Msg("Vendor connection resumed")
+	} else {
+		w.logger.Debug().Str(logfields.VIN, record.Vin).Msg("Vendor connection is disabled, skipping")
+	}
+
+	if reconnect {
+		record.ConnectionStatus = null.StringFrom("succeeded")
+		record.DisconnectionStatus = null.String{}
+	}
+	record.TelemetryPaused = false
+	record.OnboardingStatus = OnboardingStatusMintSuccess
+
+	return w.update(ctx, record, VinHistoryEventResume)
+}
+
+// update saves the pause state and records the event in VIN history, if any
+func (w *PauseWorker) update(ctx context.Context, record *dbmodels.Vin, event string) error {
+	tx, err := w.dbs.DBS().Writer.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		w.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				w.logger.Error().Err(rbErr).Msg("Failed to rollback transaction")
+			}
+		}
+	}()
+
+	columns := boil.Whitelist(
+		dbmodels.VinColumns.OnboardingStatus,
+		dbmodels.VinColumns.TelemetryPaused,
+		dbmodels.VinColumns.ConnectionStatus,
+		dbmodels.VinColumns.DisconnectionStatus,
+	)
+	if _, err = record.Update(ctx, tx, columns); err != nil {
+		return fmt.Errorf("failed to update VIN record: %w", err)
+	}
+
+	if event != "" {
+		history := dbmodels.VinHistory{
+			Vin:           record.Vin,
+			Event:         event,
+			PreviousOwner: record.OwnerAddress,
+			NewOwner:      record.OwnerAddress,
+			Details:       null.StringFrom("status: " + GetDetailedStatus(record.OnboardingStatus)),
+		}
+		if err = history.Insert(ctx, tx, boil.Infer()); err != nil {
+			return fmt.Errorf("failed to insert VIN history: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		w.logger.Error().Err(err).Msg("Failed to commit transaction")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	w.logger.Debug().Str(logfields.VIN, record.Vin).Msg("VIN record updated")
+
+	return nil
+}
+
+// Prometheus metrics
+var pauseOperationsCntr = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "oracle_example_pause_operations_total",
+	Help: "Total number of telemetry pause and resume operations, by operation and result",
+}, []string{"operation", "result"})
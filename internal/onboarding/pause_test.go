@@ -0,0 +1,227 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/test"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/riverqueue/river"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"testing"
+)
+
+type PauseWorkerTestSuite struct {
+	suite.Suite
+	pdb       db.Store
+	container testcontainers.Container
+	ctx       context.Context
+	settings  config.Settings
+	vendor    *fakeVendor
+	worker    *PauseWorker
+}
+
+func TestPauseWorkerTestSuite(t *testing.T) {
+	suite.Run(t, new(PauseWorkerTestSuite))
+}
+
+// SetupSuite starts container db
+func (s *PauseWorkerTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container, s.settings = test.StartContainerDatabase(s.ctx, s.T(), migrationsDirRelPath)
+}
+
+func (s *PauseWorkerTestSuite) SetupTest() {
+	s.vendor = newFakeVendor()
+	s.worker = NewPauseWorker(&s.settings, zerolog.Nop(), &s.pdb, s.vendor, vendorSwitches(true))
+}
+
+// TearDownTest after each test truncate tables
+func (s *PauseWorkerTestSuite) TearDownTest() {
+	test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+// TearDownSuite cleanup at end by terminating container
+func (s *PauseWorkerTestSuite) TearDownSuite() {
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+// insert stores a minted VIN, the second VIN of a test gets its own tokens
+func (s *PauseWorkerTestSuite) insert(second bool, status int, paused bool) *dbmodels.Vin {
+	record := minted()
+	if second {
+		record.Vin = "1HGCM82633A004353"
+		record.VehicleTokenID = null.Int64From(11)
+		record.SyntheticTokenID = null.Int64From(21)
+	}
+	record.OnboardingStatus = status
+	record.TelemetryPaused = paused
+	s.Require().NoError(record.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	return record
+}
+
+func (s *PauseWorkerTestSuite) work(vin string, resume bool) error {
+	return s.worker.Work(s.ctx, &river.Job[PauseArgs]{Args: PauseArgs{VIN: vin, Resume: resume}})
+}
+
+func (s *PauseWorkerTestSuite) load(vin string) (*dbmodels.Vin, dbmodels.VinHistorySlice) {
+	record, err := dbmodels.FindVin(s.ctx, s.pdb.DBS().Reader, vin)
+	s.Require().NoError(err)
+	history, err := dbmodels.VinHistories(dbmodels.VinHistoryWhere.Vin.EQ(vin)).All(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+
+	return record, history
+}
+
+func (s *PauseWorkerTestSuite) TestPauseAndResume() {
+	record := s.insert(false, OnboardingStatusPausePending, false)
+
+	s.Require().NoError(s.work(record.Vin, false))
+	paused, history := s.load(record.Vin)
+	s.Equal(OnboardingStatusPauseSuccess, paused.OnboardingStatus)
+	s.True(paused.TelemetryPaused)
+	s.Equal([]string{record.Vin}, s.vendor.calls["suspend"])
+	s.Require().Len(history, 1)
+	s.Equal(VinHistoryEventPause, history[0].Event)
+
+	// pausing again is a no-op
+	s.Require().NoError(s.work(record.Vin, false))
+	s.Len(s.vendor.calls["suspend"], 1)
+
+	s.Require().NoError(s.work(record.Vin, true))
+	resumed, history := s.load(record.Vin)
+	s.Equal(OnboardingStatusMintSuccess, resumed.OnboardingStatus)
+	s.False(resumed.TelemetryPaused)
+	s.Equal([]string{record.Vin}, s.vendor.calls["resume"])
+	s.Len(history, 2)
+}
+
+func (s *PauseWorkerTestSuite) TestResumeReconnectsDisconnectedVin() {
+	settings := s.settings
+	settings.TransferPolicy = TransferPolicyDisconnect
+	handler := NewTransferHandler(&settings, zerolog.Nop(), &s.pdb, s.vendor, vendorSwitches(true))
+
+	record := s.insert(false, OnboardingStatusMintSuccess, false)
+	s.Require().NoError(handler.HandleTransfer(s.ctx, record, common.HexToAddress(testNewOwner)))
+
+	// the new owner consents to the connection
+	s.Require().NoError(s.work(record.Vin, true))
+	s.Equal([]string{record.Vin}, s.vendor.calls["disconnect"])
+	s.Equal([]string{record.Vin}, s.vendor.calls["connect"])
+	s.Empty(s.vendor.calls["resume"])
+
+	resumed, history := s.load(record.Vin)
+	s.Equal(OnboardingStatusMintSuccess, resumed.OnboardingStatus)
+	s.False(resumed.TelemetryPaused)
+	s.Equal("succeeded", resumed.ConnectionStatus.String)
+	s.False(resumed.DisconnectionStatus.Valid)
+	events := make([]string, 0, len(history))
+	for _, event := range history {
+		events = append(events, event.Event)
+	}
+	s.ElementsMatch([]string{VinHistoryEventTransfer, VinHistoryEventResume}, events)
+
+	// paused VINs are still only resumed
+	paused := s.insert(true, OnboardingStatusPauseSuccess, true)
+	s.Require().NoError(s.work(paused.Vin, true))
+	s.Equal([]string{paused.Vin}, s.vendor.calls["resume"])
+	s.Equal([]string{record.Vin}, s.vendor.calls["connect"])
+}
+
+func (s *PauseWorkerTestSuite) TestReconnectFailure() {
+	record := s.insert(false, OnboardingStatusPauseSuccess, true)
+	record.DisconnectionStatus = null.StringFrom("succeeded")
+	_, err := record.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(dbmodels.VinColumns.DisconnectionStatus))
+	s.Require().NoError(err)
+	s.vendor.err = errors.New("vendor down")
+
+	s.Error(s.work(record.Vin, true))
+	failed, _ := s.load(record.Vin)
+	s.Equal(OnboardingStatusResumeFailure, failed.OnboardingStatus)
+	s.True(failed.TelemetryPaused)
+	s.Equal("succeeded", failed.DisconnectionStatus.String)
+	s.Equal([]string{record.Vin}, s.vendor.calls["connect"])
+}
+
+func (s *PauseWorkerTestSuite) TestVendorFailure() {
+	s.vendor.err = errors.New("vendor down")
+	record := s.insert(false, OnboardingStatusPausePending, false)
+
+	s.Error(s.work(record.Vin, false))
+	failed, history := s.load(record.Vin)
+	s.Equal(OnboardingStatusPauseFailure, failed.OnboardingStatus)
+	s.False(failed.TelemetryPaused)
+	s.Empty(history)
+
+	// a failed resume leaves the VIN paused
+	record = s.insert(true, OnboardingStatusResumePending, true)
+	s.Error(s.work(record.Vin, true))
+	failed, _ = s.load(record.Vin)
+	s.Equal(OnboardingStatusResumeFailure, failed.OnboardingStatus)
+	s.True(failed.TelemetryPaused)
+	s.True(IsPaused(failed.OnboardingStatus))
+}
+
+func (s *PauseWorkerTestSuite) TestRejectedStatus() {
+	record := s.insert(false, OnboardingStatusBurnSDPending, false)
+
+	s.Error(s.work(record.Vin, false))
+	s.Error(s.work(record.Vin, true))
+	unchanged, history := s.load(record.Vin)
+	s.Equal(OnboardingStatusBurnSDPending, unchanged.OnboardingStatus)
+	s.Empty(history)
+	s.Empty(s.vendor.calls)
+}
+
+func (s *PauseWorkerTestSuite) TestOnboardingRefusesPausedVin() {
+	onboardingWorker := NewOnboardingWorker(&s.settings, zerolog.Nop(), nil, &s.pdb, NewChains(80002, nil), nil, s.vendor, vendorSwitches(true))
+
+	for _, status := range []int{OnboardingStatusPauseFailure, OnboardingStatusPauseSuccess, OnboardingStatusResumeFailure} {
+		record := s.insert(false, status, IsPaused(status))
+
+		err := onboardingWorker.Work(s.ctx, &river.Job[OnboardingArgs]{Args: OnboardingArgs{VIN: record.Vin, Owner: common.HexToAddress(testNewOwner)}})
+		var cancel *river.JobCancelError
+		s.True(errors.As(err, &cancel), "status %d", status)
+
+		unchanged, history := s.load(record.Vin)
+		s.Equal(status, unchanged.OnboardingStatus)
+		s.Equal(IsPaused(status), unchanged.TelemetryPaused)
+		s.Empty(history)
+		s.Empty(s.vendor.calls)
+
+		test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+	}
+}
+
+func (s *PauseWorkerTestSuite) TestTransferredVinCantBeMintedAgain() {
+	for _, policy := range []string{TransferPolicyPause, TransferPolicyDisconnect} {
+		settings := s.settings
+		settings.TransferPolicy = policy
+		handler := NewTransferHandler(&settings, zerolog.Nop(), &s.pdb, s.vendor, vendorSwitches(true))
+		onboardingWorker := NewOnboardingWorker(&settings, zerolog.Nop(), nil, &s.pdb, NewChains(80002, nil), nil, s.vendor, vendorSwitches(true))
+
+		record := s.insert(false, OnboardingStatusMintSuccess, false)
+		s.Require().NoError(handler.HandleTransfer(s.ctx, record, common.HexToAddress(testNewOwner)))
+
+		// the previous owner submits the VIN for minting again
+		err := onboardingWorker.Work(s.ctx, &river.Job[OnboardingArgs]{Args: OnboardingArgs{VIN: record.Vin, Owner: common.HexToAddress(testOwner)}})
+		var cancel *river.JobCancelError
+		s.True(errors.As(err, &cancel), policy)
+
+		paused, _ := s.load(record.Vin)
+		s.Equal(OnboardingStatusPauseSuccess, paused.OnboardingStatus, policy)
+		s.True(paused.TelemetryPaused, policy)
+		s.Empty(s.vendor.calls["connect"], policy)
+
+		test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+	}
+}
@@ -0,0 +1,231 @@
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"golang.org/x/time/rate"
+	"strings"
+	"time"
+)
+
+// Jobs are split in queues so slow work of one kind can't starve the others. Maintenance jobs (reconciliation and
+// stuck job recovery) stay on river.QueueDefault.
+const (
+	QueueVerify = "verify" // VIN decoding, can block up to a minute on the device definition
+	QueueChain  = "chain"  // minting, burning and SACD user operations
+	QueueVendor = "vendor" // pausing and resuming telemetry in the vendor system
+)
+
+const queueMetricsInterval = 15 * time.Second
+
+// QueueSettings are the concurrency, priority and rate limit of a queue
+type QueueSettings struct {
+	MaxWorkers int
+	// Priority of the jobs inserted in the queue without an explicit one, from 1 (highest) to 4
+	Priority int
+	// RateLimitPerMinute caps how many jobs of the queue start per minute on each instance, 0 means unlimited
+	RateLimitPerMinute int
+}
+
+// Queues returns the settings of every queue, with defaults for the settings left empty
+func Queues(settings *config.Settings) map[string]QueueSettings {
+	return map[string]QueueSettings{
+		QueueVerify:        queueSettings(settings.VerifyQueueMaxWorkers, settings.VerifyQueuePriority, settings.VerifyQueueRateLimitPerMinute, 20),
+		QueueChain:         queueSettings(settings.ChainQueueMaxWorkers, settings.ChainQueuePriority, settings.ChainQueueRateLimitPerMinute, 10),
+		QueueVendor:        queueSettings(settings.VendorQueueMaxWorkers, settings.VendorQueuePriority, settings.VendorQueueRateLimitPerMinute, 20),
+		river.QueueDefault: queueSettings(0, 0, 0, 5),
+	}
+}
+
+func queueSettings(maxWorkers, priority, rateLimitPerMinute, defaultMaxWorkers int) QueueSettings {
+	if maxWorkers <= 0 {
+		maxWorkers = defaultMaxWorkers
+	}
+	if priority < 1 || priority > 4 {
+		priority = river.PriorityDefault
+	}
+	if rateLimitPerMinute < 0 {
+		rateLimitPerMinute = 0
+	}
+	return QueueSettings{MaxWorkers: maxWorkers, Priority: priority, RateLimitPerMinute: rateLimitPerMinute}
+}
+
+// RiverQueues returns the river configuration of the queues
+func RiverQueues(settings *config.Settings) map[string]river.QueueConfig {
+	queues := make(map[string]river.QueueConfig)
+	for name, queue := range Queues(settings) {
+		queues[name] = river.QueueConfig{MaxWorkers: queue.MaxWorkers}
+	}
+	return queues
+}
+
+// QueueMiddleware sets the priority of inserted jobs from their queue's settings, rate limits the jobs worked
+// and records how long jobs waited and ran
+type QueueMiddleware struct {
+	river.MiddlewareDefaults
+
+	queues   map[string]QueueSettings
+	limiters map[string]*rate.Limiter
+}
+
+func NewQueueMiddleware(settings *config.Settings) *QueueMiddleware {
+	queues := Queues(settings)
+	limiters := make(map[string]*rate.Limiter)
+	for name, queue := range queues {
+		if queue.RateLimitPerMinute > 0 {
+			limiters[name] = rate.NewLimiter(rate.Limit(float64(queue.RateLimitPerMinute)/60), 1)
+		}
+	}
+
+	return &QueueMiddleware{
+		queues:   queues,
+		limiters: limiters,
+	}
+}
+
+func (m *QueueMiddleware) InsertMany(ctx context.Context, manyParams []*rivertype.JobInsertParams, doInner func(context.Context) ([]*rivertype.JobInsertResult, error)) ([]*rivertype.JobInsertResult, error) {
+	for _, params := range manyParams {
+		// river fills in PriorityDefault for jobs inserted without a priority
+		if queue, ok := m.queues[params.Queue]; ok && params.Priority == river.PriorityDefault {
+			params.Priority = queue.Priority
+		}
+	}
+
+	return doInner(ctx)
+}
+
+func (m *QueueMiddleware) Work(ctx context.Context, job *rivertype.JobRow, doInner func(context.Context) error) error {
+	// a job waiting for the limiter holds one of the queue's workers
+	if limiter, ok := m.limiters[job.Queue]; ok {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	started := time.Now()
+	queueWaitHist.WithLabelValues(job.Queue, job.Kind).Observe(started.Sub(job.ScheduledAt).Seconds())
+
+	err := doInner(ctx)
+
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	jobDurationHist.WithLabelValues(job.Queue, job.Kind, result).Observe(time.Since(started).Seconds())
+
+	return err
+}
+
+// QueueMetrics exports the number of jobs per queue and state
+type QueueMetrics struct {
+	settings *config.Settings
+	logger   zerolog.Logger
+	dbs      *db.Store
+}
+
+func NewQueueMetrics(settings *config.Settings, logger zerolog.Logger, dbs *db.Store) *QueueMetrics {
+	return &QueueMetrics{
+		settings: settings,
+		logger:   logger,
+		dbs:      dbs,
+	}
+}
+
+// queueDepthStates are the states of jobs that wait for, or take, a worker of the queue
+var queueDepthStates = []rivertype.JobState{
+	rivertype.JobStateAvailable,
+	rivertype.JobStateRetryable,
+	rivertype.JobStateRunning,
+	rivertype.JobStateScheduled,
+}
+
+type queueStateCount struct {
+	Queue string `boil:"queue"`
+	State string `boil:"state"`
+	Count int64  `boil:"count"`
+}
+
+// Run updates the queue depth gauge until the context is done
+func (q *QueueMetrics) Run(ctx context.Context) {
+	ticker := time.NewTicker(queueMetricsInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := q.update(ctx); err != nil && ctx.Err() == nil {
+			q.logger.Error().Err(err).Msg("Failed to update queue metrics")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *QueueMetrics) update(ctx context.Context) error {
+	var counts []queueStateCount
+	qry := fmt.Sprintf("SELECT queue, state, count(*) AS count FROM %s.river_job WHERE state IN (%s) GROUP BY queue, state;", q.settings.DB.Name, queueDepthStateList())
+	if err := queries.Raw(qry).Bind(ctx, q.dbs.DBS().Reader, &counts); err != nil {
+		return fmt.Errorf("failed to count river jobs: %w", err)
+	}
+
+	// queues without jobs in a state report 0 instead of keeping their last value
+	depth := make(map[string]map[string]int64)
+	for name := range Queues(q.settings) {
+		depth[name] = make(map[string]int64)
+		for _, state := range queueDepthStates {
+			depth[name][string(state)] = 0
+		}
+	}
+	for _, count := range counts {
+		if depth[count.Queue] == nil {
+			depth[count.Queue] = make(map[string]int64)
+		}
+		depth[count.Queue][count.State] = count.Count
+	}
+
+	for queue, states := range depth {
+		for state, count := range states {
+			queueDepthGauge.WithLabelValues(queue, state).Set(float64(count))
+		}
+	}
+
+	return nil
+}
+
+func queueDepthStateList() string {
+	states := make([]string, 0, len(queueDepthStates))
+	for _, state := range queueDepthStates {
+		states = append(states, "'"+string(state)+"'")
+	}
+	return strings.Join(states, ",")
+}
+
+// Prometheus metrics
+var (
+	queueDepthGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oracle_example_river_queue_jobs",
+		Help: "Number of river jobs waiting for or using a worker, by queue and state",
+	}, []string{"queue", "state"})
+
+	queueWaitHist = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "oracle_example_river_job_wait_seconds",
+		Help:    "Time between a river job being scheduled and a worker starting it, by queue and kind",
+		Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 300, 900, 3600},
+	}, []string{"queue", "kind"})
+
+	jobDurationHist = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "oracle_example_river_job_duration_seconds",
+		Help:    "Time river jobs took to work, by queue, kind and result",
+		Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 300, 900, 1800},
+	}, []string{"queue", "kind", "result"})
+)
@@ -0,0 +1,76 @@
+package onboarding
+
+import (
+	"context"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type QueuesTestSuite struct {
+	suite.Suite
+}
+
+func TestQueuesTestSuite(t *testing.T) {
+	suite.Run(t, new(QueuesTestSuite))
+}
+
+func (s *QueuesTestSuite) TestQueueSettingsDefaults() {
+	tests := []struct {
+		name                              string
+		maxWorkers, priority, rateLimit   int
+		expectedWorkers, expectedPriority int
+		expectedRateLimit                 int
+	}{
+		{"empty", 0, 0, 0, 20, river.PriorityDefault, 0},
+		{"set", 5, 3, 60, 5, 3, 60},
+		{"negative", -1, -1, -1, 20, river.PriorityDefault, 0},
+		{"priority out of range", 5, 5, 0, 5, river.PriorityDefault, 0},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			queue := queueSettings(tt.maxWorkers, tt.priority, tt.rateLimit, 20)
+			s.Equal(QueueSettings{MaxWorkers: tt.expectedWorkers, Priority: tt.expectedPriority, RateLimitPerMinute: tt.expectedRateLimit}, queue)
+		})
+	}
+}
+
+func (s *QueuesTestSuite) TestQueues() {
+	queues := Queues(&config.Settings{ChainQueueMaxWorkers: 3, VendorQueueRateLimitPerMinute: 30})
+
+	s.Equal(20, queues[QueueVerify].MaxWorkers)
+	s.Equal(3, queues[QueueChain].MaxWorkers)
+	s.Equal(30, queues[QueueVendor].RateLimitPerMinute)
+	s.Equal(5, queues[river.QueueDefault].MaxWorkers)
+
+	riverQueues := RiverQueues(&config.Settings{ChainQueueMaxWorkers: 3})
+	s.Len(riverQueues, len(queues))
+	s.Equal(3, riverQueues[QueueChain].MaxWorkers)
+}
+
+func (s *QueuesTestSuite) TestInsertManyPriority() {
+	middleware := NewQueueMiddleware(&config.Settings{ChainQueuePriority: 2, VerifyQueuePriority: 4})
+	params := []*rivertype.JobInsertParams{
+		{Queue: QueueChain, Priority: river.PriorityDefault},
+		{Queue: QueueVerify, Priority: 3},
+		{Queue: QueueVendor, Priority: river.PriorityDefault},
+		{Queue: "unknown", Priority: river.PriorityDefault},
+	}
+
+	called := false
+	_, err := middleware.InsertMany(context.Background(), params, func(context.Context) ([]*rivertype.JobInsertResult, error) {
+		called = true
+		return nil, nil
+	})
+	s.Require().NoError(err)
+	s.True(called)
+
+	// jobs without an explicit priority get their queue's, explicit ones and unknown queues are left alone
+	s.Equal(2, params[0].Priority)
+	s.Equal(3, params[1].Priority)
+	s.Equal(river.PriorityDefault, params[2].Priority)
+	s.Equal(river.PriorityDefault, params[3].Priority)
+}
@@ -0,0 +1,388 @@
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/friendsofgo/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/riverqueue/river"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"strconv"
+	"time"
+)
+
+const (
+	// DriftKindVehicleBurned the vehicle token no longer exists on chain
+	DriftKindVehicleBurned = "vehicle_burned"
+	// DriftKindOwner the vehicle has a different owner on chain
+	DriftKindOwner = "owner"
+	// DriftKindSyntheticDevice the synthetic device on chain differs (burned outside the oracle or re-minted)
+	DriftKindSyntheticDevice = "synthetic_device"
+	// DriftKindDefinition the device definition on chain differs
+	DriftKindDefinition = "definition"
+)
+
+var DriftKinds = []string{DriftKindVehicleBurned, DriftKindOwner, DriftKindSyntheticDevice, DriftKindDefinition}
+
+const (
+	defaultReconciliationInterval = 60 * time.Minute
+	defaultReconciliationPageSize = 100
+)
+
+type ReconcileArgs struct{}
+
+func (a ReconcileArgs) Kind() string {
+	return "reconcile"
+}
+func (a ReconcileArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		MaxAttempts: 1,
+		UniqueOpts: river.UniqueOpts{
+			ByArgs: false,
+		},
+	}
+}
+
+// ReconciliationInterval returns how often the reconciliation job should be scheduled
+func ReconciliationInterval(settings *config.Settings) time.Duration {
+	if settings.ReconciliationIntervalMinutes <= 0 {
+		return defaultReconciliationInterval
+	}
+	return time.Duration(settings.ReconciliationIntervalMinutes) * time.Minute
+}
+
+// Drift is a single mismatch between the vins table and on-chain state
+type Drift struct {
+	Kind       string
+	DBValue    string
+	ChainValue string
+	Fixed      bool
+}
+
+type ReconcileWorker struct {
+	settings  *config.Settings
+	logger    zerolog.Logger
+	identity  service.IdentityAPI
+	dbs       *db.Store
+	transfers *TransferHandler
+
+	river.WorkerDefaults[ReconcileArgs]
+}
+
+func NewReconcileWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dbs *db.Store, transfers *TransferHandler) *ReconcileWorker {
+	return &ReconcileWorker{
+		settings:  settings,
+		logger:    logger,
+		identity:  identity,
+		dbs:       dbs,
+		transfers: transfers,
+	}
+}
+
+func (w *ReconcileWorker) Timeout(*river.Job[ReconcileArgs]) time.Duration { return 30 * time.Minute }
+
+func (w *ReconcileWorker) Work(ctx context.Context, _ *river.Job[ReconcileArgs]) error {
+	w.logger.Debug().Msg("Starting chain/DB reconciliation")
+
+	pageSize := w.settings.ReconciliationPageSize
+	if pageSize <= 0 {
+		pageSize = defaultReconciliationPageSize
+	}
+
+	counts := make(map[string]int, len(DriftKinds))
+	checked, failed := 0, 0
+	after := ""
+
+	for {
+		records, err := dbmodels.Vins(
+			dbmodels.VinWhere.VehicleTokenID.IsNotNull(),
+			dbmodels.VinWhere.Vin.GT(after),
+			qm.OrderBy(dbmodels.VinColumns.Vin),
+			qm.Limit(pageSize),
+		).All(ctx, w.dbs.DBS().Reader)
+		if err != nil {
+			w.logger.Error().Err(err).Msg("Failed to load VINs for reconciliation")
+			return err
+		}
+
+		for _, record := range records {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			// chain state is expected to differ while a job is still working on the VIN
+			if IsMintPending(record.OnboardingStatus) || IsDisconnectPending(record.OnboardingStatus) || IsBurnPending(record.OnboardingStatus) {
+				continue
+			}
+
+			drifts, err := w.ReconcileVin(ctx, record)
+			if err != nil {
+				failed++
+				w.logger.Error().Err(err).Str(logfields.VIN, record.Vin).Msg("Failed to reconcile VIN")
+				continue
+			}
+
+			checked++
+			for _, d := range drifts {
+				counts[d.Kind]++
+			}
+		}
+
+		if len(records) < pageSize {
+			break
+		}
+		after = records[len(records)-1].Vin
+	}
+
+	for _, kind := range DriftKinds {
+		reconciliationDriftGauge.WithLabelValues(kind).Set(float64(counts[kind]))
+	}
+	reconciliationLastRunGauge.SetToCurrentTime()
+
+	w.logger.Info().Int("checked", checked).Int("failed", failed).Interface("drifts", counts).Msg("Chain/DB reconciliation finished")
+
+	return nil
+}
+
+// ReconcileVin compares a single VIN record with Identity API, records any drift found and, if enabled, fixes the record
+func (w *ReconcileWorker) ReconcileVin(ctx context.Context, record *dbmodels.Vin) ([]Drift, error) {
+	vehicle, err := w.identity.FetchVehicleByTokenID(ctx, record.VehicleTokenID.Int64)
+	if err != nil {
+		if !errors.Is(err, service.ErrGraphQLNotFound) {
+			return nil, err
+		}
+
+		// the token doesn't exist anymore, compare with an empty vehicle so it's reported as burned
+		vehicle = &models.Vehicle{}
+	}
+
+	// owner wasn't tracked for VINs onboarded before reconciliation existed, just backfill it
+	if !record.OwnerAddress.Valid && vehicle.TokenID != 0 && vehicle.Owner != "" {
+		record.OwnerAddress = null.StringFrom(common.HexToAddress(vehicle.Owner).Hex())
+		if _, err := record.Update(ctx, w.dbs.DBS().Writer, boil.Whitelist(dbmodels.VinColumns.OwnerAddress)); err != nil {
+			return nil, err
+		}
+	}
+
+	// keep the Identity API data used by the vehicle listing fresh
+	if vehicle.TokenID != 0 {
+		identity := service.VehicleIdentityFromModel(vehicle)
+		if err := identity.Upsert(ctx, w.dbs.DBS().Writer, true, []string{dbmodels.VehicleIdentityColumns.VehicleTokenID}, boil.Infer(), boil.Infer()); err != nil {
+			w.logger.Error().Err(err).Str(logfields.VIN, record.Vin).Msg("Failed to cache vehicle identity")
+		}
+	}
+
+	drifts := FindDrifts(record, vehicle)
+
+	// owner changes are transfers, they are always handled according to the transfer policy
+	for i := range drifts {
+		if drifts[i].Kind == DriftKindOwner {
+			if err := w.transfers.HandleTransfer(ctx, record, common.HexToAddress(vehicle.Owner)); err != nil {
+				return nil, err
+			}
+			drifts[i].Fixed = true
+		}
+	}
+
+	if w.settings.ReconciliationAutoFix {
+		if err := w.fix(ctx, record, vehicle, drifts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.saveDrifts(ctx, record.Vin, drifts); err != nil {
+		return nil, err
+	}
+
+	for _, d := range drifts {
+		w.logger.Warn().Str(logfields.VIN, record.Vin).Str("kind", d.Kind).Str("dbValue", d.DBValue).
+			Str("chainValue", d.ChainValue).Bool("fixed", d.Fixed).Msg("Chain/DB drift detected")
+	}
+
+	return drifts, nil
+}
+
+// FindDrifts returns all differences between the VIN record and the vehicle loaded from Identity API
+func FindDrifts(record *dbmodels.Vin, vehicle *models.Vehicle) []Drift {
+	var drifts []Drift
+
+	// empty vehicle means the token doesn't exist anymore
+	if vehicle.TokenID == 0 {
+		return append(drifts, Drift{
+			Kind:       DriftKindVehicleBurned,
+			DBValue:    strconv.FormatInt(record.VehicleTokenID.Int64, 10),
+			ChainValue: "",
+		})
+	}
+
+	if record.OwnerAddress.Valid && common.HexToAddress(record.OwnerAddress.String) != common.HexToAddress(vehicle.Owner) {
+		drifts = append(drifts, Drift{
+			Kind:       DriftKindOwner,
+			DBValue:    record.OwnerAddress.String,
+			ChainValue: vehicle.Owner,
+		})
+	}
+
+	if record.SyntheticTokenID.Int64 != vehicle.SyntheticDevice.TokenID {
+		drifts = append(drifts, Drift{
+			Kind:       DriftKindSyntheticDevice,
+			DBValue:    formatTokenID(record.SyntheticTokenID),
+			ChainValue: formatTokenID(null.NewInt64(vehicle.SyntheticDevice.TokenID, vehicle.SyntheticDevice.TokenID != 0)),
+		})
+	}
+
+	if vehicle.Definition.ID != "" && record.DeviceDefinitionID.String != vehicle.Definition.ID {
+		drifts = append(drifts, Drift{
+			Kind:       DriftKindDefinition,
+			DBValue:    record.DeviceDefinitionID.String,
+			ChainValue: vehicle.Definition.ID,
+		})
+	}
+
+	return drifts
+}
+
+func formatTokenID(tokenID null.Int64) string {
+	if !tokenID.Valid {
+		return ""
+	}
+	return strconv.FormatInt(tokenID.Int64, 10)
+}
+
+// fix makes the VIN record match on-chain state, chain is always the source of truth
+func (w *ReconcileWorker) fix(ctx context.Context, record *dbmodels.Vin, vehicle *models.Vehicle, drifts []Drift) error {
+	columns := ApplyDriftFixes(record, vehicle, drifts)
+	if len(columns) == 0 {
+		return nil
+	}
+
+	if _, err := record.Update(ctx, w.dbs.DBS().Writer, boil.Whitelist(columns...)); err != nil {
+		w.logger.Error().Err(err).Str(logfields.VIN, record.Vin).Msg("Failed to fix VIN record")
+		return err
+	}
+
+	return nil
+}
+
+// ApplyDriftFixes changes the VIN record to match the vehicle for every drift not fixed yet, and returns the changed columns
+func ApplyDriftFixes(record *dbmodels.Vin, vehicle *models.Vehicle, drifts []Drift) []string {
+	columns := []string{}
+
+	for i, d := range drifts {
+		if d.Fixed {
+			continue
+		}
+
+		drifts[i].Fixed = true
+		switch d.Kind {
+		case DriftKindVehicleBurned:
+			record.VehicleTokenID = null.Int64{}
+			record.SyntheticTokenID = null.Int64{}
+			record.OnboardingStatus = OnboardingStatusBurnVehicleSuccess
+			columns = append(columns, dbmodels.VinColumns.VehicleTokenID, dbmodels.VinColumns.SyntheticTokenID, dbmodels.VinColumns.OnboardingStatus)
+		case DriftKindSyntheticDevice:
+			if vehicle.SyntheticDevice.TokenID == 0 {
+				// like a disconnect, a later SD mint reserves a new wallet index
+				record.SyntheticTokenID = null.Int64{}
+				record.WalletIndex = null.Int64{}
+				record.OnboardingStatus = OnboardingStatusBurnSDSuccess
+				columns = append(columns, dbmodels.VinColumns.WalletIndex)
+			} else {
+				record.SyntheticTokenID = null.Int64From(vehicle.SyntheticDevice.TokenID)
+				// a paused VIN stays paused with its new SD, the owner resumes it
+				if !IsPausePhase(record.OnboardingStatus) {
+					record.OnboardingStatus = OnboardingStatusMintSuccess
+				}
+			}
+			columns = append(columns, dbmodels.VinColumns.SyntheticTokenID, dbmodels.VinColumns.OnboardingStatus)
+		case DriftKindDefinition:
+			record.DeviceDefinitionID = null.StringFrom(vehicle.Definition.ID)
+			columns = append(columns, dbmodels.VinColumns.DeviceDefinitionID)
+		}
+	}
+
+	return columns
+}
+
+// saveDrifts upserts the drifts found for the VIN and resolves the ones that are no longer present
+func (w *ReconcileWorker) saveDrifts(ctx context.Context, vin string, drifts []Drift) error {
+	tx, err := w.dbs.DBS().Writer.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		w.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				w.logger.Error().Err(rbErr).Msg("Failed to rollback transaction")
+			}
+		}
+	}()
+
+	now := time.Now()
+	kinds := make([]string, 0, len(drifts))
+
+	// a drift that is still open keeps the time it was first detected, a resolved one that is back is detected again
+	qry := fmt.Sprintf(`INSERT INTO %s.vin_drifts (vin, kind, db_value, chain_value, detected_at, resolved_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (vin, kind) DO UPDATE SET
+			db_value = EXCLUDED.db_value,
+			chain_value = EXCLUDED.chain_value,
+			detected_at = CASE WHEN vin_drifts.resolved_at IS NULL THEN vin_drifts.detected_at ELSE EXCLUDED.detected_at END,
+			resolved_at = EXCLUDED.resolved_at;`, w.settings.DB.Name)
+
+	for _, d := range drifts {
+		kinds = append(kinds, d.Kind)
+
+		resolvedAt := null.Time{}
+		if d.Fixed {
+			resolvedAt = null.TimeFrom(now)
+		}
+
+		_, err = queries.Raw(qry, vin, d.Kind, null.StringFrom(d.DBValue), null.StringFrom(d.ChainValue), now, resolvedAt).ExecContext(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to save drift: %w", err)
+		}
+	}
+
+	// anything still open that we didn't see this time was fixed elsewhere
+	_, err = dbmodels.VinDrifts(
+		dbmodels.VinDriftWhere.Vin.EQ(vin),
+		dbmodels.VinDriftWhere.Kind.NIN(kinds),
+		dbmodels.VinDriftWhere.ResolvedAt.IsNull(),
+	).UpdateAll(ctx, tx, dbmodels.M{dbmodels.VinDriftColumns.ResolvedAt: now})
+	if err != nil {
+		return fmt.Errorf("failed to resolve drifts: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		w.logger.Error().Err(err).Msg("Failed to commit transaction")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Prometheus metrics
+var reconciliationDriftGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "oracle_example_reconciliation_drifts",
+	Help: "Number of VINs with a chain/DB mismatch found in the last reconciliation run, by kind",
+}, []string{"kind"})
+
+var reconciliationLastRunGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "oracle_example_reconciliation_last_run_timestamp_seconds",
+	Help: "Unix time of the last finished reconciliation run",
+})
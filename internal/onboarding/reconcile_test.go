@@ -0,0 +1,144 @@
+package onboarding
+
+import (
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/stretchr/testify/suite"
+	"github.com/volatiletech/null/v8"
+	"testing"
+)
+
+type ReconcileTestSuite struct {
+	suite.Suite
+}
+
+func TestReconcileTestSuite(t *testing.T) {
+	suite.Run(t, new(ReconcileTestSuite))
+}
+
+const (
+	testOwner    = "0x1e7B7e2a8c0F3C1A2F4e5d0b6C3f2A9e8D7c6B5a"
+	testNewOwner = "0x2A4c6E8f0B1d3F5a7C9e1B3d5F7a9C1e3B5d7F9a"
+)
+
+func minted() *dbmodels.Vin {
+	return &dbmodels.Vin{
+		Vin:                "1HGCM82633A004352",
+		VehicleTokenID:     null.Int64From(10),
+		SyntheticTokenID:   null.Int64From(20),
+		DeviceDefinitionID: null.StringFrom("ford_f-150_2020"),
+		OwnerAddress:       null.StringFrom(testOwner),
+		OnboardingStatus:   OnboardingStatusMintSuccess,
+	}
+}
+
+func onChain() *models.Vehicle {
+	return &models.Vehicle{
+		TokenID:         10,
+		Owner:           testOwner,
+		SyntheticDevice: models.SyntheticDevice{TokenID: 20},
+		Definition:      models.Definition{ID: "ford_f-150_2020"},
+	}
+}
+
+func kinds(drifts []Drift) []string {
+	result := make([]string, 0, len(drifts))
+	for _, d := range drifts {
+		result = append(result, d.Kind)
+	}
+	return result
+}
+
+func (s *ReconcileTestSuite) TestFindDrifts() {
+	tests := []struct {
+		name    string
+		vehicle func(*models.Vehicle)
+		record  func(*dbmodels.Vin)
+		kinds   []string
+	}{
+		{name: "in sync", kinds: []string{}},
+		{name: "burned", vehicle: func(v *models.Vehicle) { *v = models.Vehicle{} }, kinds: []string{DriftKindVehicleBurned}},
+		{name: "transferred", vehicle: func(v *models.Vehicle) { v.Owner = testNewOwner }, kinds: []string{DriftKindOwner}},
+		// addresses are compared, not their case
+		{name: "owner case", record: func(r *dbmodels.Vin) { r.OwnerAddress = null.StringFrom("0x1e7b7e2a8c0f3c1a2f4e5d0b6c3f2a9e8d7c6b5a") }, kinds: []string{}},
+		{name: "owner not tracked", record: func(r *dbmodels.Vin) { r.OwnerAddress = null.String{} }, kinds: []string{}},
+		{name: "SD burned", vehicle: func(v *models.Vehicle) { v.SyntheticDevice.TokenID = 0 }, kinds: []string{DriftKindSyntheticDevice}},
+		{name: "SD re-minted", vehicle: func(v *models.Vehicle) { v.SyntheticDevice.TokenID = 21 }, kinds: []string{DriftKindSyntheticDevice}},
+		{name: "definition", vehicle: func(v *models.Vehicle) { v.Definition.ID = "ford_f-150_2021" }, kinds: []string{DriftKindDefinition}},
+		{name: "no definition on chain", vehicle: func(v *models.Vehicle) { v.Definition.ID = "" }, kinds: []string{}},
+		{
+			name:    "several",
+			vehicle: func(v *models.Vehicle) { v.Owner, v.SyntheticDevice.TokenID = testNewOwner, 0 },
+			kinds:   []string{DriftKindOwner, DriftKindSyntheticDevice},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			record, vehicle := minted(), onChain()
+			if tt.record != nil {
+				tt.record(record)
+			}
+			if tt.vehicle != nil {
+				tt.vehicle(vehicle)
+			}
+			s.Equal(tt.kinds, kinds(FindDrifts(record, vehicle)))
+		})
+	}
+}
+
+func (s *ReconcileTestSuite) TestFindDriftsValues() {
+	vehicle := onChain()
+	vehicle.SyntheticDevice.TokenID = 0
+
+	drifts := FindDrifts(minted(), vehicle)
+	s.Require().Len(drifts, 1)
+	s.Equal("20", drifts[0].DBValue)
+	s.Equal("", drifts[0].ChainValue)
+}
+
+func (s *ReconcileTestSuite) TestApplyDriftFixes() {
+	record, vehicle := minted(), onChain()
+	record.WalletIndex = null.Int64From(3)
+	vehicle.SyntheticDevice.TokenID = 0
+	s.Contains(ApplyDriftFixes(record, vehicle, FindDrifts(record, vehicle)), dbmodels.VinColumns.WalletIndex)
+	s.Equal(OnboardingStatusBurnSDSuccess, record.OnboardingStatus)
+	s.False(record.SyntheticTokenID.Valid)
+	s.False(record.WalletIndex.Valid, "the burned SD's wallet index is released")
+
+	record, vehicle = minted(), onChain()
+	record.OnboardingStatus = OnboardingStatusBurnSDSuccess
+	vehicle.SyntheticDevice.TokenID = 21
+	ApplyDriftFixes(record, vehicle, FindDrifts(record, vehicle))
+	s.Equal(OnboardingStatusMintSuccess, record.OnboardingStatus)
+	s.Equal(int64(21), record.SyntheticTokenID.Int64)
+
+	record, vehicle = minted(), onChain()
+	*vehicle = models.Vehicle{}
+	ApplyDriftFixes(record, vehicle, FindDrifts(record, vehicle))
+	s.Equal(OnboardingStatusBurnVehicleSuccess, record.OnboardingStatus)
+	s.False(record.VehicleTokenID.Valid)
+}
+
+func (s *ReconcileTestSuite) TestApplyDriftFixesKeepsPause() {
+	for _, status := range []int{OnboardingStatusPauseSuccess, OnboardingStatusResumeFailure, OnboardingStatusPauseFailure} {
+		record, vehicle := minted(), onChain()
+		record.OnboardingStatus = status
+		vehicle.SyntheticDevice.TokenID = 21
+
+		ApplyDriftFixes(record, vehicle, FindDrifts(record, vehicle))
+		s.Equal(status, record.OnboardingStatus)
+		s.Equal(int64(21), record.SyntheticTokenID.Int64)
+	}
+}
+
+func (s *ReconcileTestSuite) TestApplyDriftFixesSkipsFixed() {
+	record, vehicle := minted(), onChain()
+	vehicle.Owner = testNewOwner
+	drifts := FindDrifts(record, vehicle)
+	// transfers are handled by the transfer policy before the fixes
+	drifts[0].Fixed = true
+
+	s.Empty(ApplyDriftFixes(record, vehicle, drifts))
+	s.Equal(testOwner, record.OwnerAddress.String)
+}
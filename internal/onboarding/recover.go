@@ -0,0 +1,420 @@
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/friendsofgo/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/riverqueue/river"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"time"
+)
+
+const (
+	defaultStuckJobInterval  = 15 * time.Minute
+	defaultStuckJobThreshold = 60 * time.Minute
+	// minted tokens are matched to jobs by time, allow for clock differences between the chain and the DB
+	mintedAtTolerance = 5 * time.Minute
+)
+
+const VinHistoryEventStuckRecovery = "stuck_recovery"
+
+const (
+	// RecoveryResultSuccess the operation happened on chain, the VIN moved forward to the success status
+	RecoveryResultSuccess = "success"
+	// RecoveryResultFailure the operation didn't happen on chain, the VIN moved back to a retryable failure status
+	RecoveryResultFailure = "failure"
+	// RecoveryResultAmbiguous chain state doesn't tell whether the operation happened, the VIN is left for operators
+	RecoveryResultAmbiguous = "ambiguous"
+	// RecoveryResultSkipped the VIN changed while it was being checked
+	RecoveryResultSkipped = "skipped"
+)
+
+// stuckStatuses are the pending statuses VINs stay in while a job of the kind works on them
+var stuckStatuses = map[string][]int{
+	OnboardingArgs{}.Kind(): {
+		OnboardingStatusMintSubmitUnknown, OnboardingStatusMintSubmitPending, OnboardingStatusMintSubmitSuccess,
+		OnboardingStatusConnectUnknown, OnboardingStatusConnectPending, OnboardingStatusConnectSuccess,
+		OnboardingStatusMintUnknown, OnboardingStatusMintPending,
+	},
+	DisconnectArgs{}.Kind(): {
+		OnboardingStatusDisconnectSubmitUnknown, OnboardingStatusDisconnectSubmitPending, OnboardingStatusDisconnectSubmitSuccess,
+		OnboardingStatusDisconnectUnknown, OnboardingStatusDisconnectPending, OnboardingStatusDisconnectSuccess,
+		OnboardingStatusBurnSDUnknown, OnboardingStatusBurnSDPending,
+	},
+	DeleteArgs{}.Kind(): {
+		OnboardingStatusDeleteSubmitUnknown, OnboardingStatusDeleteSubmitPending, OnboardingStatusDeleteSubmitSuccess,
+		OnboardingStatusBurnVehicleUnknown, OnboardingStatusBurnVehiclePending,
+	},
+	PauseArgs{}.Kind(): {
+		OnboardingStatusPauseUnknown, OnboardingStatusPausePending,
+		OnboardingStatusResumeUnknown, OnboardingStatusResumePending,
+	},
+}
+
+// stuckUserOperationSteps are the chain-side steps of the job kinds, their pending user operations are dropped on recovery
+var stuckUserOperationSteps = map[string][]string{
+	OnboardingArgs{}.Kind(): {userOperationStepMintVehicle, userOperationStepMintSD},
+	DisconnectArgs{}.Kind(): {userOperationStepBurnSD},
+	DeleteArgs{}.Kind():     {userOperationStepBurnVehicle},
+}
+
+type RecoverStuckArgs struct{}
+
+func (a RecoverStuckArgs) Kind() string {
+	return "recover_stuck"
+}
+func (a RecoverStuckArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		MaxAttempts: 1,
+		UniqueOpts: river.UniqueOpts{
+			ByArgs: false,
+		},
+	}
+}
+
+// StuckJobInterval returns how often the stuck job detector should be scheduled
+func StuckJobInterval(settings *config.Settings) time.Duration {
+	if settings.StuckJobIntervalMinutes <= 0 {
+		return defaultStuckJobInterval
+	}
+	return time.Duration(settings.StuckJobIntervalMinutes) * time.Minute
+}
+
+// StuckJobThreshold returns how long a VIN has to be pending before it's considered stuck
+func StuckJobThreshold(settings *config.Settings) time.Duration {
+	if settings.StuckJobThresholdMinutes <= 0 {
+		return defaultStuckJobThreshold
+	}
+	return time.Duration(settings.StuckJobThresholdMinutes) * time.Minute
+}
+
+// StuckJobWorker finds VINs left pending by mint, disconnect, delete and pause jobs that died with their pod, e.g.
+// mid-mint, or ran out of attempts. Pause jobs have a single attempt and chain jobs give up after chainStepMaxAttempts,
+// after that nothing would ever move the VINs again and the API refuses to resubmit them. Chain state from Identity API
+// decides whether a chain operation happened: the VIN moves forward to success, or back to a failure status the owner can
+// retry from. Pause and resume have no chain state, they always move back to their failure status.
+type StuckJobWorker struct {
+	settings *config.Settings
+	logger   zerolog.Logger
+	identity service.IdentityAPI
+	dbs      *db.Store
+
+	river.WorkerDefaults[RecoverStuckArgs]
+}
+
+func NewStuckJobWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dbs *db.Store) *StuckJobWorker {
+	return &StuckJobWorker{
+		settings: settings,
+		logger:   logger,
+		identity: identity,
+		dbs:      dbs,
+	}
+}
+
+func (w *StuckJobWorker) Timeout(*river.Job[RecoverStuckArgs]) time.Duration { return 30 * time.Minute }
+
+func (w *StuckJobWorker) Work(ctx context.Context, _ *river.Job[RecoverStuckArgs]) error {
+	threshold := StuckJobThreshold(w.settings)
+	w.logger.Debug().Dur("threshold", threshold).Msg("Looking for stuck VINs")
+
+	for kind, statuses := range stuckStatuses {
+		records, err := dbmodels.Vins(
+			dbmodels.VinWhere.OnboardingStatus.IN(statuses),
+			dbmodels.VinWhere.OnboardingStatusUpdatedAt.LT(time.Now().Add(-threshold)),
+			qm.OrderBy(dbmodels.VinColumns.OnboardingStatusUpdatedAt),
+		).All(ctx, w.dbs.DBS().Reader)
+		if err != nil {
+			w.logger.Error().Err(err).Msg("Failed to load pending VINs")
+			return err
+		}
+
+		for _, record := range records {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			job, err := lastVinJob(ctx, w.dbs.DBS().Reader, w.settings.DB.Name, kind, record.Vin)
+			if err != nil {
+				w.logger.Error().Err(err).Str(logfields.VIN, record.Vin).Msg("Failed to load last job of VIN")
+				stuckVinsCntr.WithLabelValues(kind, "error").Inc()
+				continue
+			}
+			if job != nil && job.IsLive() {
+				continue
+			}
+
+			result, err := w.recoverVin(ctx, record, kind, job)
+			if err != nil {
+				w.logger.Error().Err(err).Str(logfields.VIN, record.Vin).Str("kind", kind).Msg("Failed to recover stuck VIN")
+				stuckVinsCntr.WithLabelValues(kind, "error").Inc()
+				continue
+			}
+			stuckVinsCntr.WithLabelValues(kind, result).Inc()
+		}
+	}
+
+	stuckJobLastRunGauge.SetToCurrentTime()
+
+	return nil
+}
+
+// recoverVin checks chain state for the VIN stuck by the last job of the kind, which may be nil if it was never
+// inserted, and moves the VIN to the status matching the chain
+func (w *StuckJobWorker) recoverVin(ctx context.Context, record *dbmodels.Vin, kind string, job *vinJob) (string, error) {
+	previousStatus := record.OnboardingStatus
+	var result string
+	var columns []string
+	var err error
+
+	switch kind {
+	case OnboardingArgs{}.Kind():
+		result, columns, err = w.recoverMint(ctx, record, job)
+	case DisconnectArgs{}.Kind():
+		result, columns, err = w.recoverDisconnect(ctx, record)
+	case DeleteArgs{}.Kind():
+		result, columns, err = w.recoverDelete(ctx, record)
+	case PauseArgs{}.Kind():
+		result, columns = w.recoverPause(record)
+	default:
+		return "", fmt.Errorf("unsupported job kind %s", kind)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if result == RecoveryResultAmbiguous {
+		w.logger.Warn().Str(logfields.VIN, record.Vin).Str("kind", kind).Str("status", GetDetailedStatus(previousStatus)).
+			Msg("Stuck VIN needs an operator, chain state is ambiguous")
+		return result, nil
+	}
+
+	details := fmt.Sprintf("status: %s -> %s", GetDetailedStatus(previousStatus), GetDetailedStatus(record.OnboardingStatus))
+	if job != nil {
+		details += fmt.Sprintf(", job: %s %d %s", kind, job.ID, job.State)
+	}
+
+	updated, err := w.update(ctx, record, previousStatus, columns, stuckUserOperationSteps[kind], details)
+	if err != nil {
+		return "", err
+	}
+	if !updated {
+		return RecoveryResultSkipped, nil
+	}
+
+	w.logger.Info().Str(logfields.VIN, record.Vin).Str("kind", kind).Str("result", result).Msgf("Stuck VIN recovered, %s", details)
+
+	return result, nil
+}
+
+// recoverMint looks for the vehicle the job may have minted. It's known by token ID when the job stored it before
+// dying, otherwise it's searched among the owner's vehicles minted since the job was created, with the VIN's definition.
+func (w *StuckJobWorker) recoverMint(ctx context.Context, record *dbmodels.Vin, job *vinJob) (string, []string, error) {
+	var vehicle *models.Vehicle
+
+	if record.VehicleTokenID.Valid {
+		fetched, err := w.identity.FetchVehicleByTokenID(ctx, record.VehicleTokenID.Int64)
+		if err != nil && !errors.Is(err, service.ErrGraphQLNotFound) {
+			return "", nil, err
+		}
+		vehicle = fetched
+	} else {
+		owner := record.OwnerAddress
+		if !owner.Valid && job != nil {
+			owner = job.Owner
+		}
+
+		if owner.Valid {
+			candidates, err := w.mintCandidates(ctx, record, owner.String, job)
+			if err != nil {
+				return "", nil, err
+			}
+			if len(candidates) > 1 {
+				return RecoveryResultAmbiguous, nil, nil
+			}
+			if len(candidates) == 1 {
+				vehicle = &candidates[0]
+				record.VehicleTokenID = null.Int64From(vehicle.TokenID)
+				record.OwnerAddress = null.StringFrom(common.HexToAddress(vehicle.Owner).Hex())
+			}
+		}
+	}
+
+	columns := []string{dbmodels.VinColumns.OnboardingStatus, dbmodels.VinColumns.VehicleTokenID, dbmodels.VinColumns.SyntheticTokenID, dbmodels.VinColumns.OwnerAddress}
+
+	// a vehicle without SD is finished by the retry, it only mints the SD when the vehicle token ID is known
+	if vehicle == nil || vehicle.TokenID == 0 || vehicle.SyntheticDevice.TokenID == 0 {
+		if vehicle == nil || vehicle.TokenID == 0 {
+			record.VehicleTokenID = null.Int64{}
+		}
+		record.SyntheticTokenID = null.Int64{}
+		record.OnboardingStatus = OnboardingStatusMintFailure
+		return RecoveryResultFailure, columns, nil
+	}
+
+	record.SyntheticTokenID = null.Int64From(vehicle.SyntheticDevice.TokenID)
+	record.OnboardingStatus = OnboardingStatusMintSuccess
+	return RecoveryResultSuccess, columns, nil
+}
+
+// mintCandidates returns the owner's vehicles that could have been minted by the job and aren't linked to another VIN
+func (w *StuckJobWorker) mintCandidates(ctx context.Context, record *dbmodels.Vin, owner string, job *vinJob) ([]models.Vehicle, error) {
+	vehicles, err := w.identity.FetchVehiclesByWalletAddress(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []models.Vehicle
+	for _, vehicle := range vehicles {
+		if vehicle.Definition.ID != record.DeviceDefinitionID.String {
+			continue
+		}
+
+		if job != nil {
+			mintedAt, err := time.Parse(time.RFC3339, vehicle.MintedAt)
+			if err == nil && mintedAt.Before(job.CreatedAt.Add(-mintedAtTolerance)) {
+				continue
+			}
+		}
+
+		linked, err := dbmodels.Vins(dbmodels.VinWhere.VehicleTokenID.EQ(null.Int64From(vehicle.TokenID))).Exists(ctx, w.dbs.DBS().Reader)
+		if err != nil {
+			return nil, err
+		}
+		if !linked {
+			candidates = append(candidates, vehicle)
+		}
+	}
+
+	return candidates, nil
+}
+
+func (w *StuckJobWorker) recoverDisconnect(ctx context.Context, record *dbmodels.Vin) (string, []string, error) {
+	columns := []string{dbmodels.VinColumns.OnboardingStatus, dbmodels.VinColumns.SyntheticTokenID, dbmodels.VinColumns.WalletIndex}
+
+	vehicle, err := w.identity.FetchVehicleByTokenID(ctx, record.VehicleTokenID.Int64)
+	if err != nil && !errors.Is(err, service.ErrGraphQLNotFound) {
+		return "", nil, err
+	}
+
+	if err == nil && vehicle.SyntheticDevice.TokenID != 0 {
+		record.OnboardingStatus = OnboardingStatusBurnSDFailure
+		return RecoveryResultFailure, columns, nil
+	}
+
+	record.SyntheticTokenID = null.Int64{}
+	record.WalletIndex = null.Int64{}
+	record.OnboardingStatus = OnboardingStatusBurnSDSuccess
+	return RecoveryResultSuccess, columns, nil
+}
+
+func (w *StuckJobWorker) recoverDelete(ctx context.Context, record *dbmodels.Vin) (string, []string, error) {
+	columns := []string{dbmodels.VinColumns.OnboardingStatus, dbmodels.VinColumns.VehicleTokenID}
+
+	_, err := w.identity.FetchVehicleByTokenID(ctx, record.VehicleTokenID.Int64)
+	if err == nil {
+		record.OnboardingStatus = OnboardingStatusBurnVehicleFailure
+		return RecoveryResultFailure, columns, nil
+	}
+	if !errors.Is(err, service.ErrGraphQLNotFound) {
+		return "", nil, err
+	}
+
+	record.VehicleTokenID = null.Int64{}
+	record.OnboardingStatus = OnboardingStatusBurnVehicleSuccess
+	return RecoveryResultSuccess, columns, nil
+}
+
+// recoverPause moves the VIN to the pause or resume failure status. Whether the vendor call happened is unknown, the
+// owner retries it and the vendor calls are idempotent. The pause flag isn't changed, a failed resume leaves the VIN paused.
+func (w *StuckJobWorker) recoverPause(record *dbmodels.Vin) (string, []string) {
+	if record.OnboardingStatus == OnboardingStatusResumeUnknown || record.OnboardingStatus == OnboardingStatusResumePending {
+		record.OnboardingStatus = OnboardingStatusResumeFailure
+	} else {
+		record.OnboardingStatus = OnboardingStatusPauseFailure
+	}
+
+	return RecoveryResultFailure, []string{dbmodels.VinColumns.OnboardingStatus}
+}
+
+// update saves the recovered VIN and records it in VIN history, unless its status changed since it was loaded.
+// Pending user operations of the steps are marked replaced, so the owner's next job sends a new operation instead of
+// resuming the stale one.
+func (w *StuckJobWorker) update(ctx context.Context, record *dbmodels.Vin, previousStatus int, columns []string, steps []string, details string) (bool, error) {
+	tx, err := w.dbs.DBS().Writer.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		w.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				w.logger.Error().Err(rbErr).Msg("Failed to rollback transaction")
+			}
+		}
+	}()
+
+	current, err := dbmodels.Vins(dbmodels.VinWhere.Vin.EQ(record.Vin), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		return false, fmt.Errorf("failed to load VIN record: %w", err)
+	}
+	if current.OnboardingStatus != previousStatus {
+		return false, tx.Rollback()
+	}
+
+	if _, err = record.Update(ctx, tx, boil.Whitelist(columns...)); err != nil {
+		return false, fmt.Errorf("failed to update VIN record: %w", err)
+	}
+
+	if len(steps) > 0 {
+		_, err = dbmodels.VinUserOperations(
+			dbmodels.VinUserOperationWhere.Vin.EQ(record.Vin),
+			dbmodels.VinUserOperationWhere.Step.IN(steps),
+			dbmodels.VinUserOperationWhere.Status.EQ(UserOperationStatusPending),
+		).UpdateAll(ctx, tx, dbmodels.M{dbmodels.VinUserOperationColumns.Status: UserOperationStatusReplaced})
+		if err != nil {
+			return false, fmt.Errorf("failed to replace pending user operations: %w", err)
+		}
+	}
+
+	history := dbmodels.VinHistory{
+		Vin:           record.Vin,
+		Event:         VinHistoryEventStuckRecovery,
+		PreviousOwner: current.OwnerAddress,
+		NewOwner:      record.OwnerAddress,
+		Details:       null.StringFrom(details),
+	}
+	if err = history.Insert(ctx, tx, boil.Infer()); err != nil {
+		return false, fmt.Errorf("failed to insert VIN history: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		w.logger.Error().Err(err).Msg("Failed to commit transaction")
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// Prometheus metrics
+var stuckVinsCntr = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "oracle_example_stuck_vins_total",
+	Help: "Total number of VINs found stuck in a pending status without a live job, by job kind and recovery result",
+}, []string{"kind", "result"})
+
+var stuckJobLastRunGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "oracle_example_stuck_job_detector_last_run_timestamp_seconds",
+	Help: "Unix time of the last finished stuck job detector run",
+})
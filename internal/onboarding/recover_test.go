@@ -0,0 +1,364 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/DIMO-Network/oracle-example/internal/test"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"testing"
+	"time"
+)
+
+// fakeIdentity serves vehicles from memory, unknown token IDs are not found like on Identity API
+type fakeIdentity struct {
+	service.IdentityAPI
+	vehicles []models.Vehicle
+	err      error
+}
+
+func (f *fakeIdentity) FetchVehicleByTokenID(_ context.Context, tokenID int64) (*models.Vehicle, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, vehicle := range f.vehicles {
+		if vehicle.TokenID == tokenID {
+			return &vehicle, nil
+		}
+	}
+
+	return nil, service.ErrGraphQLNotFound
+}
+
+func (f *fakeIdentity) FetchVehiclesByWalletAddress(_ context.Context, address string) ([]models.Vehicle, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var vehicles []models.Vehicle
+	for _, vehicle := range f.vehicles {
+		if vehicle.Owner == address {
+			vehicles = append(vehicles, vehicle)
+		}
+	}
+
+	return vehicles, nil
+}
+
+type RecoverTestSuite struct {
+	suite.Suite
+	identity *fakeIdentity
+	worker   *StuckJobWorker
+}
+
+func TestRecoverTestSuite(t *testing.T) {
+	suite.Run(t, new(RecoverTestSuite))
+}
+
+func (s *RecoverTestSuite) SetupTest() {
+	s.identity = &fakeIdentity{}
+	s.worker = NewStuckJobWorker(&config.Settings{}, zerolog.Nop(), s.identity, nil)
+}
+
+func (s *RecoverTestSuite) TestDefaults() {
+	s.Equal(15*time.Minute, StuckJobInterval(&config.Settings{}))
+	s.Equal(60*time.Minute, StuckJobThreshold(&config.Settings{StuckJobThresholdMinutes: -1}))
+	s.Equal(5*time.Minute, StuckJobInterval(&config.Settings{StuckJobIntervalMinutes: 5}))
+	s.Equal(30*time.Minute, StuckJobThreshold(&config.Settings{StuckJobThresholdMinutes: 30}))
+}
+
+func (s *RecoverTestSuite) TestRecoverMintKnownToken() {
+	record := minted()
+	record.SyntheticTokenID = null.Int64{}
+	record.OnboardingStatus = OnboardingStatusMintPending
+
+	// the job died after minting both NFTs
+	s.identity.vehicles = []models.Vehicle{*onChain()}
+	result, _, err := s.worker.recoverMint(context.Background(), record, nil)
+	s.Require().NoError(err)
+	s.Equal(RecoveryResultSuccess, result)
+	s.Equal(OnboardingStatusMintSuccess, record.OnboardingStatus)
+	s.Equal(int64(20), record.SyntheticTokenID.Int64)
+
+	// the job died before minting the SD, the retry mints it for the known vehicle
+	record.SyntheticTokenID = null.Int64{}
+	record.OnboardingStatus = OnboardingStatusMintPending
+	vehicle := onChain()
+	vehicle.SyntheticDevice = models.SyntheticDevice{}
+	s.identity.vehicles = []models.Vehicle{*vehicle}
+	result, _, err = s.worker.recoverMint(context.Background(), record, nil)
+	s.Require().NoError(err)
+	s.Equal(RecoveryResultFailure, result)
+	s.Equal(OnboardingStatusMintFailure, record.OnboardingStatus)
+	s.Equal(int64(10), record.VehicleTokenID.Int64)
+	s.False(record.SyntheticTokenID.Valid)
+}
+
+func (s *RecoverTestSuite) TestRecoverDisconnect() {
+	record := minted()
+	record.OnboardingStatus = OnboardingStatusBurnSDPending
+
+	s.identity.vehicles = []models.Vehicle{*onChain()}
+	result, _, err := s.worker.recoverDisconnect(context.Background(), record)
+	s.Require().NoError(err)
+	s.Equal(RecoveryResultFailure, result)
+	s.Equal(OnboardingStatusBurnSDFailure, record.OnboardingStatus)
+	s.True(record.SyntheticTokenID.Valid)
+
+	vehicle := onChain()
+	vehicle.SyntheticDevice = models.SyntheticDevice{}
+	s.identity.vehicles = []models.Vehicle{*vehicle}
+	result, _, err = s.worker.recoverDisconnect(context.Background(), record)
+	s.Require().NoError(err)
+	s.Equal(RecoveryResultSuccess, result)
+	s.Equal(OnboardingStatusBurnSDSuccess, record.OnboardingStatus)
+	s.False(record.SyntheticTokenID.Valid)
+}
+
+func (s *RecoverTestSuite) TestRecoverDelete() {
+	record := minted()
+	record.SyntheticTokenID = null.Int64{}
+	record.OnboardingStatus = OnboardingStatusBurnVehiclePending
+
+	s.identity.vehicles = []models.Vehicle{*onChain()}
+	result, _, err := s.worker.recoverDelete(context.Background(), record)
+	s.Require().NoError(err)
+	s.Equal(RecoveryResultFailure, result)
+	s.Equal(OnboardingStatusBurnVehicleFailure, record.OnboardingStatus)
+
+	s.identity.vehicles = nil
+	result, _, err = s.worker.recoverDelete(context.Background(), record)
+	s.Require().NoError(err)
+	s.Equal(RecoveryResultSuccess, result)
+	s.Equal(OnboardingStatusBurnVehicleSuccess, record.OnboardingStatus)
+	s.False(record.VehicleTokenID.Valid)
+}
+
+func (s *RecoverTestSuite) TestRecoverPause() {
+	for _, tc := range []struct {
+		status   int
+		paused   bool
+		expected int
+	}{
+		{status: OnboardingStatusPauseUnknown, expected: OnboardingStatusPauseFailure},
+		{status: OnboardingStatusPausePending, expected: OnboardingStatusPauseFailure},
+		{status: OnboardingStatusResumeUnknown, paused: true, expected: OnboardingStatusResumeFailure},
+		{status: OnboardingStatusResumePending, paused: true, expected: OnboardingStatusResumeFailure},
+	} {
+		record := minted()
+		record.OnboardingStatus = tc.status
+		record.TelemetryPaused = tc.paused
+
+		result, columns := s.worker.recoverPause(record)
+		s.Equal(RecoveryResultFailure, result)
+		s.Equal([]string{dbmodels.VinColumns.OnboardingStatus}, columns)
+		s.Equal(tc.expected, record.OnboardingStatus, GetDetailedStatus(tc.status))
+		s.Equal(tc.paused, record.TelemetryPaused)
+		s.Equal(tc.paused, IsPaused(record.OnboardingStatus))
+	}
+}
+
+func (s *RecoverTestSuite) TestRecoverIdentityFailure() {
+	s.identity.err = errors.New("identity down")
+
+	record := minted()
+	record.OnboardingStatus = OnboardingStatusBurnVehiclePending
+	_, _, err := s.worker.recoverDelete(context.Background(), record)
+	s.Error(err)
+	s.Equal(OnboardingStatusBurnVehiclePending, record.OnboardingStatus)
+}
+
+// StuckJobWorkerTestSuite runs the detector against the database
+type StuckJobWorkerTestSuite struct {
+	suite.Suite
+	pdb       db.Store
+	container testcontainers.Container
+	ctx       context.Context
+	settings  config.Settings
+	identity  *fakeIdentity
+	worker    *StuckJobWorker
+}
+
+func TestStuckJobWorkerTestSuite(t *testing.T) {
+	suite.Run(t, new(StuckJobWorkerTestSuite))
+}
+
+// SetupSuite starts container db
+func (s *StuckJobWorkerTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container, s.settings = test.StartContainerDatabase(s.ctx, s.T(), migrationsDirRelPath)
+}
+
+func (s *StuckJobWorkerTestSuite) SetupTest() {
+	s.identity = &fakeIdentity{}
+	s.worker = NewStuckJobWorker(&s.settings, zerolog.Nop(), s.identity, &s.pdb)
+}
+
+// TearDownTest after each test truncate tables
+func (s *StuckJobWorkerTestSuite) TearDownTest() {
+	test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+// TearDownSuite cleanup at end by terminating container
+func (s *StuckJobWorkerTestSuite) TearDownSuite() {
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+// insertStuck inserts the VIN with a status set long enough ago to be stuck, the trigger owning the column is
+// bypassed to backdate it
+func (s *StuckJobWorkerTestSuite) insertStuck(record *dbmodels.Vin, age time.Duration) {
+	s.Require().NoError(record.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	_, err := s.pdb.DBS().Writer.Exec(fmt.Sprintf(`
+		ALTER TABLE %[1]s.vins DISABLE TRIGGER vins_onboarding_status_updated_at;
+		UPDATE %[1]s.vins SET onboarding_status_updated_at = now() - make_interval(secs => %[2]d) WHERE vin = '%[3]s';
+		ALTER TABLE %[1]s.vins ENABLE TRIGGER vins_onboarding_status_updated_at;
+	`, s.settings.DB.Name, int(age.Seconds()), record.Vin))
+	s.Require().NoError(err)
+}
+
+// insertJob inserts a job in the state directly, finalized states need their finalization time
+func (s *StuckJobWorkerTestSuite) insertJob(kind, vin string, state rivertype.JobState) {
+	finalizedAt := "NULL"
+	if state == rivertype.JobStateCancelled || state == rivertype.JobStateCompleted || state == rivertype.JobStateDiscarded {
+		finalizedAt = "now()"
+	}
+
+	_, err := s.pdb.DBS().Writer.Exec(fmt.Sprintf(
+		"INSERT INTO %s.river_job (kind, args, state, max_attempts, queue, finalized_at) VALUES ($1, jsonb_build_object('vin', $2::text), $3, 1, $4, %s);",
+		s.settings.DB.Name, finalizedAt), kind, vin, string(state), QueueChain)
+	s.Require().NoError(err)
+}
+
+func (s *StuckJobWorkerTestSuite) load(vin string) (*dbmodels.Vin, dbmodels.VinHistorySlice) {
+	record, err := dbmodels.FindVin(s.ctx, s.pdb.DBS().Reader, vin)
+	s.Require().NoError(err)
+	history, err := dbmodels.VinHistories(dbmodels.VinHistoryWhere.Vin.EQ(vin)).All(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+
+	return record, history
+}
+
+func (s *StuckJobWorkerTestSuite) TestRecoversStuckVin() {
+	record := minted()
+	record.OnboardingStatus = OnboardingStatusBurnSDPending
+	s.insertStuck(record, 2*time.Hour)
+	s.insertJob(DisconnectArgs{}.Kind(), record.Vin, rivertype.JobStateDiscarded)
+
+	vehicle := onChain()
+	vehicle.SyntheticDevice = models.SyntheticDevice{}
+	s.identity.vehicles = []models.Vehicle{*vehicle}
+
+	s.Require().NoError(s.worker.Work(s.ctx, &river.Job[RecoverStuckArgs]{}))
+
+	recovered, history := s.load(record.Vin)
+	s.Equal(OnboardingStatusBurnSDSuccess, recovered.OnboardingStatus)
+	s.False(recovered.SyntheticTokenID.Valid)
+	s.Require().Len(history, 1)
+	s.Equal(VinHistoryEventStuckRecovery, history[0].Event)
+	s.Contains(history[0].Details.String, "status: BurnSDPending -> BurnSDSuccess, job: disconnect")
+}
+
+func (s *StuckJobWorkerTestSuite) TestReplacesPendingUserOperations() {
+	record := minted()
+	record.OnboardingStatus = OnboardingStatusBurnSDPending
+	s.insertStuck(record, 2*time.Hour)
+	s.insertJob(DisconnectArgs{}.Kind(), record.Vin, rivertype.JobStateDiscarded)
+	s.identity.vehicles = []models.Vehicle{*onChain()}
+
+	operations := []*dbmodels.VinUserOperation{
+		{Vin: record.Vin, Step: userOperationStepBurnSD, Hash: "0x01", UserOperation: "{}", Status: UserOperationStatusPending},
+		{Vin: record.Vin, Step: userOperationStepMintSD, Hash: "0x02", UserOperation: "{}", Status: UserOperationStatusMined},
+		{Vin: "1HGCM82633A004353", Step: userOperationStepBurnSD, Hash: "0x03", UserOperation: "{}", Status: UserOperationStatusPending},
+	}
+	for _, operation := range operations {
+		s.Require().NoError(operation.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+	}
+
+	s.Require().NoError(s.worker.Work(s.ctx, &river.Job[RecoverStuckArgs]{}))
+
+	recovered, _ := s.load(record.Vin)
+	s.Equal(OnboardingStatusBurnSDFailure, recovered.OnboardingStatus)
+
+	// the owner's next disconnect sends the newly signed operation instead of resuming the stale one
+	for hash, status := range map[string]string{
+		"0x01": UserOperationStatusReplaced,
+		"0x02": UserOperationStatusMined,
+		"0x03": UserOperationStatusPending,
+	} {
+		operation, err := dbmodels.VinUserOperations(dbmodels.VinUserOperationWhere.Hash.EQ(hash)).One(s.ctx, s.pdb.DBS().Reader)
+		s.Require().NoError(err)
+		s.Equal(status, operation.Status, hash)
+	}
+}
+
+func (s *StuckJobWorkerTestSuite) TestRecoversStuckPause() {
+	record := minted()
+	record.OnboardingStatus = OnboardingStatusResumePending
+	record.TelemetryPaused = true
+	s.insertStuck(record, 2*time.Hour)
+	s.insertJob(PauseArgs{}.Kind(), record.Vin, rivertype.JobStateDiscarded)
+
+	s.Require().NoError(s.worker.Work(s.ctx, &river.Job[RecoverStuckArgs]{}))
+
+	recovered, history := s.load(record.Vin)
+	s.Equal(OnboardingStatusResumeFailure, recovered.OnboardingStatus)
+	s.True(recovered.TelemetryPaused)
+	s.True(CanResume(recovered.OnboardingStatus))
+	s.Require().Len(history, 1)
+	s.Contains(history[0].Details.String, "status: ResumePending -> ResumeFailure, job: pause")
+}
+
+func (s *StuckJobWorkerTestSuite) TestSkipsLiveAndRecentVins() {
+	live := minted()
+	live.OnboardingStatus = OnboardingStatusBurnSDPending
+	s.insertStuck(live, 2*time.Hour)
+	s.insertJob(DisconnectArgs{}.Kind(), live.Vin, rivertype.JobStateRunning)
+
+	recent := minted()
+	recent.Vin = "1HGCM82633A004353"
+	recent.VehicleTokenID = null.Int64From(11)
+	recent.SyntheticTokenID = null.Int64From(21)
+	recent.OnboardingStatus = OnboardingStatusBurnSDPending
+	s.insertStuck(recent, time.Minute)
+
+	s.Require().NoError(s.worker.Work(s.ctx, &river.Job[RecoverStuckArgs]{}))
+
+	for _, vin := range []string{live.Vin, recent.Vin} {
+		record, history := s.load(vin)
+		s.Equal(OnboardingStatusBurnSDPending, record.OnboardingStatus, vin)
+		s.Empty(history, vin)
+	}
+}
+
+func (s *StuckJobWorkerTestSuite) TestLeavesAmbiguousMint() {
+	record := minted()
+	record.VehicleTokenID = null.Int64{}
+	record.SyntheticTokenID = null.Int64{}
+	record.OnboardingStatus = OnboardingStatusMintPending
+	s.insertStuck(record, 2*time.Hour)
+
+	// two vehicles of the owner with the VIN's definition, either could be the one the job minted
+	first, second := onChain(), onChain()
+	second.TokenID = 11
+	s.identity.vehicles = []models.Vehicle{*first, *second}
+
+	s.Require().NoError(s.worker.Work(s.ctx, &river.Job[RecoverStuckArgs]{}))
+
+	recovered, history := s.load(record.Vin)
+	s.Equal(OnboardingStatusMintPending, recovered.OnboardingStatus)
+	s.False(recovered.VehicleTokenID.Valid)
+	s.Empty(history)
+}
@@ -0,0 +1,198 @@
+package onboarding
+
+import (
+	"context"
+	"github.com/DIMO-Network/go-transactions"
+	"github.com/DIMO-Network/go-transactions/contracts/vehicleid"
+	"github.com/DIMO-Network/go-zerodev"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/friendsofgo/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/riverqueue/river"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	// SacdStatusSignatureRequired means the grant was requested, but the owner still has to sign the user operation
+	SacdStatusSignatureRequired = "signature_required"
+	SacdStatusPending           = "pending"
+	SacdStatusGranted           = "granted"
+	SacdStatusRevoked           = "revoked"
+	SacdStatusFailed            = "failed"
+)
+
+type SacdArgs struct {
+	VIN           string                 `json:"vin"`
+	Sacd          OnboardingSacd         `json:"sacd"`
+	UserOperation *zerodev.UserOperation `json:"userOperation"`
+}
+
+func (a SacdArgs) Kind() string {
+	return "sacd"
+}
+func (a SacdArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue:       QueueChain,
+		MaxAttempts: 1,
+		UniqueOpts: river.UniqueOpts{
+			ByArgs: false,
+		},
+	}
+}
+
+// IsSacdPending checks if a SACD job is already in flight for the grant
+func IsSacdPending(status string) bool {
+	return status == SacdStatusPending
+}
+
+// GetSetSacdCallData encodes the setSacd call on the vehicle contract, as executed by the owner's account.
+// Revoking is a grant with zero permissions.
+func GetSetSacdCallData(tr *transactions.Client, vehicleTokenID *big.Int, sacd OnboardingSacd) (*[]byte, error) {
+	callData := tr.VehicleId.PackSetSacd(vehicleTokenID, vehicleid.VehicleIdSacdInput{
+		Grantee:     sacd.Grantee,
+		Permissions: sacd.Permissions,
+		Expiration:  sacd.Expiration,
+		Source:      sacd.Source,
+	})
+
+	return zerodev.EncodeExecuteCall(&ethereum.CallMsg{
+		To:    &tr.VehicleIdAddress,
+		Value: big.NewInt(0),
+		Data:  callData,
+	})
+}
+
+// GetSetSacdUserOperationAndHash builds the user operation the vehicle owner has to sign to set SACD on the vehicle
+func GetSetSacdUserOperationAndHash(tr *transactions.Client, owner common.Address, vehicleTokenID *big.Int, sacd OnboardingSacd) (*zerodev.UserOperation, *common.Hash, error) {
+	encodedCall, err := GetSetSacdCallData(tr, vehicleTokenID, sacd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tr.ZerodevClient.GetUserOperationAndHashToSign(owner, encodedCall)
+}
+
+// NewVinSacdRecord creates the DB record holding the SACD grant state of the VIN for the grantee
+func NewVinSacdRecord(vin string, sacd OnboardingSacd, status string, grantErr error) *dbmodels.VinSacd {
+	record := &dbmodels.VinSacd{
+		Vin:         vin,
+		Grantee:     sacd.Grantee.Hex(),
+		Permissions: sacd.Permissions.String(),
+		Expiration:  sacd.Expiration.Int64(),
+		Source:      null.NewString(sacd.Source, sacd.Source != ""),
+		Status:      status,
+	}
+	if grantErr != nil {
+		record.Error = null.StringFrom(grantErr.Error())
+	}
+
+	return record
+}
+
+// SaveSacdGrant stores the SACD grant state of the VIN for the grantee
+func SaveSacdGrant(ctx context.Context, exec boil.ContextExecutor, vin string, sacd OnboardingSacd, status string, grantErr error) error {
+	return NewVinSacdRecord(vin, sacd, status, grantErr).Upsert(ctx, exec, true,
+		[]string{dbmodels.VinSacdColumns.Vin, dbmodels.VinSacdColumns.Grantee},
+		boil.Infer(),
+		boil.Infer(),
+	)
+}
+
+type SacdWorker struct {
+	settings *config.Settings
+	logger   zerolog.Logger
+	dbs      *db.Store
+	chains   *Chains
+	m        sync.RWMutex
+
+	river.WorkerDefaults[SacdArgs]
+}
+
+func NewSacdWorker(settings *config.Settings, logger zerolog.Logger, dbs *db.Store, chains *Chains) *SacdWorker {
+	return &SacdWorker{
+		settings: settings,
+		logger:   logger,
+		dbs:      dbs,
+		chains:   chains,
+	}
+}
+
+func (w *SacdWorker) Timeout(*river.Job[SacdArgs]) time.Duration { return 30 * time.Minute }
+
+func (w *SacdWorker) Work(ctx context.Context, job *river.Job[SacdArgs]) error {
+	normalizedVin, err := normalizeJobVin(job.Args.VIN)
+	if err != nil {
+		return err
+	}
+	job.Args.VIN = normalizedVin
+
+	w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Str("grantee", job.Args.Sacd.Grantee.Hex()).Msg("Setting SACD")
+
+	record, err := dbmodels.Vins(dbmodels.VinWhere.Vin.EQ(job.Args.VIN)).One(ctx, w.dbs.DBS().Reader)
+	if err != nil {
+		return err
+	}
+
+	if !record.VehicleTokenID.Valid {
+		err = errors.New("vehicle not minted")
+		w.save(ctx, job.Args, SacdStatusFailed, err)
+		return err
+	}
+
+	tr, err := w.chains.ForRecord(record)
+	if err != nil {
+		w.save(ctx, job.Args, SacdStatusFailed, err)
+		return river.JobCancel(err)
+	}
+
+	w.m.Lock()
+	result, err := tr.SendSignedUserOperation(job.Args.UserOperation, true)
+	w.m.Unlock()
+	if err != nil {
+		w.logger.Error().Err(err).Str(logfields.VIN, job.Args.VIN).Msg("Failed to set SACD")
+		w.save(ctx, job.Args, SacdStatusFailed, err)
+		return err
+	}
+
+	if result.Receipt == nil || result.Receipt.Status == nil || *result.Receipt.Status != 1 {
+		err = errors.New("set SACD user operation reverted")
+		w.logger.Error().Err(err).Str(logfields.VIN, job.Args.VIN).Msg("Failed to set SACD")
+		w.save(ctx, job.Args, SacdStatusFailed, err)
+		return err
+	}
+
+	status := SacdStatusGranted
+	if job.Args.Sacd.Permissions.Sign() == 0 {
+		status = SacdStatusRevoked
+	}
+
+	w.save(ctx, job.Args, status, nil)
+
+	w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Str("status", status).Msg("SACD set")
+
+	return nil
+}
+
+func (w *SacdWorker) save(ctx context.Context, args SacdArgs, status string, grantErr error) {
+	if err := SaveSacdGrant(ctx, w.dbs.DBS().Writer, args.VIN, args.Sacd, status, grantErr); err != nil {
+		w.logger.Error().Err(err).Str(logfields.VIN, args.VIN).Msgf("Failed to save SACD status %s", status)
+	}
+	sacdGrantsCntr.WithLabelValues(status).Inc()
+}
+
+// Prometheus metrics
+var sacdGrantsCntr = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "oracle_example_sacd_grants_total",
+	Help: "Total number of processed SACD grant jobs, by resulting status",
+}, []string{"status"})
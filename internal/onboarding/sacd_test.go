@@ -0,0 +1,54 @@
+package onboarding
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+	"math/big"
+	"testing"
+)
+
+type SacdTestSuite struct {
+	suite.Suite
+}
+
+func TestSacdTestSuite(t *testing.T) {
+	suite.Run(t, new(SacdTestSuite))
+}
+
+func (s *SacdTestSuite) sacd(source string) OnboardingSacd {
+	return OnboardingSacd{
+		Grantee:     common.HexToAddress("0x5e31bBc786D7bEd95216383787deA1ab0f1c1897"),
+		Permissions: big.NewInt(3),
+		Expiration:  big.NewInt(1893456000),
+		Source:      source,
+	}
+}
+
+func (s *SacdTestSuite) TestNewVinSacdRecord() {
+	record := NewVinSacdRecord("1HGCM82633A004352", s.sacd(""), SacdStatusPending, nil)
+
+	s.Equal("1HGCM82633A004352", record.Vin)
+	s.Equal("0x5e31bBc786D7bEd95216383787deA1ab0f1c1897", record.Grantee)
+	s.Equal("3", record.Permissions)
+	s.Equal(int64(1893456000), record.Expiration)
+	s.False(record.Source.Valid, "empty source is stored as null")
+	s.Equal(SacdStatusPending, record.Status)
+	s.False(record.Error.Valid)
+}
+
+func (s *SacdTestSuite) TestNewVinSacdRecordWithSourceAndError() {
+	record := NewVinSacdRecord("1HGCM82633A004352", s.sacd("ipfs://terms"), SacdStatusFailed, errors.New("user operation reverted"))
+
+	s.Equal("ipfs://terms", record.Source.String)
+	s.True(record.Source.Valid)
+	s.Equal(SacdStatusFailed, record.Status)
+	s.Equal("user operation reverted", record.Error.String)
+}
+
+func (s *SacdTestSuite) TestIsSacdPending() {
+	s.True(IsSacdPending(SacdStatusPending))
+	for _, status := range []string{SacdStatusSignatureRequired, SacdStatusGranted, SacdStatusRevoked, SacdStatusFailed} {
+		s.False(IsSacdPending(status), status)
+	}
+}
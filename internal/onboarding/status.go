@@ -1,5 +1,11 @@
 package onboarding
 
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
 const (
 	// 0-9 Initial status for submitting the job
 	OnboardingStatusSubmitUnknown = 0
@@ -64,6 +70,17 @@ const (
 	OnboardingStatusBurnVehiclePending = 101
 	OnboardingStatusBurnVehicleFailure = 102
 	OnboardingStatusBurnVehicleSuccess = 103
+
+	// 110-113 telemetry pause, vendor connection is suspended but SD stays minted
+	OnboardingStatusPauseUnknown = 110
+	OnboardingStatusPausePending = 111
+	OnboardingStatusPauseFailure = 112
+	OnboardingStatusPauseSuccess = 113
+
+	// 120-122 telemetry resume, on success VIN goes back to OnboardingStatusMintSuccess
+	OnboardingStatusResumeUnknown = 120
+	OnboardingStatusResumePending = 121
+	OnboardingStatusResumeFailure = 122
 )
 
 var statusToString = map[int]string{
@@ -111,6 +128,34 @@ var statusToString = map[int]string{
 	OnboardingStatusBurnVehiclePending:      "BurnVehiclePending",
 	OnboardingStatusBurnVehicleFailure:      "BurnVehicleFailure",
 	OnboardingStatusBurnVehicleSuccess:      "BurnVehicleSuccess",
+	OnboardingStatusPauseUnknown:            "PauseUnknown",
+	OnboardingStatusPausePending:            "PausePending",
+	OnboardingStatusPauseFailure:            "PauseFailure",
+	OnboardingStatusPauseSuccess:            "PauseSuccess",
+	OnboardingStatusResumeUnknown:           "ResumeUnknown",
+	OnboardingStatusResumePending:           "ResumePending",
+	OnboardingStatusResumeFailure:           "ResumeFailure",
+}
+
+// OnboardingPhases maps onboarding phases to their inclusive status ranges
+var OnboardingPhases = map[string][2]int{
+	"verification": {OnboardingStatusSubmitUnknown, OnboardingStatusVendorValidationSuccess},
+	"minting":      {OnboardingStatusMintSubmitUnknown, OnboardingStatusMintFailure},
+	"minted":       {OnboardingStatusMintSuccess, OnboardingStatusMintSuccess},
+	"disconnect":   {OnboardingStatusDisconnectSubmitUnknown, OnboardingStatusBurnSDSuccess},
+	"delete":       {OnboardingStatusDeleteSubmitUnknown, OnboardingStatusBurnVehicleSuccess},
+	"paused":       {OnboardingStatusPauseUnknown, OnboardingStatusResumeFailure},
+}
+
+// GetPhase returns the onboarding phase of the status
+func GetPhase(status int) string {
+	for phase, statusRange := range OnboardingPhases {
+		if status >= statusRange[0] && status <= statusRange[1] {
+			return phase
+		}
+	}
+
+	return "unknown"
 }
 
 func IsVerified(status int) bool {
@@ -149,6 +194,29 @@ func IsBurnPending(status int) bool {
 	return status > OnboardingStatusDeleteSubmitUnknown && status < OnboardingStatusBurnVehicleSuccess
 }
 
+// IsPaused checks if telemetry of the VIN is paused, a failed resume leaves the VIN paused
+func IsPaused(status int) bool {
+	return status == OnboardingStatusPauseSuccess || status == OnboardingStatusResumeFailure
+}
+
+// IsPausePhase checks if the VIN is in one of the pause or resume statuses, its SD is still minted
+func IsPausePhase(status int) bool {
+	return status >= OnboardingStatusPauseUnknown && status <= OnboardingStatusResumeFailure
+}
+
+func IsPausePending(status int) bool {
+	return status == OnboardingStatusPauseUnknown || status == OnboardingStatusPausePending ||
+		status == OnboardingStatusResumeUnknown || status == OnboardingStatusResumePending
+}
+
+func CanPause(status int) bool {
+	return IsMinted(status) || status == OnboardingStatusPauseFailure
+}
+
+func CanResume(status int) bool {
+	return IsPaused(status)
+}
+
 func GetVerificationStatus(status int) string {
 	if IsVerified(status) {
 		return "Success"
@@ -166,7 +234,8 @@ func GetVerificationStatus(status int) string {
 }
 
 func GetMintStatus(status int) string {
-	if status == OnboardingStatusMintSuccess {
+	// a paused VIN, or one being paused or resumed, keeps its minted SD
+	if status == OnboardingStatusMintSuccess || IsPausePhase(status) {
 		return "Success"
 	}
 
@@ -221,3 +290,50 @@ func GetDetailedStatus(status int) string {
 
 	return detailedStatus
 }
+
+// ParseStatus parses a detailed status name, as returned by GetDetailedStatus, or a status number
+func ParseStatus(name string) (int, bool) {
+	for status, statusName := range statusToString {
+		if strings.EqualFold(statusName, name) || strconv.Itoa(status) == name {
+			return status, true
+		}
+	}
+
+	return 0, false
+}
+
+// RetryableStatuses are the statuses a VIN can be reset to by operators: each one is accepted by the API to start or
+// retry the next step, so the owner can resubmit after the reset
+var RetryableStatuses = []int{
+	OnboardingStatusSubmitFailure,
+	OnboardingStatusVendorValidationSuccess,
+	OnboardingStatusMintFailure,
+	OnboardingStatusMintSuccess,
+	OnboardingStatusBurnSDFailure,
+	OnboardingStatusBurnSDSuccess,
+	OnboardingStatusBurnVehicleFailure,
+}
+
+func IsRetryable(status int) bool {
+	return slices.Contains(RetryableStatuses, status)
+}
+
+func GetPauseStatus(status int) string {
+	if IsPaused(status) {
+		return "Paused"
+	}
+
+	if IsMinted(status) {
+		return "Active"
+	}
+
+	if IsPausePending(status) {
+		return "Pending"
+	}
+
+	if status == OnboardingStatusPauseFailure {
+		return "Failure"
+	}
+
+	return "Unknown"
+}
@@ -0,0 +1,82 @@
+package onboarding
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type StatusTestSuite struct {
+	suite.Suite
+}
+
+func TestStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusTestSuite))
+}
+
+func (s *StatusTestSuite) TestGetMintStatus() {
+	tests := []struct {
+		status   int
+		expected string
+	}{
+		{OnboardingStatusSubmitUnknown, "Unknown"},
+		{OnboardingStatusMintSubmitPending, "Pending"},
+		{OnboardingStatusMintPending, "Pending"},
+		{OnboardingStatusMintFailure, "Failure"},
+		{OnboardingStatusMintSuccess, "Success"},
+		{OnboardingStatusPauseUnknown, "Success"},
+		{OnboardingStatusPausePending, "Success"},
+		{OnboardingStatusPauseFailure, "Success"},
+		{OnboardingStatusPauseSuccess, "Success"},
+		{OnboardingStatusResumeUnknown, "Success"},
+		{OnboardingStatusResumePending, "Success"},
+		{OnboardingStatusResumeFailure, "Success"},
+		{OnboardingStatusBurnSDFailure, "Failure"},
+		{OnboardingStatusBurnSDSuccess, "Unknown"},
+	}
+
+	for _, tt := range tests {
+		s.Equal(tt.expected, GetMintStatus(tt.status), GetDetailedStatus(tt.status))
+	}
+}
+
+func (s *StatusTestSuite) TestGetPauseStatus() {
+	tests := []struct {
+		status   int
+		expected string
+	}{
+		{OnboardingStatusMintPending, "Unknown"},
+		{OnboardingStatusMintSuccess, "Active"},
+		{OnboardingStatusPauseUnknown, "Pending"},
+		{OnboardingStatusPausePending, "Pending"},
+		{OnboardingStatusPauseFailure, "Failure"},
+		{OnboardingStatusPauseSuccess, "Paused"},
+		{OnboardingStatusResumeUnknown, "Pending"},
+		{OnboardingStatusResumePending, "Pending"},
+		{OnboardingStatusResumeFailure, "Paused"},
+		{OnboardingStatusBurnSDSuccess, "Unknown"},
+	}
+
+	for _, tt := range tests {
+		s.Equal(tt.expected, GetPauseStatus(tt.status), GetDetailedStatus(tt.status))
+	}
+}
+
+func (s *StatusTestSuite) TestPauseHelpers() {
+	for status := range statusToString {
+		inPhase := status >= OnboardingStatusPauseUnknown && status <= OnboardingStatusResumeFailure
+		s.Equal(inPhase, IsPausePhase(status), GetDetailedStatus(status))
+		s.Equal(inPhase, GetPhase(status) == "paused", GetDetailedStatus(status))
+		// a VIN in the pause phase is neither pending mint nor pending disconnect
+		if inPhase {
+			s.False(IsPending(status), GetDetailedStatus(status))
+			s.False(IsDisconnectPending(status), GetDetailedStatus(status))
+		}
+	}
+
+	s.True(CanPause(OnboardingStatusMintSuccess))
+	s.True(CanPause(OnboardingStatusPauseFailure))
+	s.False(CanPause(OnboardingStatusPauseSuccess))
+	s.True(CanResume(OnboardingStatusPauseSuccess))
+	s.True(CanResume(OnboardingStatusResumeFailure))
+	s.False(CanResume(OnboardingStatusMintSuccess))
+}
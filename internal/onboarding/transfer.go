@@ -0,0 +1,160 @@
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const (
+	// TransferPolicyNotify only records and logs the transfer, data keeps flowing
+	TransferPolicyNotify = "notify"
+	// TransferPolicyPause stops forwarding telemetry until the new owner resumes it
+	TransferPolicyPause = "pause"
+	// TransferPolicyDisconnect disconnects the vehicle in the vendor system and pauses telemetry. Burning the SD requires
+	// the owner's signature, the oracle can't sign for the new owner, so the VIN is left paused with its SD: the new owner
+	// burns it through the disconnect endpoints, or resumes the VIN to consent to the connection, which connects it in the
+	// vendor system again.
+	TransferPolicyDisconnect = "disconnect"
+)
+
+const (
+	VinHistoryEventTransfer = "transfer"
+	VinHistoryEventPause    = "pause"
+	VinHistoryEventResume   = "resume"
+)
+
+// TransferHandler applies the configured policy when a vehicle NFT changes owner outside of this oracle
+type TransferHandler struct {
+	settings *config.Settings
+	logger   zerolog.Logger
+	dbs      *db.Store
+	vendor   VendorOnboardingAPI
+	switches *VendorSwitches
+}
+
+func NewTransferHandler(settings *config.Settings, logger zerolog.Logger, dbs *db.Store, vendor VendorOnboardingAPI, switches *VendorSwitches) *TransferHandler {
+	return &TransferHandler{
+		settings: settings,
+		logger:   logger,
+		dbs:      dbs,
+		vendor:   vendor,
+		switches: switches,
+	}
+}
+
+// Policy returns the configured transfer policy, falling back to notify for empty or unknown values
+func (t *TransferHandler) Policy() string {
+	switch t.settings.TransferPolicy {
+	case TransferPolicyPause, TransferPolicyDisconnect:
+		return t.settings.TransferPolicy
+	default:
+		return TransferPolicyNotify
+	}
+}
+
+// HandleTransfer records the previous owner in the VIN history, stores the new owner and applies the transfer policy
+func (t *TransferHandler) HandleTransfer(ctx context.Context, record *dbmodels.Vin, newOwner common.Address) error {
+	policy := t.Policy()
+	previousOwner := record.OwnerAddress
+
+	t.logger.Warn().Str(logfields.VIN, record.Vin).Str("previousOwner", previousOwner.String).
+		Str("newOwner", newOwner.Hex()).Str("policy", policy).Msg("Vehicle ownership transfer detected")
+
+	columns, err := t.applyPolicy(policy, record, newOwner)
+	if err != nil {
+		return err
+	}
+
+	tx, err := t.dbs.DBS().Writer.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		t.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				t.logger.Error().Err(rbErr).Msg("Failed to rollback transaction")
+			}
+		}
+	}()
+
+	if _, err = record.Update(ctx, tx, boil.Whitelist(columns...)); err != nil {
+		return fmt.Errorf("failed to update VIN record: %w", err)
+	}
+
+	history := dbmodels.VinHistory{
+		Vin:           record.Vin,
+		Event:         VinHistoryEventTransfer,
+		PreviousOwner: previousOwner,
+		NewOwner:      null.StringFrom(newOwner.Hex()),
+		Details:       null.StringFrom("policy: " + policy),
+	}
+	if err = history.Insert(ctx, tx, boil.Infer()); err != nil {
+		return fmt.Errorf("failed to insert VIN history: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		t.logger.Error().Err(err).Msg("Failed to commit transaction")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	vehicleTransfersCntr.WithLabelValues(policy).Inc()
+
+	return nil
+}
+
+// applyPolicy changes the VIN record for the new owner according to the policy, calling the vendor if needed, and returns
+// the changed columns. The VIN always ends in a status no job or stuck detection works on.
+func (t *TransferHandler) applyPolicy(policy string, record *dbmodels.Vin, newOwner common.Address) ([]string, error) {
+	columns := []string{dbmodels.VinColumns.OwnerAddress}
+	record.OwnerAddress = null.StringFrom(newOwner.Hex())
+
+	switch policy {
+	case TransferPolicyPause:
+		record.TelemetryPaused = true
+		columns = append(columns, dbmodels.VinColumns.TelemetryPaused)
+		if CanPause(record.OnboardingStatus) {
+			record.OnboardingStatus = OnboardingStatusPauseSuccess
+			columns = append(columns, dbmodels.VinColumns.OnboardingStatus)
+		}
+	case TransferPolicyDisconnect:
+		if t.switches.Connection() {
+			if _, err := t.vendor.Disconnect([]string{record.Vin}); err != nil {
+				t.logger.Error().Err(err).Str(logfields.VIN, record.Vin).Msg("Failed to disconnect transferred vehicle from vendor")
+				return nil, err
+			}
+		}
+
+		record.TelemetryPaused = true
+		record.DisconnectionStatus = null.StringFrom("succeeded")
+		record.ConnectionStatus = null.String{}
+		columns = append(columns,
+			dbmodels.VinColumns.TelemetryPaused,
+			dbmodels.VinColumns.DisconnectionStatus,
+			dbmodels.VinColumns.ConnectionStatus,
+		)
+		if CanPause(record.OnboardingStatus) {
+			record.OnboardingStatus = OnboardingStatusPauseSuccess
+			columns = append(columns, dbmodels.VinColumns.OnboardingStatus)
+		}
+	}
+
+	return columns, nil
+}
+
+// Prometheus metrics
+var vehicleTransfersCntr = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "oracle_example_vehicle_transfers_total",
+	Help: "Total number of vehicle ownership transfers detected, by applied policy",
+}, []string{"policy"})
@@ -0,0 +1,152 @@
+package onboarding
+
+import (
+	"errors"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/volatiletech/null/v8"
+	"testing"
+)
+
+// fakeVendor records the VINs of each call, calls fail with err when set
+type fakeVendor struct {
+	calls map[string][]string
+	err   error
+}
+
+func newFakeVendor() *fakeVendor {
+	return &fakeVendor{calls: make(map[string][]string)}
+}
+
+func (f *fakeVendor) call(name string, vins []string) ([]VendorConnectionStatus, error) {
+	f.calls[name] = append(f.calls[name], vins...)
+	if f.err != nil {
+		return nil, f.err
+	}
+	statuses := make([]VendorConnectionStatus, 0, len(vins))
+	for _, vin := range vins {
+		statuses = append(statuses, VendorConnectionStatus{VIN: vin, Status: "succeeded"})
+	}
+	return statuses, nil
+}
+
+func (f *fakeVendor) Validate(vins []string) ([]VendorCapabilityStatus, error) {
+	f.calls["validate"] = append(f.calls["validate"], vins...)
+	return nil, f.err
+}
+func (f *fakeVendor) Connect(vins []string) ([]VendorConnectionStatus, error) {
+	return f.call("connect", vins)
+}
+func (f *fakeVendor) Disconnect(vins []string) ([]VendorConnectionStatus, error) {
+	return f.call("disconnect", vins)
+}
+func (f *fakeVendor) Suspend(vins []string) ([]VendorConnectionStatus, error) {
+	return f.call("suspend", vins)
+}
+func (f *fakeVendor) Resume(vins []string) ([]VendorConnectionStatus, error) {
+	return f.call("resume", vins)
+}
+
+func vendorSwitches(connection bool) *VendorSwitches {
+	settings := config.Settings{EnableVendorConnection: connection}
+	return NewVendorSwitches(config.NewProvider("settings.yaml", settings, zerolog.Nop()))
+}
+
+type TransferTestSuite struct {
+	suite.Suite
+	vendor *fakeVendor
+}
+
+func TestTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(TransferTestSuite))
+}
+
+func (s *TransferTestSuite) SetupTest() {
+	s.vendor = newFakeVendor()
+}
+
+func (s *TransferTestSuite) handler(policy string) *TransferHandler {
+	return NewTransferHandler(&config.Settings{TransferPolicy: policy}, zerolog.Nop(), nil, s.vendor, vendorSwitches(true))
+}
+
+func (s *TransferTestSuite) TestPolicy() {
+	s.Equal(TransferPolicyNotify, s.handler("").Policy())
+	s.Equal(TransferPolicyNotify, s.handler("burn").Policy())
+	s.Equal(TransferPolicyPause, s.handler("pause").Policy())
+	s.Equal(TransferPolicyDisconnect, s.handler("disconnect").Policy())
+}
+
+func (s *TransferTestSuite) TestNotify() {
+	record := minted()
+	handler := s.handler(TransferPolicyNotify)
+
+	columns, err := handler.applyPolicy(handler.Policy(), record, common.HexToAddress(testNewOwner))
+	s.Require().NoError(err)
+	s.Equal([]string{dbmodels.VinColumns.OwnerAddress}, columns)
+	s.Equal(common.HexToAddress(testNewOwner).Hex(), record.OwnerAddress.String)
+	s.Equal(OnboardingStatusMintSuccess, record.OnboardingStatus)
+	s.False(record.TelemetryPaused)
+	s.Empty(s.vendor.calls)
+}
+
+func (s *TransferTestSuite) TestPause() {
+	record := minted()
+	handler := s.handler(TransferPolicyPause)
+
+	columns, err := handler.applyPolicy(handler.Policy(), record, common.HexToAddress(testNewOwner))
+	s.Require().NoError(err)
+	s.Contains(columns, dbmodels.VinColumns.OnboardingStatus)
+	s.Equal(OnboardingStatusPauseSuccess, record.OnboardingStatus)
+	s.True(record.TelemetryPaused)
+	s.Empty(s.vendor.calls)
+
+	// a VIN that is not minted only gets its telemetry paused
+	record = minted()
+	record.OnboardingStatus = OnboardingStatusBurnSDFailure
+	_, err = handler.applyPolicy(handler.Policy(), record, common.HexToAddress(testNewOwner))
+	s.Require().NoError(err)
+	s.Equal(OnboardingStatusBurnSDFailure, record.OnboardingStatus)
+	s.True(record.TelemetryPaused)
+}
+
+func (s *TransferTestSuite) TestDisconnect() {
+	record := minted()
+	record.ConnectionStatus = null.StringFrom("succeeded")
+	handler := s.handler(TransferPolicyDisconnect)
+
+	_, err := handler.applyPolicy(handler.Policy(), record, common.HexToAddress(testNewOwner))
+	s.Require().NoError(err)
+	s.Equal([]string{record.Vin}, s.vendor.calls["disconnect"])
+	s.True(record.TelemetryPaused)
+	s.Equal("succeeded", record.DisconnectionStatus.String)
+	s.False(record.ConnectionStatus.Valid)
+	// the SD stays, the VIN must end in a status that neither looks pending nor is picked by the stuck detector
+	s.Equal(OnboardingStatusPauseSuccess, record.OnboardingStatus)
+	s.True(record.SyntheticTokenID.Valid)
+	s.False(IsDisconnectPending(record.OnboardingStatus))
+	for _, statuses := range stuckStatuses {
+		s.NotContains(statuses, record.OnboardingStatus)
+	}
+}
+
+func (s *TransferTestSuite) TestDisconnectVendorFailure() {
+	s.vendor.err = errors.New("vendor down")
+	record := minted()
+	handler := s.handler(TransferPolicyDisconnect)
+
+	_, err := handler.applyPolicy(handler.Policy(), record, common.HexToAddress(testNewOwner))
+	s.Error(err)
+}
+
+func (s *TransferTestSuite) TestDisconnectWithoutVendorConnection() {
+	record := minted()
+	handler := NewTransferHandler(&config.Settings{TransferPolicy: TransferPolicyDisconnect}, zerolog.Nop(), nil, s.vendor, vendorSwitches(false))
+
+	_, err := handler.applyPolicy(handler.Policy(), record, common.HexToAddress(testNewOwner))
+	s.Require().NoError(err)
+	s.Empty(s.vendor.calls)
+	s.Equal(OnboardingStatusPauseSuccess, record.OnboardingStatus)
+}
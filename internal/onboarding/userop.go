@@ -0,0 +1,290 @@
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/go-transactions"
+	"github.com/DIMO-Network/go-zerodev"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/riverqueue/river"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"math/big"
+	"time"
+)
+
+const (
+	UserOperationStatusPending  = "pending"
+	UserOperationStatusMined    = "mined"
+	UserOperationStatusReverted = "reverted"
+	UserOperationStatusReplaced = "replaced"
+)
+
+// chain-side steps of the workers, a step has at most one pending user operation per VIN
+const (
+	userOperationStepMintVehicle = "mint_vehicle"
+	userOperationStepMintSD      = "mint_sd"
+	userOperationStepBurnSD      = "burn_sd"
+	userOperationStepBurnVehicle = "burn_vehicle"
+)
+
+// chainStepMaxAttempts is the number of attempts of the jobs sending user operations, retried with river's backoff
+const chainStepMaxAttempts = 5
+
+var (
+	errUserOperationRejected    = errors.New("user operation rejected by the bundler")
+	errUserOperationUnconfirmed = errors.New("user operation sent but not confirmed yet")
+	errUserOperationReverted    = errors.New("user operation reverted")
+	errUserOperationNonceUsed   = errors.New("user operation nonce used by another operation")
+)
+
+// userOperations sends the user operations of chain-side worker steps. Every operation is stored with its hash before
+// it's sent, so a retried job checks the receipt of the operation it already sent instead of sending a second one.
+type userOperations struct {
+	logger zerolog.Logger
+	dbs    *db.Store
+	tr     *transactions.Client
+}
+
+func newUserOperations(logger zerolog.Logger, dbs *db.Store, tr *transactions.Client) *userOperations {
+	return &userOperations{
+		logger: logger,
+		dbs:    dbs,
+		tr:     tr,
+	}
+}
+
+// sendOwn sends registry call data from the oracle account. buildCallData is only called when the step has no
+// pending operation to resume.
+func (u *userOperations) sendOwn(ctx context.Context, vin, step string, buildCallData func() ([]byte, error)) (*zerodev.UserOperationResult, error) {
+	return u.send(ctx, vin, step, func() (*zerodev.UserOperation, *common.Hash, error) {
+		callData, err := buildCallData()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		executeCallData, err := zerodev.EncodeExecuteCall(&ethereum.CallMsg{
+			To:    &u.tr.RegistryAddress,
+			Value: big.NewInt(0),
+			Data:  callData,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode execute call: %w", err)
+		}
+
+		zd := u.tr.ZerodevClient
+		op, hash, err := zd.GetUserOperationAndHashToSign(zd.Signer.GetAddress(), executeCallData)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to build user operation: %w", err)
+		}
+
+		op.Signature, err = zd.Signer.SignUserOperationHash(*hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to sign user operation: %w", err)
+		}
+
+		return op, hash, nil
+	})
+}
+
+// sendSigned sends a user operation signed by the vehicle owner
+func (u *userOperations) sendSigned(ctx context.Context, vin, step string, op *zerodev.UserOperation) (*zerodev.UserOperationResult, error) {
+	return u.send(ctx, vin, step, func() (*zerodev.UserOperation, *common.Hash, error) {
+		hash, err := u.tr.ZerodevClient.EntryPoint.GetUserOperationHash(op)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to hash user operation: %w", err)
+		}
+
+		return op, hash, nil
+	})
+}
+
+func (u *userOperations) send(ctx context.Context, vin, step string, build func() (*zerodev.UserOperation, *common.Hash, error)) (*zerodev.UserOperationResult, error) {
+	pending, err := dbmodels.VinUserOperations(
+		dbmodels.VinUserOperationWhere.Vin.EQ(vin),
+		dbmodels.VinUserOperationWhere.Step.EQ(step),
+		dbmodels.VinUserOperationWhere.Status.EQ(UserOperationStatusPending),
+	).One(ctx, u.dbs.DBS().Reader)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load pending user operation: %w", err)
+	}
+
+	if pending == nil {
+		op, hash, err := build()
+		if err != nil {
+			return nil, err
+		}
+		return u.submit(ctx, vin, step, op, *hash)
+	}
+
+	u.logger.Info().Str(logfields.VIN, vin).Str("step", step).Str("userOperationHash", pending.Hash).Msg("Resuming pending user operation")
+
+	result, err := u.resume(ctx, pending)
+	if !errors.Is(err, errUserOperationRejected) && !errors.Is(err, errUserOperationNonceUsed) {
+		return result, err
+	}
+
+	op, hash, buildErr := build()
+	if buildErr != nil {
+		return nil, buildErr
+	}
+	if hash.Hex() == pending.Hash {
+		return nil, err
+	}
+
+	// the pending operation can't be mined anymore: either its nonce is still unused and the new operation takes it,
+	// or another operation already used it
+	if pending.Status != UserOperationStatusReplaced {
+		if err := u.setStatus(ctx, pending, UserOperationStatusReplaced); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.submit(ctx, vin, step, op, *hash)
+}
+
+// submit stores the operation as pending and sends it
+func (u *userOperations) submit(ctx context.Context, vin, step string, op *zerodev.UserOperation, hash common.Hash) (*zerodev.UserOperationResult, error) {
+	opJSON, err := json.Marshal(op)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode user operation: %w", err)
+	}
+
+	record := &dbmodels.VinUserOperation{
+		Vin:           vin,
+		Step:          step,
+		Hash:          hash.Hex(),
+		UserOperation: string(opJSON),
+		Status:        UserOperationStatusPending,
+	}
+	if err := record.Insert(ctx, u.dbs.DBS().Writer, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed to save user operation: %w", err)
+	}
+
+	return u.sendAndWait(ctx, record, op)
+}
+
+// resume checks the receipt of a pending operation and sends it again if its nonce is still unused. An operation
+// without receipt whose nonce was used is marked replaced, so the step can be built again.
+func (u *userOperations) resume(ctx context.Context, record *dbmodels.VinUserOperation) (*zerodev.UserOperationResult, error) {
+	receipt, err := u.receipt(ctx, record.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt != nil {
+		return u.confirm(ctx, record, receipt)
+	}
+
+	op := &zerodev.UserOperation{}
+	if err := json.Unmarshal([]byte(record.UserOperation), op); err != nil {
+		return nil, fmt.Errorf("failed to decode user operation: %w", err)
+	}
+
+	nonce, err := u.tr.ZerodevClient.EntryPoint.GetNonce(op.Sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account nonce: %w", err)
+	}
+	if nonce.Cmp(op.Nonce) > 0 {
+		// the operation has no receipt, so another one used the nonce. Operations of the oracle share its account nonce,
+		// e.g. when the first send of this one was rejected and another VIN's operation was mined meanwhile.
+		if err := u.setStatus(ctx, record, UserOperationStatusReplaced); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", errUserOperationNonceUsed, record.Hash)
+	}
+
+	return u.sendAndWait(ctx, record, op)
+}
+
+func (u *userOperations) sendAndWait(ctx context.Context, record *dbmodels.VinUserOperation, op *zerodev.UserOperation) (*zerodev.UserOperationResult, error) {
+	zd := u.tr.ZerodevClient
+	if _, err := zd.SendSignedUserOperation(op, false); err != nil {
+		return nil, fmt.Errorf("%w: %w", errUserOperationRejected, err)
+	}
+
+	for i := 0; i < zd.ReceiptPollingRetries; i++ {
+		// the operation was accepted, so failures from here on leave it pending
+		receipt, err := u.receipt(ctx, record.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errUserOperationUnconfirmed, err)
+		}
+		if receipt != nil {
+			return u.confirm(ctx, record, receipt)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", errUserOperationUnconfirmed, ctx.Err())
+		case <-time.After(time.Duration(zd.ReceiptPollingDelay) * time.Second):
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errUserOperationUnconfirmed, record.Hash)
+}
+
+// receipt returns the receipt of the operation, or nil if the bundler doesn't know it as mined
+func (u *userOperations) receipt(ctx context.Context, hash string) (*zerodev.GetUserOperationReceiptResponse, error) {
+	response := &zerodev.GetUserOperationReceiptResponse{}
+	if err := u.tr.ZerodevClient.BundlerClient.Client.CallContext(ctx, response, "eth_getUserOperationReceipt", hash); err != nil {
+		return nil, fmt.Errorf("failed to get user operation receipt: %w", err)
+	}
+	if response.UserOpHash == nil {
+		return nil, nil
+	}
+
+	return response, nil
+}
+
+// confirm records the outcome of a mined operation. The result only carries the operation's own logs, not the
+// logs of other operations in the same bundle.
+func (u *userOperations) confirm(ctx context.Context, record *dbmodels.VinUserOperation, response *zerodev.GetUserOperationReceiptResponse) (*zerodev.UserOperationResult, error) {
+	status := UserOperationStatusMined
+	if !response.Success {
+		status = UserOperationStatusReverted
+	}
+	if err := u.setStatus(ctx, record, status); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, fmt.Errorf("%w: %s", errUserOperationReverted, record.Hash)
+	}
+
+	receipt := response.Receipt
+	receipt.Logs = response.Logs
+
+	return &zerodev.UserOperationResult{
+		UserOperationHash: common.FromHex(record.Hash),
+		Receipt:           &receipt,
+	}, nil
+}
+
+func (u *userOperations) setStatus(ctx context.Context, record *dbmodels.VinUserOperation, status string) error {
+	record.Status = status
+	if _, err := record.Update(ctx, u.dbs.DBS().Writer, boil.Whitelist(dbmodels.VinUserOperationColumns.Status)); err != nil {
+		return fmt.Errorf("failed to update user operation %s: %w", record.Hash, err)
+	}
+
+	return nil
+}
+
+// chainStepFailure returns the status and error of a failed chain-side step. Operations that may still be mined
+// keep the pending status, reverted operations and signed operations whose nonce was used fail the step without
+// further retries.
+func chainStepFailure(err error, pendingStatus, failureStatus int) (int, error) {
+	switch {
+	case errors.Is(err, errUserOperationUnconfirmed):
+		return pendingStatus, err
+	case errors.Is(err, errUserOperationReverted), errors.Is(err, errUserOperationNonceUsed):
+		return failureStatus, river.JobCancel(err)
+	default:
+		return failureStatus, err
+	}
+}
@@ -0,0 +1,303 @@
+package onboarding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/go-transactions"
+	"github.com/DIMO-Network/go-zerodev"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/test"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/riverqueue/river"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"math/big"
+	"testing"
+)
+
+const (
+	migrationsDirRelPath = "../db/migrations"
+	userOpTestVin        = "1HGCM82633A004352"
+)
+
+// fakeEntryPoint hashes user operations locally and returns a fixed account nonce
+type fakeEntryPoint struct {
+	nonce *big.Int
+}
+
+func (f *fakeEntryPoint) GetAddress() common.Address {
+	return common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+}
+
+func (f *fakeEntryPoint) GetNonce(_ common.Address) (*big.Int, error) {
+	return f.nonce, nil
+}
+
+func (f *fakeEntryPoint) GetUserOperationHash(op *zerodev.UserOperation) (*common.Hash, error) {
+	opJSON, err := json.Marshal(op)
+	if err != nil {
+		return nil, err
+	}
+	hash := crypto.Keccak256Hash(opJSON)
+	return &hash, nil
+}
+
+func (f *fakeEntryPoint) PackUserOperation(_ *zerodev.UserOperation) ([]byte, error) {
+	return nil, nil
+}
+
+// fakeBundler answers the bundler RPC calls. Sent operations are mined right away when mine is set, and reverted
+// when revert is set too.
+type fakeBundler struct {
+	entryPoint *fakeEntryPoint
+	sent       []string
+	rejected   map[string]bool
+	receipts   map[string]*zerodev.GetUserOperationReceiptResponse
+	mine       bool
+	revert     bool
+}
+
+func newFakeBundler(entryPoint *fakeEntryPoint) *fakeBundler {
+	return &fakeBundler{
+		entryPoint: entryPoint,
+		rejected:   make(map[string]bool),
+		receipts:   make(map[string]*zerodev.GetUserOperationReceiptResponse),
+	}
+}
+
+func (f *fakeBundler) CallContext(_ context.Context, result interface{}, method string, args ...interface{}) error {
+	switch method {
+	case "eth_sendUserOperation":
+		hash, err := f.entryPoint.GetUserOperationHash(args[0].(*zerodev.UserOperation))
+		if err != nil {
+			return err
+		}
+
+		f.sent = append(f.sent, hash.Hex())
+		if f.rejected[hash.Hex()] {
+			return errors.New("AA25 invalid account nonce")
+		}
+		if f.mine {
+			f.receipt(hash.Hex(), !f.revert)
+		}
+
+		*result.(*hexutil.Bytes) = hash.Bytes()
+	case "eth_getUserOperationReceipt":
+		if receipt, ok := f.receipts[args[0].(string)]; ok {
+			*result.(*zerodev.GetUserOperationReceiptResponse) = *receipt
+		}
+	default:
+		return fmt.Errorf("unexpected bundler call %s", method)
+	}
+
+	return nil
+}
+
+func (f *fakeBundler) Close() {}
+
+// receipt marks the operation as mined
+func (f *fakeBundler) receipt(hash string, success bool) {
+	userOpHash := hexutil.Bytes(common.FromHex(hash))
+	f.receipts[hash] = &zerodev.GetUserOperationReceiptResponse{
+		UserOpHash: &userOpHash,
+		Success:    success,
+	}
+}
+
+type UserOperationsTestSuite struct {
+	suite.Suite
+	pdb        db.Store
+	container  testcontainers.Container
+	ctx        context.Context
+	entryPoint *fakeEntryPoint
+	bundler    *fakeBundler
+	ops        *userOperations
+}
+
+func TestUserOperationsTestSuite(t *testing.T) {
+	suite.Run(t, new(UserOperationsTestSuite))
+}
+
+// SetupSuite starts container db
+func (s *UserOperationsTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container, _ = test.StartContainerDatabase(s.ctx, s.T(), migrationsDirRelPath)
+}
+
+func (s *UserOperationsTestSuite) SetupTest() {
+	s.entryPoint = &fakeEntryPoint{nonce: big.NewInt(1)}
+	s.bundler = newFakeBundler(s.entryPoint)
+
+	tr := &transactions.Client{
+		ZerodevClient: &zerodev.Client{
+			EntryPoint: s.entryPoint,
+			BundlerClient: &zerodev.BundlerClient{
+				Client:     s.bundler,
+				EntryPoint: s.entryPoint,
+				ChainID:    big.NewInt(80002),
+			},
+			ReceiptPollingRetries: 1,
+		},
+	}
+	s.ops = newUserOperations(zerolog.Nop(), &s.pdb, tr)
+}
+
+// TearDownTest after each test truncate tables
+func (s *UserOperationsTestSuite) TearDownTest() {
+	test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+// TearDownSuite cleanup at end by terminating container
+func (s *UserOperationsTestSuite) TearDownSuite() {
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+// signedOp returns an operation of the vehicle owner, different signatures give different hashes
+func (s *UserOperationsTestSuite) signedOp(nonce int64, signature byte) (*zerodev.UserOperation, string) {
+	op := &zerodev.UserOperation{
+		Sender:               common.HexToAddress(testOwner),
+		Nonce:                big.NewInt(nonce),
+		CallData:             []byte{1},
+		MaxFeePerGas:         big.NewInt(1),
+		MaxPriorityFeePerGas: big.NewInt(1),
+		Signature:            []byte{signature},
+	}
+
+	hash, err := s.entryPoint.GetUserOperationHash(op)
+	s.Require().NoError(err)
+
+	return op, hash.Hex()
+}
+
+// insertPending stores the operation as sent by an earlier attempt of the job
+func (s *UserOperationsTestSuite) insertPending(op *zerodev.UserOperation, hash string) {
+	opJSON, err := json.Marshal(op)
+	s.Require().NoError(err)
+
+	record := &dbmodels.VinUserOperation{
+		Vin:           userOpTestVin,
+		Step:          userOperationStepBurnSD,
+		Hash:          hash,
+		UserOperation: string(opJSON),
+		Status:        UserOperationStatusPending,
+	}
+	s.Require().NoError(record.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+}
+
+func (s *UserOperationsTestSuite) status(hash string) string {
+	record, err := dbmodels.VinUserOperations(dbmodels.VinUserOperationWhere.Hash.EQ(hash)).One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+
+	return record.Status
+}
+
+func (s *UserOperationsTestSuite) TestNoPendingOperation() {
+	s.bundler.mine = true
+	op, hash := s.signedOp(1, 1)
+
+	result, err := s.ops.sendSigned(s.ctx, userOpTestVin, userOperationStepBurnSD, op)
+	s.Require().NoError(err)
+	s.Equal(common.FromHex(hash), result.UserOperationHash)
+	s.Equal([]string{hash}, s.bundler.sent)
+	s.Equal(UserOperationStatusMined, s.status(hash))
+}
+
+func (s *UserOperationsTestSuite) TestPendingOperationWithReceipt() {
+	op, hash := s.signedOp(1, 1)
+	s.insertPending(op, hash)
+	s.bundler.receipt(hash, true)
+
+	result, err := s.ops.sendSigned(s.ctx, userOpTestVin, userOperationStepBurnSD, op)
+	s.Require().NoError(err)
+	s.Equal(common.FromHex(hash), result.UserOperationHash)
+	s.Empty(s.bundler.sent, "mined operation must not be sent again")
+	s.Equal(UserOperationStatusMined, s.status(hash))
+}
+
+func (s *UserOperationsTestSuite) TestPendingOperationNonceUsed() {
+	op, hash := s.signedOp(1, 1)
+	s.insertPending(op, hash)
+	s.entryPoint.nonce = big.NewInt(2)
+
+	// the owner's account used the nonce for another operation, this one can never be mined
+	_, err := s.ops.sendSigned(s.ctx, userOpTestVin, userOperationStepBurnSD, op)
+	s.Require().ErrorIs(err, errUserOperationNonceUsed)
+	s.Empty(s.bundler.sent)
+	s.Equal(UserOperationStatusReplaced, s.status(hash))
+
+	status, err := chainStepFailure(err, OnboardingStatusBurnSDPending, OnboardingStatusBurnSDFailure)
+	s.Equal(OnboardingStatusBurnSDFailure, status)
+	var cancel *river.JobCancelError
+	s.True(errors.As(err, &cancel))
+}
+
+func (s *UserOperationsTestSuite) TestRejectedThenNonceUsedElsewhere() {
+	op, hash := s.signedOp(1, 1)
+	s.bundler.rejected[hash] = true
+
+	_, err := s.ops.sendSigned(s.ctx, userOpTestVin, userOperationStepBurnSD, op)
+	s.Require().ErrorIs(err, errUserOperationRejected)
+	s.Equal(UserOperationStatusPending, s.status(hash))
+
+	// another operation of the account is mined with the nonce before the job is retried with a new signature
+	s.entryPoint.nonce = big.NewInt(2)
+	s.bundler.mine = true
+	resigned, resignedHash := s.signedOp(2, 1)
+
+	result, err := s.ops.sendSigned(s.ctx, userOpTestVin, userOperationStepBurnSD, resigned)
+	s.Require().NoError(err)
+	s.Equal(common.FromHex(resignedHash), result.UserOperationHash)
+	s.Equal([]string{hash, resignedHash}, s.bundler.sent)
+	s.Equal(UserOperationStatusReplaced, s.status(hash))
+	s.Equal(UserOperationStatusMined, s.status(resignedHash))
+}
+
+func (s *UserOperationsTestSuite) TestRejectedResendReplaced() {
+	s.bundler.mine = true
+	pending, pendingHash := s.signedOp(1, 1)
+	s.insertPending(pending, pendingHash)
+	s.bundler.rejected[pendingHash] = true
+
+	// the owner signed the step again, eg. after the pending operation's gas price went stale
+	op, hash := s.signedOp(1, 2)
+	_, err := s.ops.sendSigned(s.ctx, userOpTestVin, userOperationStepBurnSD, op)
+	s.Require().NoError(err)
+	s.Equal([]string{pendingHash, hash}, s.bundler.sent)
+	s.Equal(UserOperationStatusReplaced, s.status(pendingHash))
+	s.Equal(UserOperationStatusMined, s.status(hash))
+}
+
+func (s *UserOperationsTestSuite) TestRejectedResendSameOperation() {
+	op, hash := s.signedOp(1, 1)
+	s.insertPending(op, hash)
+	s.bundler.rejected[hash] = true
+
+	_, err := s.ops.sendSigned(s.ctx, userOpTestVin, userOperationStepBurnSD, op)
+	s.Require().ErrorIs(err, errUserOperationRejected)
+	s.Equal([]string{hash}, s.bundler.sent)
+	s.Equal(UserOperationStatusPending, s.status(hash))
+}
+
+func (s *UserOperationsTestSuite) TestRevertedOperation() {
+	s.bundler.mine = true
+	s.bundler.revert = true
+	op, hash := s.signedOp(1, 1)
+
+	_, err := s.ops.sendSigned(s.ctx, userOpTestVin, userOperationStepBurnSD, op)
+	s.Require().ErrorIs(err, errUserOperationReverted)
+	s.Equal(UserOperationStatusReverted, s.status(hash))
+
+	status, err := chainStepFailure(err, OnboardingStatusBurnSDPending, OnboardingStatusBurnSDFailure)
+	s.Equal(OnboardingStatusBurnSDFailure, status)
+	var cancel *river.JobCancelError
+	s.True(errors.As(err, &cancel))
+}
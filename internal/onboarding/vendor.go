@@ -5,6 +5,7 @@ import (
 	"github.com/DIMO-Network/oracle-example/internal/models"
 	"github.com/DIMO-Network/oracle-example/internal/service"
 	"github.com/rs/zerolog"
+	"sync/atomic"
 )
 
 type VendorCapabilityStatus struct {
@@ -22,6 +23,35 @@ type VendorOnboardingAPI interface {
 	Validate(vins []string) ([]VendorCapabilityStatus, error)
 	Connect(vins []string) ([]VendorConnectionStatus, error)
 	Disconnect(vins []string) ([]VendorConnectionStatus, error)
+	// Suspend temporarily stops data for the VINs, the connection can be resumed later
+	Suspend(vins []string) ([]VendorConnectionStatus, error)
+	Resume(vins []string) ([]VendorConnectionStatus, error)
+}
+
+// VendorSwitches turn the calls of the workers to the vendor on and off. ENABLE_VENDOR_CAPABILITY_CHECK and
+// ENABLE_VENDOR_CONNECTION are reloadable, so the workers read them here instead of from their settings.
+type VendorSwitches struct {
+	capabilityCheck atomic.Bool
+	connection      atomic.Bool
+}
+
+func NewVendorSwitches(provider *config.Provider) *VendorSwitches {
+	s := &VendorSwitches{}
+	provider.Subscribe(func(settings *config.Settings) {
+		s.capabilityCheck.Store(settings.EnableVendorCapabilityCheck)
+		s.connection.Store(settings.EnableVendorConnection)
+	})
+	return s
+}
+
+// CapabilityCheck tells if VINs are validated with the vendor before minting
+func (s *VendorSwitches) CapabilityCheck() bool {
+	return s.capabilityCheck.Load()
+}
+
+// Connection tells if vehicles are connected, paused, resumed and disconnected at the vendor
+func (s *VendorSwitches) Connection() bool {
+	return s.connection.Load()
 }
 
 type ExternalOnboardingService struct {
@@ -92,3 +122,39 @@ func (s *ExternalOnboardingService) Disconnect(vins []string) ([]VendorConnectio
 
 	return result, nil
 }
+
+func (s *ExternalOnboardingService) Suspend(vins []string) ([]VendorConnectionStatus, error) {
+	s.logger.Debug().Strs("vins", vins).Msg("vendor.Suspend")
+
+	result := make([]VendorConnectionStatus, 0, len(vins))
+
+	// Here should be any logic / API calls for temporarily stopping data in external vendor system, keeping the connection
+
+	for _, vin := range vins {
+		result = append(result, VendorConnectionStatus{
+			VIN:        vin,
+			Status:     "succeeded",
+			ExternalID: "",
+		})
+	}
+
+	return result, nil
+}
+
+func (s *ExternalOnboardingService) Resume(vins []string) ([]VendorConnectionStatus, error) {
+	s.logger.Debug().Strs("vins", vins).Msg("vendor.Resume")
+
+	result := make([]VendorConnectionStatus, 0, len(vins))
+
+	// Here should be any logic / API calls for resuming data of a suspended connection in external vendor system
+
+	for _, vin := range vins {
+		result = append(result, VendorConnectionStatus{
+			VIN:        vin,
+			Status:     "succeeded",
+			ExternalID: "",
+		})
+	}
+
+	return result, nil
+}
@@ -12,13 +12,22 @@ import (
 	"github.com/DIMO-Network/shared/pkg/logfields"
 	"github.com/riverqueue/river"
 	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"time"
 )
 
+const (
+	deviceDefinitionPollInitialDelay = time.Second
+	deviceDefinitionPollMaxDelay     = 10 * time.Second
+	deviceDefinitionPollTimeout      = time.Minute
+)
+
 type VerifyArgs struct {
 	VIN         string `json:"vin"`
 	CountryCode string `json:"countryCode"`
+	// ChainID of the profile the VIN is minted on, 0 for the default chain
+	ChainID int64 `json:"chainId,omitempty"`
 }
 
 func (a VerifyArgs) Kind() string {
@@ -27,6 +36,7 @@ func (a VerifyArgs) Kind() string {
 
 func (VerifyArgs) InsertOpts() river.InsertOpts {
 	return river.InsertOpts{
+		Queue: QueueVerify,
 		UniqueOpts: river.UniqueOpts{
 			ByArgs: false,
 		},
@@ -41,11 +51,12 @@ type VerifyWorker struct {
 	dd       service.DeviceDefinitionsAPI
 	dbs      *db.Store
 	vendor   VendorOnboardingAPI
+	switches *VendorSwitches
 
 	river.WorkerDefaults[VerifyArgs]
 }
 
-func NewVerifyWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dd service.DeviceDefinitionsAPI, os *service.OracleService, dbs *db.Store, vendor VendorOnboardingAPI) *VerifyWorker {
+func NewVerifyWorker(settings *config.Settings, logger zerolog.Logger, identity service.IdentityAPI, dd service.DeviceDefinitionsAPI, os *service.OracleService, dbs *db.Store, vendor VendorOnboardingAPI, switches *VendorSwitches) *VerifyWorker {
 	return &VerifyWorker{
 		settings: settings,
 		logger:   logger,
@@ -54,10 +65,17 @@ func NewVerifyWorker(settings *config.Settings, logger zerolog.Logger, identity
 		dd:       dd,
 		dbs:      dbs,
 		vendor:   vendor,
+		switches: switches,
 	}
 }
 
 func (w *VerifyWorker) Work(ctx context.Context, job *river.Job[VerifyArgs]) error {
+	normalizedVin, err := normalizeJobVin(job.Args.VIN)
+	if err != nil {
+		return err
+	}
+	job.Args.VIN = normalizedVin
+
 	w.logger.Debug().Str(logfields.VIN, job.Args.VIN).Str(logfields.CountryCode, job.Args.CountryCode).Msg("Verifying VIN")
 
 	// Check if the VIN already exists, create the record if not
@@ -72,7 +90,7 @@ func (w *VerifyWorker) Work(ctx context.Context, job *river.Job[VerifyArgs]) err
 		return nil
 	}
 
-	err = w.DecodeVinAndUpdate(record, job.Args)
+	err = w.DecodeVinAndUpdate(ctx, record, job.Args)
 	if err != nil {
 		return err
 	}
@@ -103,6 +121,7 @@ func (w *VerifyWorker) GetOrCreateVinRecord(ctx context.Context, args VerifyArgs
 	vin = &dbmodels.Vin{
 		Vin:              args.VIN,
 		OnboardingStatus: OnboardingStatusDecodingUnknown,
+		ChainID:          null.NewInt64(args.ChainID, args.ChainID != 0),
 	}
 
 	err = vin.Insert(ctx, w.dbs.DBS().Writer, boil.Infer())
@@ -113,7 +132,7 @@ func (w *VerifyWorker) GetOrCreateVinRecord(ctx context.Context, args VerifyArgs
 	return vin, nil
 }
 
-func (w *VerifyWorker) DecodeVinAndUpdate(record *dbmodels.Vin, args VerifyArgs) error {
+func (w *VerifyWorker) DecodeVinAndUpdate(ctx context.Context, record *dbmodels.Vin, args VerifyArgs) error {
 	// make sure we save status update (and possible new DD)
 	defer (func() { _ = w.update(record, args) })()
 
@@ -129,7 +148,7 @@ func (w *VerifyWorker) DecodeVinAndUpdate(record *dbmodels.Vin, args VerifyArgs)
 		}
 		w.logger.Debug().Str(logfields.VIN, args.VIN).Str(logfields.CountryCode, args.CountryCode).Str(logfields.DefinitionID, decoded.DeviceDefinitionID).Msg("VIN decoded")
 
-		dd, err := w.getOrWaitForDeviceDefinition(decoded.DeviceDefinitionID)
+		dd, err := w.getOrWaitForDeviceDefinition(ctx, decoded.DeviceDefinitionID)
 		if err != nil {
 			record.OnboardingStatus = OnboardingStatusDecodingFailure
 			return err
@@ -154,7 +173,7 @@ func (w *VerifyWorker) ValidateWithExternalVendorAndUpdate(record *dbmodels.Vin,
 
 	record.OnboardingStatus = OnboardingStatusVendorValidationUnknown
 
-	if w.settings.EnableVendorCapabilityCheck {
+	if w.switches.CapabilityCheck() {
 		validation, err := w.vendor.Validate([]string{args.VIN})
 		if err != nil {
 			w.logger.Error().Err(err).Msg("Failed to validate VIN")
@@ -181,19 +200,29 @@ func (w *VerifyWorker) ValidateWithExternalVendorAndUpdate(record *dbmodels.Vin,
 	return nil
 }
 
-func (w *VerifyWorker) getOrWaitForDeviceDefinition(deviceDefinitionID string) (*models.DeviceDefinition, error) {
-	w.logger.Debug().Str(logfields.DefinitionID, deviceDefinitionID).Msg("Waiting for device definition")
-	for i := 0; i < 12; i++ {
-		definition, err := w.identity.FetchDeviceDefinitionByID(deviceDefinitionID)
-		if err != nil || definition == nil || definition.DeviceDefinitionID == "" {
-			time.Sleep(5 * time.Second)
-			w.logger.Debug().Str(logfields.DefinitionID, deviceDefinitionID).Msgf("Still waiting, retry %d", i+1)
-			continue
+// getOrWaitForDeviceDefinition returns the definition from the shared cache, so known definitions don't hit Identity API at all.
+// Definitions just created by VIN decoding may take a while to show up in Identity API, so those are polled with backoff.
+func (w *VerifyWorker) getOrWaitForDeviceDefinition(ctx context.Context, deviceDefinitionID string) (*models.DeviceDefinition, error) {
+	delay := deviceDefinitionPollInitialDelay
+	deadline := time.Now().Add(deviceDefinitionPollTimeout)
+	for attempt := 1; ; attempt++ {
+		definition, err := w.identity.GetDeviceDefinitionByID(ctx, deviceDefinitionID)
+		if err == nil && definition != nil && definition.DeviceDefinitionID != "" {
+			return definition, nil
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			return nil, errors.New("device definition not found")
 		}
-		return definition, nil
-	}
 
-	return nil, errors.New("device definition not found")
+		w.logger.Debug().Err(err).Str(logfields.DefinitionID, deviceDefinitionID).Msgf("Waiting for device definition, retry %d", attempt)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, deviceDefinitionPollMaxDelay)
+	}
 }
 
 func (w *VerifyWorker) update(record *dbmodels.Vin, args VerifyArgs) error {
@@ -0,0 +1,18 @@
+package onboarding
+
+import (
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/vin"
+	"github.com/riverqueue/river"
+)
+
+// normalizeJobVin normalizes the VIN from job args, jobs with a malformed VIN can never succeed, so they are cancelled.
+// Check digit isn't validated here, the controllers did it already (or skipped it in vendor test mode).
+func normalizeJobVin(rawVin string) (string, error) {
+	normalized := vin.Normalize(rawVin)
+	if err := vin.ValidateFormat(normalized); err != nil {
+		return "", river.JobCancel(fmt.Errorf("invalid VIN %q: %w", rawVin, err))
+	}
+
+	return normalized, nil
+}
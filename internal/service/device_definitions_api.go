@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -36,6 +37,11 @@ func NewDeviceDefinitionsAPIService(logger zerolog.Logger, settings config.Setti
 	}
 }
 
+// AuthCheck checks the DIMO Auth login the decoder depends on
+func (d *DeviceDefinitionsAPIService) AuthCheck(ctx context.Context) (string, error) {
+	return d.auth.Check(ctx)
+}
+
 type DecodeVinPayload struct {
 	CountryCode string `json:"countryCode"`
 	Vin         string `json:"vin"`
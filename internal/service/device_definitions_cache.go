@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDeviceDefinitionCacheTTL   = 24 * time.Hour
+	defaultDeviceDefinitionRefreshAge = time.Hour
+	deviceDefinitionRefreshTimeout    = 30 * time.Second
+)
+
+// deviceDefinitionCache keeps device definitions in Postgres, so they are shared by the API and the workers and survive restarts.
+// Definitions older than the TTL are fetched again, definitions about to expire are served and refreshed in the background.
+// All other IdentityAPI calls go straight to the wrapped service.
+type deviceDefinitionCache struct {
+	IdentityAPI
+	// ctx is the service context, background refreshes stop with it
+	ctx          context.Context
+	pdb          *db.Store
+	logger       zerolog.Logger
+	ttl          time.Duration
+	refreshAhead time.Duration
+	// refreshing holds IDs with a background refresh in progress
+	refreshing sync.Map
+}
+
+func NewDeviceDefinitionCache(ctx context.Context, logger zerolog.Logger, settings config.Settings, pdb *db.Store, identity IdentityAPI) IdentityAPI {
+	ttl := defaultDeviceDefinitionCacheTTL
+	if settings.DeviceDefinitionCacheTTLMinutes > 0 {
+		ttl = time.Duration(settings.DeviceDefinitionCacheTTLMinutes) * time.Minute
+	}
+
+	refreshAhead := defaultDeviceDefinitionRefreshAge
+	if settings.DeviceDefinitionRefreshAheadMinutes > 0 {
+		refreshAhead = time.Duration(settings.DeviceDefinitionRefreshAheadMinutes) * time.Minute
+	}
+
+	return &deviceDefinitionCache{
+		IdentityAPI:  identity,
+		ctx:          ctx,
+		pdb:          pdb,
+		logger:       logger,
+		ttl:          ttl,
+		refreshAhead: min(refreshAhead, ttl),
+	}
+}
+
+func (d *deviceDefinitionCache) GetDeviceDefinitionByID(ctx context.Context, id string) (*models.DeviceDefinition, error) {
+	record, err := d.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if record != nil {
+		age := time.Since(record.UpdatedAt)
+		if age < d.ttl {
+			deviceDefinitionCacheCntr.WithLabelValues("hit").Inc()
+			if age >= d.ttl-d.refreshAhead {
+				d.refreshInBackground(id)
+			}
+			return DeviceDefinitionFromRecord(record), nil
+		}
+	}
+
+	deviceDefinitionCacheCntr.WithLabelValues("miss").Inc()
+	definition, err := d.FetchDeviceDefinitionByID(ctx, id)
+	if err != nil {
+		// serve the expired definition rather than failing, definitions rarely change
+		if record != nil && !errors.Is(err, ErrGraphQLNotFound) {
+			d.logger.Warn().Err(err).Str(logfields.DefinitionID, id).Msg("Failed to refresh device definition, using expired one")
+			deviceDefinitionCacheCntr.WithLabelValues("stale").Inc()
+			return DeviceDefinitionFromRecord(record), nil
+		}
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+// GetCachedDeviceDefinitionByID returns the definition only if it's cached and not expired
+func (d *deviceDefinitionCache) GetCachedDeviceDefinitionByID(id string) (*models.DeviceDefinition, error) {
+	record, err := d.load(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if record == nil || time.Since(record.UpdatedAt) >= d.ttl {
+		return nil, errors.New("not found")
+	}
+
+	return DeviceDefinitionFromRecord(record), nil
+}
+
+// FetchDeviceDefinitionByID always asks Identity API and stores the result
+func (d *deviceDefinitionCache) FetchDeviceDefinitionByID(ctx context.Context, id string) (*models.DeviceDefinition, error) {
+	definition, err := d.IdentityAPI.FetchDeviceDefinitionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if definition.DeviceDefinitionID != "" {
+		record := DeviceDefinitionRecord(definition)
+		err = record.Upsert(ctx, d.pdb.DBS().Writer, true, []string{dbmodels.DeviceDefinitionColumns.DeviceDefinitionID}, boil.Infer(), boil.Infer())
+		if err != nil {
+			// the definition is still good, it will just be fetched again next time
+			d.logger.Error().Err(err).Str(logfields.DefinitionID, id).Msg("Failed to store device definition")
+		}
+	}
+
+	return definition, nil
+}
+
+func (d *deviceDefinitionCache) load(ctx context.Context, id string) (*dbmodels.DeviceDefinition, error) {
+	record, err := dbmodels.FindDeviceDefinition(ctx, d.pdb.DBS().Reader, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		d.logger.Error().Err(err).Str(logfields.DefinitionID, id).Msg("Failed to load device definition")
+		return nil, fmt.Errorf("failed to load device definition: %w", err)
+	}
+
+	return record, nil
+}
+
+func (d *deviceDefinitionCache) refreshInBackground(id string) {
+	if _, inProgress := d.refreshing.LoadOrStore(id, true); inProgress {
+		return
+	}
+
+	go func() {
+		defer d.refreshing.Delete(id)
+
+		ctx, cancel := context.WithTimeout(d.ctx, deviceDefinitionRefreshTimeout)
+		defer cancel()
+
+		if _, err := d.FetchDeviceDefinitionByID(ctx, id); err != nil {
+			d.logger.Warn().Err(err).Str(logfields.DefinitionID, id).Msg("Failed to refresh device definition ahead of expiration")
+			return
+		}
+		deviceDefinitionCacheCntr.WithLabelValues("refresh").Inc()
+	}()
+}
+
+// DeviceDefinitionRecord converts Identity API device definition into its DB cache record
+func DeviceDefinitionRecord(definition *models.DeviceDefinition) *dbmodels.DeviceDefinition {
+	return &dbmodels.DeviceDefinition{
+		DeviceDefinitionID:  definition.DeviceDefinitionID,
+		ManufacturerName:    definition.Manufacturer.Name,
+		ManufacturerTokenID: int64(definition.Manufacturer.TokenID),
+		Model:               definition.Model,
+		Year:                definition.Year,
+	}
+}
+
+func DeviceDefinitionFromRecord(record *dbmodels.DeviceDefinition) *models.DeviceDefinition {
+	return &models.DeviceDefinition{
+		DeviceDefinitionID: record.DeviceDefinitionID,
+		Manufacturer: models.Manufacturer{
+			Name:    record.ManufacturerName,
+			TokenID: uint64(record.ManufacturerTokenID),
+		},
+		Model: record.Model,
+		Year:  record.Year,
+	}
+}
+
+// Prometheus metrics
+var deviceDefinitionCacheCntr = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "oracle_example_device_definition_cache_total",
+	Help: "Device definition cache lookups by result: hit, miss, stale (expired served on fetch failure) and refresh (background refreshes)",
+}, []string{"result"})
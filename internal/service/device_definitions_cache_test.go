@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/oracle-example/internal/test"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testDefinitionID = "ford_f-150_2022"
+
+// fakeDefinitionIdentity answers device definition fetches with the current model, all other calls panic
+type fakeDefinitionIdentity struct {
+	IdentityAPI
+	mu      sync.Mutex
+	model   string
+	err     error
+	fetches int
+}
+
+func (f *fakeDefinitionIdentity) FetchDeviceDefinitionByID(ctx context.Context, id string) (*models.DeviceDefinition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.fetches++
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &models.DeviceDefinition{
+		DeviceDefinitionID: id,
+		Manufacturer:       models.Manufacturer{Name: "Ford", TokenID: 42},
+		Model:              f.model,
+		Year:               2022,
+	}, nil
+}
+
+func (f *fakeDefinitionIdentity) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.fetches
+}
+
+type DeviceDefinitionCacheTestSuite struct {
+	suite.Suite
+	pdb       db.Store
+	container testcontainers.Container
+	ctx       context.Context
+	settings  config.Settings
+	identity  *fakeDefinitionIdentity
+	cache     IdentityAPI
+}
+
+func TestDeviceDefinitionCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(DeviceDefinitionCacheTestSuite))
+}
+
+// SetupSuite starts container db
+func (s *DeviceDefinitionCacheTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container, s.settings = test.StartContainerDatabase(s.ctx, s.T(), migrationsDirRelPath)
+	s.settings.DeviceDefinitionCacheTTLMinutes = 60
+	s.settings.DeviceDefinitionRefreshAheadMinutes = 10
+}
+
+func (s *DeviceDefinitionCacheTestSuite) SetupTest() {
+	s.identity = &fakeDefinitionIdentity{model: "F-150"}
+	s.cache = NewDeviceDefinitionCache(s.ctx, zerolog.Nop(), s.settings, &s.pdb, s.identity)
+}
+
+// TearDownTest after each test truncate tables
+func (s *DeviceDefinitionCacheTestSuite) TearDownTest() {
+	test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+// TearDownSuite cleanup at end by terminating container
+func (s *DeviceDefinitionCacheTestSuite) TearDownSuite() {
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+// cached stores the definition as fetched age ago
+func (s *DeviceDefinitionCacheTestSuite) cached(age time.Duration) {
+	_, err := s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.Require().NoError(err)
+
+	_, err = s.pdb.DBS().Writer.ExecContext(s.ctx, "UPDATE "+s.settings.DB.Name+".device_definitions SET updated_at = $1 WHERE device_definition_id = $2", time.Now().Add(-age), testDefinitionID)
+	s.Require().NoError(err)
+	s.identity.fetches = 0
+}
+
+func (s *DeviceDefinitionCacheTestSuite) storedModel() string {
+	record, err := dbmodels.FindDeviceDefinition(s.ctx, s.pdb.DBS().Reader, testDefinitionID)
+	s.Require().NoError(err)
+
+	return record.Model
+}
+
+func (s *DeviceDefinitionCacheTestSuite) TestMissIsStored() {
+	definition, err := s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.Require().NoError(err)
+	s.Equal("F-150", definition.Model)
+	s.Equal(uint64(42), definition.Manufacturer.TokenID)
+
+	definition, err = s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.Require().NoError(err)
+	s.Equal("F-150", definition.Model)
+	s.Equal(1, s.identity.calls())
+
+	definition, err = s.cache.GetCachedDeviceDefinitionByID(testDefinitionID)
+	s.Require().NoError(err)
+	s.Equal("F-150", definition.Model)
+}
+
+func (s *DeviceDefinitionCacheTestSuite) TestExpiredIsFetchedAgain() {
+	s.cached(2 * time.Hour)
+	s.identity.model = "F-150 Lightning"
+
+	_, err := s.cache.GetCachedDeviceDefinitionByID(testDefinitionID)
+	s.Error(err, "expired definitions are not served from cache")
+
+	definition, err := s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.Require().NoError(err)
+	s.Equal("F-150 Lightning", definition.Model)
+	s.Equal(1, s.identity.calls())
+	s.Equal("F-150 Lightning", s.storedModel())
+}
+
+func (s *DeviceDefinitionCacheTestSuite) TestRefreshAhead() {
+	s.cached(55 * time.Minute)
+	s.identity.model = "F-150 Lightning"
+
+	definition, err := s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.Require().NoError(err)
+	s.Equal("F-150", definition.Model, "the cached definition is served while refreshing")
+
+	s.Eventually(func() bool {
+		return s.storedModel() == "F-150 Lightning"
+	}, 5*time.Second, 50*time.Millisecond)
+	s.Equal(1, s.identity.calls())
+}
+
+func (s *DeviceDefinitionCacheTestSuite) TestNoRefreshBeforeWindow() {
+	s.cached(30 * time.Minute)
+
+	_, err := s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.Require().NoError(err)
+	s.Never(func() bool {
+		return s.identity.calls() > 0
+	}, 200*time.Millisecond, 50*time.Millisecond)
+}
+
+func (s *DeviceDefinitionCacheTestSuite) TestRefreshStopsWithServiceContext() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.cache = NewDeviceDefinitionCache(ctx, zerolog.Nop(), s.settings, &s.pdb, s.identity)
+	s.cached(55 * time.Minute)
+	s.identity.model = "F-150 Lightning"
+	cancel()
+
+	// lookups still use the caller's context, only the background refresh is cancelled
+	definition, err := s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.Require().NoError(err)
+	s.Equal("F-150", definition.Model)
+
+	s.Eventually(func() bool {
+		return s.identity.calls() == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	s.Equal("F-150", s.storedModel())
+}
+
+func (s *DeviceDefinitionCacheTestSuite) TestStaleFallback() {
+	s.cached(2 * time.Hour)
+	s.identity.err = errors.New("identity api unavailable")
+
+	definition, err := s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.Require().NoError(err)
+	s.Equal("F-150", definition.Model)
+	s.Equal(1, s.identity.calls())
+}
+
+func (s *DeviceDefinitionCacheTestSuite) TestNotFoundIsNotServedStale() {
+	s.cached(2 * time.Hour)
+	s.identity.err = ErrGraphQLNotFound
+
+	_, err := s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.ErrorIs(err, ErrGraphQLNotFound)
+}
+
+func (s *DeviceDefinitionCacheTestSuite) TestFetchFailureWithoutCache() {
+	s.identity.err = errors.New("identity api unavailable")
+
+	_, err := s.cache.GetDeviceDefinitionByID(s.ctx, testDefinitionID)
+	s.Error(err)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
@@ -57,6 +58,26 @@ func (a *DimoAuthService) GetToken() *jwt.Token {
 	return a.token
 }
 
+// Check gets a token, logging in again if the current one expired, for readiness checks and the doctor command
+func (a *DimoAuthService) Check(context.Context) (string, error) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.validateCurrentToken()
+	if a.token == nil {
+		token, err := a.getNewToken()
+		if err != nil {
+			return "", fmt.Errorf("failed to get token: %w", err)
+		}
+		a.token = token
+	}
+
+	expiration, err := a.token.Claims.GetExpirationTime()
+	if err != nil || expiration == nil {
+		return "token valid", nil
+	}
+	return fmt.Sprintf("token expires at %s", expiration.Format(time.RFC3339)), nil
+}
+
 func (a *DimoAuthService) validateCurrentToken() {
 	a.logger.Debug().Msg("validate current token")
 	if a.token == nil {
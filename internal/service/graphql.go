@@ -0,0 +1,191 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrGraphQLNotFound is matched (with errors.Is) by GraphQL errors reporting the requested object doesn't exist
+var ErrGraphQLNotFound = errors.New("not found")
+
+const graphQLMaxAttempts = 3
+
+// GraphQLError is a single entry of the GraphQL response errors list
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Code returns the error code from extensions, if any
+func (e GraphQLError) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// GraphQLErrors is returned when the response has errors, even if it was returned with HTTP 200
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, gqlErr := range e {
+		messages = append(messages, gqlErr.Message)
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+func (e GraphQLErrors) Is(target error) bool {
+	if target != ErrGraphQLNotFound {
+		return false
+	}
+
+	for _, gqlErr := range e {
+		if gqlErr.Code() == "NOT_FOUND" || strings.Contains(strings.ToLower(gqlErr.Message), "not found") {
+			return true
+		}
+	}
+	return false
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors GraphQLErrors   `json:"errors"`
+}
+
+// GraphQLClient sends queries with variables, so no values are ever interpolated into the query text
+type GraphQLClient struct {
+	endpoint   url.URL
+	httpClient *http.Client
+	logger     zerolog.Logger
+}
+
+func NewGraphQLClient(endpoint url.URL, timeout time.Duration, logger zerolog.Logger) *GraphQLClient {
+	return &GraphQLClient{
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: timeout},
+		logger:     logger,
+	}
+}
+
+// Query runs the query and unmarshals the response data into result.
+// Transport failures and 5xx responses are retried, GraphQL errors are returned as GraphQLErrors.
+func (g *GraphQLClient) Query(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	payload, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	var response *graphQLResponse
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		response, retry, err = g.do(ctx, payload)
+		if err == nil || !retry || attempt == graphQLMaxAttempts {
+			break
+		}
+
+		g.logger.Warn().Err(err).Int("attempt", attempt).Msg("GraphQL request failed, retrying")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * 200 * time.Millisecond):
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(response.Errors) > 0 {
+		return response.Errors
+	}
+
+	if len(response.Data) == 0 || string(response.Data) == "null" {
+		return errors.New("graphql: response has no data")
+	}
+
+	return json.Unmarshal(response.Data, result)
+}
+
+// do sends a single request, returns whether the failure can be retried
+func (g *GraphQLClient) do(ctx context.Context, payload []byte) (*graphQLResponse, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.endpoint.String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		g.logger.Err(err).Msg("Failed to send POST request")
+		return nil, ctx.Err() == nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			g.logger.Err(err).Msg("Failed to close response body")
+		}
+	}(resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		g.logger.Err(err).Msgf("Failed to read response body")
+		return nil, true, err
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, true, fmt.Errorf("graphql: unexpected status code %d", resp.StatusCode)
+	}
+
+	response := new(graphQLResponse)
+	if err = json.Unmarshal(body, response); err != nil {
+		if resp.StatusCode == http.StatusBadRequest {
+			return nil, false, ErrBadRequest
+		}
+		return nil, false, fmt.Errorf("graphql: failed to decode response with status code %d: %w", resp.StatusCode, err)
+	}
+
+	// invalid queries are reported with 400 and the errors list, keep both matchable
+	if resp.StatusCode == http.StatusBadRequest {
+		if len(response.Errors) == 0 {
+			return nil, false, ErrBadRequest
+		}
+		return nil, false, fmt.Errorf("%w: %w", ErrBadRequest, response.Errors)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("graphql: unexpected status code %d", resp.StatusCode)
+	}
+
+	return response, false, nil
+}
+
+// buildGraphQLQuery appends the fragments used by the operation, each one once
+func buildGraphQLQuery(operation string, fragments ...string) string {
+	var query strings.Builder
+	query.WriteString(operation)
+
+	seen := make(map[string]bool, len(fragments))
+	for _, fragment := range fragments {
+		if seen[fragment] {
+			continue
+		}
+		seen[fragment] = true
+		query.WriteString("\n")
+		query.WriteString(fragment)
+	}
+
+	return query.String()
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type GraphQLClientTestSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	requests atomic.Int32
+	// handler serves the fake GraphQL server responses, set by each test
+	handler func(w http.ResponseWriter, request graphQLRequest)
+	client  *GraphQLClient
+}
+
+func TestGraphQLClientTestSuite(t *testing.T) {
+	suite.Run(t, new(GraphQLClientTestSuite))
+}
+
+func (s *GraphQLClientTestSuite) SetupTest() {
+	s.requests.Store(0)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+
+		var request graphQLRequest
+		s.Require().NoError(json.NewDecoder(r.Body).Decode(&request))
+		s.handler(w, request)
+	}))
+
+	apiURL, _ := url.Parse(s.server.URL)
+	s.client = NewGraphQLClient(*apiURL, time.Second, zerolog.Nop())
+}
+
+func (s *GraphQLClientTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *GraphQLClientTestSuite) TestQuerySendsVariables() {
+	s.handler = func(w http.ResponseWriter, request graphQLRequest) {
+		s.Equal(map[string]interface{}{"id": `ford_"focus"`}, request.Variables)
+		_, _ = w.Write([]byte(`{"data":{"deviceDefinition":{"deviceDefinitionId":"ford_focus_2020","model":"Focus","year":2020}}}`))
+	}
+
+	var result struct {
+		DeviceDefinition struct {
+			DeviceDefinitionID string `json:"deviceDefinitionId"`
+			Year               int    `json:"year"`
+		} `json:"deviceDefinition"`
+	}
+	err := s.client.Query(context.Background(), DeviceDefinitionByIDQuery, map[string]interface{}{"id": `ford_"focus"`}, &result)
+
+	s.Require().NoError(err)
+	s.Equal("ford_focus_2020", result.DeviceDefinition.DeviceDefinitionID)
+	s.Equal(2020, result.DeviceDefinition.Year)
+}
+
+func (s *GraphQLClientTestSuite) TestQueryReturnsGraphQLErrors() {
+	s.handler = func(w http.ResponseWriter, _ graphQLRequest) {
+		_, _ = w.Write([]byte(`{"data":null,"errors":[{"message":"No vehicle with that token ID.","path":["vehicle"],"extensions":{"code":"NOT_FOUND"}}]}`))
+	}
+
+	var result map[string]interface{}
+	err := s.client.Query(context.Background(), VehicleByTokenIDQuery, map[string]interface{}{"tokenId": 1}, &result)
+
+	var gqlErrors GraphQLErrors
+	s.Require().ErrorAs(err, &gqlErrors)
+	s.Equal("NOT_FOUND", gqlErrors[0].Code())
+	s.ErrorIs(err, ErrGraphQLNotFound)
+	s.Nil(result)
+}
+
+func (s *GraphQLClientTestSuite) TestQueryOtherErrorsAreNotNotFound() {
+	s.handler = func(w http.ResponseWriter, _ graphQLRequest) {
+		_, _ = w.Write([]byte(`{"errors":[{"message":"internal system error"}]}`))
+	}
+
+	var result map[string]interface{}
+	err := s.client.Query(context.Background(), VehicleByTokenIDQuery, nil, &result)
+
+	s.Require().Error(err)
+	s.NotErrorIs(err, ErrGraphQLNotFound)
+}
+
+func (s *GraphQLClientTestSuite) TestQueryBadRequest() {
+	s.handler = func(w http.ResponseWriter, _ graphQLRequest) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"Cannot query field \"foo\" on type \"Query\"."}]}`))
+	}
+
+	var result map[string]interface{}
+	err := s.client.Query(context.Background(), "{ foo }", nil, &result)
+
+	s.ErrorIs(err, ErrBadRequest)
+	s.ErrorContains(err, "Cannot query field")
+	s.Equal(int32(1), s.requests.Load())
+}
+
+func (s *GraphQLClientTestSuite) TestQueryRetriesServerErrors() {
+	s.handler = func(w http.ResponseWriter, _ graphQLRequest) {
+		if s.requests.Load() < graphQLMaxAttempts {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"ok":true}}`))
+	}
+
+	var result struct {
+		OK bool `json:"ok"`
+	}
+	err := s.client.Query(context.Background(), "{ ok }", nil, &result)
+
+	s.Require().NoError(err)
+	s.True(result.OK)
+	s.Equal(int32(graphQLMaxAttempts), s.requests.Load())
+}
+
+func (s *GraphQLClientTestSuite) TestQueryCancelled() {
+	s.handler = func(w http.ResponseWriter, _ graphQLRequest) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var result map[string]interface{}
+	err := s.client.Query(ctx, "{ ok }", nil, &result)
+
+	s.ErrorIs(err, context.Canceled)
+	s.Equal(int32(0), s.requests.Load())
+}
+
+func (s *GraphQLClientTestSuite) TestQueryIncludesFragmentsOnce() {
+	s.Equal(1, strings.Count(VehicleByTokenIDQuery, "fragment VehicleFields on Vehicle"))
+	s.Equal(1, strings.Count(VehicleByTokenIDQuery, "fragment DefinitionFields on Definition"))
+	s.Equal(1, strings.Count(VehicleByTokenIDQuery, "fragment SyntheticDeviceFields on SyntheticDevice"))
+	s.NotContains(DeviceDefinitionByIDQuery, "fragment")
+}
@@ -1,52 +1,61 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/DIMO-Network/oracle-example/internal/config"
 	"github.com/DIMO-Network/oracle-example/internal/models"
-	shttp "github.com/DIMO-Network/shared/pkg/http"
 	"github.com/patrickmn/go-cache"
 	"github.com/rs/zerolog"
-	"io"
-	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
 var ErrBadRequest = errors.New("bad request")
 
+// sacdCacheExpiration is kept short so revoked permissions are respected quickly
+const sacdCacheExpiration = 2 * time.Minute
+
+// walletVehiclesCacheExpiration is kept short so transferred vehicles show up (and disappear) quickly
+const walletVehiclesCacheExpiration = 2 * time.Minute
+
+// vehiclesPageSize is the Identity API maximum page size, also used for SACD pages and as the max number of token IDs per ownership lookup
+const vehiclesPageSize = 100
+
+// IdentityAPI errors are GraphQLErrors when Identity API responded with errors, objects that don't exist match ErrGraphQLNotFound
 type IdentityAPI interface {
 	GetCachedVehicleByTokenID(tokenID int64) (*models.Vehicle, error)
-	FetchVehicleByTokenID(tokenID int64) (*models.Vehicle, error)
-	FetchVehiclesByWalletAddress(address string) ([]models.Vehicle, error)
-
-	GetDeviceDefinitionByID(id string) (*models.DeviceDefinition, error)
+	FetchVehicleByTokenID(ctx context.Context, tokenID int64) (*models.Vehicle, error)
+	// GetVehiclesByWalletAddress returns all vehicles of the wallet, the full set is cached per wallet
+	GetVehiclesByWalletAddress(ctx context.Context, address string) ([]models.Vehicle, error)
+	FetchVehiclesByWalletAddress(ctx context.Context, address string) ([]models.Vehicle, error)
+	// StreamVehiclesByWalletAddress calls fn for each page of the wallet's vehicles, stops on fn error or ctx cancellation
+	StreamVehiclesByWalletAddress(ctx context.Context, address string, fn func(page []models.Vehicle) error) error
+	// FetchOwnedVehicles returns those of the token IDs vehicles that are owned by the address, never cached
+	FetchOwnedVehicles(ctx context.Context, owner string, tokenIDs []int64) ([]models.Vehicle, error)
+	GetVehicleSacds(ctx context.Context, tokenID int64) ([]models.Sacd, error)
+
+	GetDeviceDefinitionByID(ctx context.Context, id string) (*models.DeviceDefinition, error)
 	GetCachedDeviceDefinitionByID(id string) (*models.DeviceDefinition, error)
-	FetchDeviceDefinitionByID(id string) (*models.DeviceDefinition, error)
+	FetchDeviceDefinitionByID(ctx context.Context, id string) (*models.DeviceDefinition, error)
 }
 
 type identityAPIService struct {
-	apiURL     url.URL
-	cache      *cache.Cache
-	httpClient shttp.ClientWrapper
-	logger     zerolog.Logger
+	client *GraphQLClient
+	cache  *cache.Cache
+	logger zerolog.Logger
 }
 
 func NewIdentityAPIService(logger zerolog.Logger, settings config.Settings) IdentityAPI {
-	h := map[string]string{}
-	h["Content-Type"] = "application/json"
-	hcw, _ := shttp.NewClientWrapper("", "", 10*time.Second, h, false, shttp.WithRetry(3))
-
 	// Initialize cache with a default expiration time of 10 minutes and cleanup interval of 15 minutes
 	c := cache.New(10*time.Minute, 15*time.Minute)
 
 	return &identityAPIService{
-		httpClient: hcw,
-		apiURL:     settings.IdentityAPIEndpoint,
-		logger:     logger,
-		cache:      c,
+		client: NewGraphQLClient(settings.IdentityAPIEndpoint, 10*time.Second, logger),
+		logger: logger,
+		cache:  c,
 	}
 }
 
@@ -58,78 +67,175 @@ func (i *identityAPIService) GetCachedVehicleByTokenID(tokenID int64) (*models.V
 	return nil, errors.New("not found")
 }
 
-func (i *identityAPIService) FetchVehicleByTokenID(tokenID int64) (*models.Vehicle, error) {
-	strTokenID := strconv.FormatInt(tokenID, 10)
-
-	// GraphQL query
-	graphqlQuery := fmt.Sprintf(VehicleByTokenIDQuery, strTokenID)
-
-	body, err := i.Query(graphqlQuery)
-	if err != nil {
+func (i *identityAPIService) FetchVehicleByTokenID(ctx context.Context, tokenID int64) (*models.Vehicle, error) {
+	var result models.SingleVehicle
+	if err := i.client.Query(ctx, VehicleByTokenIDQuery, map[string]interface{}{"tokenId": tokenID}, &result); err != nil {
 		return nil, err
 	}
 
-	var af models.GraphQlData[models.SingleVehicle]
+	// Store response in cache
+	i.cache.Set(fmt.Sprintf("vehicle_%s", strconv.FormatInt(tokenID, 10)), &result.Vehicle, cache.DefaultExpiration)
+
+	return &result.Vehicle, nil
+}
 
-	if err = json.Unmarshal(body, &af); err != nil {
+func (i *identityAPIService) GetVehiclesByWalletAddress(ctx context.Context, walletAddress string) ([]models.Vehicle, error) {
+	cacheKey := fmt.Sprintf("wallet_vehicles_%s", strings.ToLower(walletAddress))
+	if cachedResponse, found := i.cache.Get(cacheKey); found {
+		return cachedResponse.([]models.Vehicle), nil
+	}
+
+	vehicles, err := i.FetchVehiclesByWalletAddress(ctx, walletAddress)
+	if err != nil {
 		return nil, err
 	}
 
-	// Store response in cache
-	i.cache.Set(fmt.Sprintf("vehicle_%s", strTokenID), &af.Data.Vehicle, cache.DefaultExpiration)
+	// Store response in cache, only complete sets are cached
+	i.cache.Set(cacheKey, vehicles, walletVehiclesCacheExpiration)
 
-	return &af.Data.Vehicle, nil
+	return vehicles, nil
 }
 
-func (i *identityAPIService) FetchVehiclesByWalletAddress(walletAddress string) ([]models.Vehicle, error) {
+func (i *identityAPIService) FetchVehiclesByWalletAddress(ctx context.Context, walletAddress string) ([]models.Vehicle, error) {
 	vehicles := []models.Vehicle{}
-	pagedVehicles, err := i.FetchUserVehiclesPage(walletAddress, "")
+	err := i.StreamVehiclesByWalletAddress(ctx, walletAddress, func(page []models.Vehicle) error {
+		vehicles = append(vehicles, page...)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	vehicles = append(vehicles, pagedVehicles.Nodes...)
+	return vehicles, nil
+}
 
-	for pagedVehicles.PageInfo.HasNextPage {
-		pagedVehicles, err = i.FetchUserVehiclesPage(walletAddress, pagedVehicles.PageInfo.EndCursor)
+func (i *identityAPIService) StreamVehiclesByWalletAddress(ctx context.Context, walletAddress string, fn func(page []models.Vehicle) error) error {
+	return i.streamVehicles(ctx, map[string]interface{}{"owner": walletAddress}, fn)
+}
+
+func (i *identityAPIService) FetchOwnedVehicles(ctx context.Context, owner string, tokenIDs []int64) ([]models.Vehicle, error) {
+	vehicles := []models.Vehicle{}
+	for start := 0; start < len(tokenIDs); start += vehiclesPageSize {
+		chunk := tokenIDs[start:min(start+vehiclesPageSize, len(tokenIDs))]
+
+		filter := map[string]interface{}{"owner": owner, "tokenIds": chunk}
+		err := i.streamVehicles(ctx, filter, func(page []models.Vehicle) error {
+			vehicles = append(vehicles, page...)
+			return nil
+		})
 		if err != nil {
 			return nil, err
 		}
-
-		vehicles = append(vehicles, pagedVehicles.Nodes...)
 	}
 
 	return vehicles, nil
 }
 
-func (i *identityAPIService) FetchUserVehiclesPage(walletAddress string, after string) (*models.PagedVehiclesNodes, error) {
-	afterCursor := "null"
+// streamVehicles walks all pages of the vehicles matching the filter, checking ctx before each page
+func (i *identityAPIService) streamVehicles(ctx context.Context, filter map[string]interface{}, fn func(page []models.Vehicle) error) error {
+	after := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		pagedVehicles, err := i.FetchVehiclesPage(ctx, filter, after)
+		if err != nil {
+			return err
+		}
+
+		if err = fn(pagedVehicles.Nodes); err != nil {
+			return err
+		}
+
+		if !pagedVehicles.PageInfo.HasNextPage {
+			return nil
+		}
+
+		if pagedVehicles.PageInfo.EndCursor == "" || pagedVehicles.PageInfo.EndCursor == after {
+			return fmt.Errorf("identity API returned no progress cursor for vehicles page after %q", after)
+		}
+		after = pagedVehicles.PageInfo.EndCursor
+	}
+}
+
+func (i *identityAPIService) FetchVehiclesPage(ctx context.Context, filter map[string]interface{}, after string) (*models.PagedVehiclesNodes, error) {
+	variables := map[string]interface{}{
+		"filterBy": filter,
+		"first":    vehiclesPageSize,
+	}
 	if after != "" {
-		afterCursor = "\"" + after + "\""
+		variables["after"] = after
 	}
-	graphqlQuery := fmt.Sprintf(VehiclesByWalletAndCursorQuery, walletAddress, afterCursor)
 
-	body, err := i.Query(graphqlQuery)
-	if err != nil {
+	var result models.PagedVehicles
+	if err := i.client.Query(ctx, VehiclesByFilterAndCursorQuery, variables, &result); err != nil {
 		return nil, err
 	}
 
-	var pagedVehicles models.GraphQlData[models.PagedVehicles]
+	return &result.VehicleNodes, nil
+}
+
+// GetVehicleSacds returns the SACD grants of the vehicle, cached per token ID
+func (i *identityAPIService) GetVehicleSacds(ctx context.Context, tokenID int64) ([]models.Sacd, error) {
+	cacheKey := fmt.Sprintf("sacd_%s", strconv.FormatInt(tokenID, 10))
+	if cachedResponse, found := i.cache.Get(cacheKey); found {
+		return cachedResponse.([]models.Sacd), nil
+	}
 
-	if err = json.Unmarshal(body, &pagedVehicles); err != nil {
+	var sacds []models.Sacd
+	after := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pagedSacds, err := i.FetchVehicleSacdsPage(ctx, tokenID, after)
+		if err != nil {
+			return nil, err
+		}
+		sacds = append(sacds, pagedSacds.Nodes...)
+
+		if !pagedSacds.PageInfo.HasNextPage {
+			break
+		}
+
+		if pagedSacds.PageInfo.EndCursor == "" || pagedSacds.PageInfo.EndCursor == after {
+			return nil, fmt.Errorf("identity API returned no progress cursor for sacds page after %q", after)
+		}
+		after = pagedSacds.PageInfo.EndCursor
+	}
+
+	// Store response in cache, only complete grant lists are cached
+	i.cache.Set(cacheKey, sacds, sacdCacheExpiration)
+
+	return sacds, nil
+}
+
+func (i *identityAPIService) FetchVehicleSacdsPage(ctx context.Context, tokenID int64, after string) (*models.SacdNodes, error) {
+	variables := map[string]interface{}{
+		"tokenId": tokenID,
+		"first":   vehiclesPageSize,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var result models.SingleVehicleSacds
+	if err := i.client.Query(ctx, VehicleSacdsByTokenIDQuery, variables, &result); err != nil {
 		return nil, err
 	}
 
-	return &pagedVehicles.Data.VehicleNodes, nil
+	return &result.Vehicle.Sacds, nil
 }
 
-func (i *identityAPIService) GetDeviceDefinitionByID(id string) (*models.DeviceDefinition, error) {
+func (i *identityAPIService) GetDeviceDefinitionByID(ctx context.Context, id string) (*models.DeviceDefinition, error) {
 	cached, err := i.GetCachedDeviceDefinitionByID(id)
 	if err == nil {
 		return cached, nil
 	}
 
-	return i.FetchDeviceDefinitionByID(id)
+	return i.FetchDeviceDefinitionByID(ctx, id)
 }
 
 func (i *identityAPIService) GetCachedDeviceDefinitionByID(id string) (*models.DeviceDefinition, error) {
@@ -140,55 +246,14 @@ func (i *identityAPIService) GetCachedDeviceDefinitionByID(id string) (*models.D
 	return nil, errors.New("not found")
 }
 
-func (i *identityAPIService) FetchDeviceDefinitionByID(id string) (*models.DeviceDefinition, error) {
-	graphqlQuery := fmt.Sprintf(DeviceDefinitionByIDQuery, id)
-
-	body, err := i.Query(graphqlQuery)
-	if err != nil {
-		return nil, err
-	}
-
-	var af models.GraphQlData[models.SingleDeviceDefinition]
-
-	if err = json.Unmarshal(body, &af); err != nil {
+func (i *identityAPIService) FetchDeviceDefinitionByID(ctx context.Context, id string) (*models.DeviceDefinition, error) {
+	var result models.SingleDeviceDefinition
+	if err := i.client.Query(ctx, DeviceDefinitionByIDQuery, map[string]interface{}{"id": id}, &result); err != nil {
 		return nil, err
 	}
 
 	// Store response in cache
-	i.cache.Set(fmt.Sprintf("dd_%s", id), &af.Data.DeviceDefinition, cache.DefaultExpiration)
-
-	return &af.Data.DeviceDefinition, nil
-}
-
-func (i *identityAPIService) Query(graphqlQuery string) ([]byte, error) {
-	requestPayload := models.GraphQLRequest{Query: graphqlQuery}
-	payloadBytes, err := json.Marshal(requestPayload)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := i.httpClient.ExecuteRequest(i.apiURL.String(), "POST", payloadBytes)
-	if err != nil {
-		i.logger.Err(err).Msg("Failed to send POST request")
-		return nil, err
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			i.logger.Err(err).Msg("Failed to close response body")
-		}
-	}(resp.Body)
-
-	if resp.StatusCode == 400 {
-		return nil, ErrBadRequest
-	}
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		i.logger.Err(err).Msgf("Failed to read response body")
-		return nil, err
-	}
+	i.cache.Set(fmt.Sprintf("dd_%s", id), &result.DeviceDefinition, cache.DefaultExpiration)
 
-	return body, nil
+	return &result.DeviceDefinition, nil
 }
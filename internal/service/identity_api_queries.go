@@ -1,61 +1,83 @@
 package service
 
-const DeviceDefinitionByIDQuery = `{
-	deviceDefinition(by: {id: "%s"}) {
-    	deviceDefinitionId
-    	manufacturer {
-      		name
-      		tokenId
-    	}
-    	model
-    	year
-  	}
+// Reusable fragments, queries list the fragments they use in buildGraphQLQuery
+
+const DefinitionFragment = `fragment DefinitionFields on Definition {
+	id
+	make
+	model
+	year
 }`
 
-const VehiclesByWalletAndCursorQuery = `{
-  	vehicles(filterBy: {owner: "%s"}, first: 100, after: %s) {
-		nodes {
-			id
+const SyntheticDeviceFragment = `fragment SyntheticDeviceFields on SyntheticDevice {
+	id
+	tokenId
+	mintedAt
+}`
+
+const VehicleFragment = `fragment VehicleFields on Vehicle {
+	id
+	tokenId
+	mintedAt
+	owner
+	definition {
+		...DefinitionFields
+	}
+	syntheticDevice {
+		...SyntheticDeviceFields
+	}
+}`
+
+var vehicleFragments = []string{VehicleFragment, DefinitionFragment, SyntheticDeviceFragment}
+
+var DeviceDefinitionByIDQuery = buildGraphQLQuery(`query DeviceDefinitionByID($id: String!) {
+	deviceDefinition(by: {id: $id}) {
+		deviceDefinitionId
+		manufacturer {
+			name
 			tokenId
-			mintedAt
-			owner
-			definition{
-				id
-				make
-				model
-				year
-			}
-			syntheticDevice {
-			  	id
-			  	tokenId
-				mintedAt
-			}
 		}
-    	pageInfo {
+		model
+		year
+	}
+}`)
+
+var VehiclesByFilterAndCursorQuery = buildGraphQLQuery(`query Vehicles($filterBy: VehiclesFilter, $first: Int, $after: String) {
+	vehicles(filterBy: $filterBy, first: $first, after: $after) {
+		nodes {
+			...VehicleFields
+		}
+		pageInfo {
 			hasPreviousPage
 			hasNextPage
 			startCursor
 			endCursor
-    	}
-  	}
-}`
-
-const VehicleByTokenIDQuery = `{
-	vehicle(tokenId: %s) {
-		id
-		tokenId
-		mintedAt
-		owner
-		definition{
-			id
-			make
-			model
-			year
 		}
-		syntheticDevice {
-			id
-			tokenId
-			mintedAt
+	}
+}`, vehicleFragments...)
+
+var VehicleByTokenIDQuery = buildGraphQLQuery(`query VehicleByTokenID($tokenId: Int!) {
+	vehicle(tokenId: $tokenId) {
+		...VehicleFields
+	}
+}`, vehicleFragments...)
+
+var VehicleSacdsByTokenIDQuery = buildGraphQLQuery(`query VehicleSacds($tokenId: Int!, $first: Int, $after: String) {
+	vehicle(tokenId: $tokenId) {
+		sacds(first: $first, after: $after) {
+			nodes {
+				grantee
+				permissions
+				source
+				createdAt
+				expiresAt
+			}
+			pageInfo {
+				hasPreviousPage
+				hasNextPage
+				startCursor
+				endCursor
+			}
 		}
-  	}
-}`
+	}
+}`)
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const identityTestOwner = "0x5e31bBc786D7bEd95216383787deA1ab0f1c1897"
+
+type IdentityAPITestSuite struct {
+	suite.Suite
+	server  *httptest.Server
+	queries atomic.Int32
+	// pages are served in order, the cursor of the next page is its index
+	pages [][]models.Vehicle
+	// sacdPages are served like pages for SACD queries
+	sacdPages [][]models.Sacd
+	// lastRequest is the last received GraphQL request
+	lastRequest atomic.Value
+	identity    IdentityAPI
+}
+
+func TestIdentityAPITestSuite(t *testing.T) {
+	suite.Run(t, new(IdentityAPITestSuite))
+}
+
+func (s *IdentityAPITestSuite) SetupTest() {
+	s.queries.Store(0)
+	s.pages = [][]models.Vehicle{
+		{{TokenID: 1, Owner: identityTestOwner}, {TokenID: 2, Owner: identityTestOwner}},
+		{{TokenID: 3, Owner: identityTestOwner}},
+		{{TokenID: 4, Owner: identityTestOwner}},
+	}
+	s.sacdPages = [][]models.Sacd{
+		{{Grantee: identityTestOwner, Permissions: "0x3"}, {Grantee: identityTestOwner, Permissions: "0xc"}},
+		{{Grantee: identityTestOwner, Permissions: "0x30"}},
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.queries.Add(1)
+
+		var request graphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		s.lastRequest.Store(request)
+
+		page := 0
+		if after, ok := request.Variables["after"].(string); ok {
+			page, _ = strconv.Atoi(after)
+		}
+
+		if strings.Contains(request.Query, "query VehicleSacds") {
+			var response struct {
+				Data models.SingleVehicleSacds `json:"data"`
+			}
+			response.Data.Vehicle.Sacds.Nodes = s.sacdPages[page]
+			if page+1 < len(s.sacdPages) {
+				response.Data.Vehicle.Sacds.PageInfo = models.PageInfo{HasNextPage: true, EndCursor: strconv.Itoa(page + 1)}
+			}
+
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		if _, ok := request.Variables["tokenId"]; ok {
+			_, _ = w.Write([]byte(`{"data":{"vehicle":null},"errors":[{"message":"No vehicle with that token ID.","extensions":{"code":"NOT_FOUND"}}]}`))
+			return
+		}
+
+		var response struct {
+			Data models.PagedVehicles `json:"data"`
+		}
+		response.Data.VehicleNodes.Nodes = s.pages[page]
+		if page+1 < len(s.pages) {
+			response.Data.VehicleNodes.PageInfo = models.PageInfo{HasNextPage: true, EndCursor: strconv.Itoa(page + 1)}
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+
+	apiURL, _ := url.Parse(s.server.URL)
+	s.identity = NewIdentityAPIService(zerolog.Nop(), config.Settings{IdentityAPIEndpoint: *apiURL})
+}
+
+func (s *IdentityAPITestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *IdentityAPITestSuite) TestFetchVehiclesByWalletAddressAllPages() {
+	vehicles, err := s.identity.FetchVehiclesByWalletAddress(context.Background(), identityTestOwner)
+	s.Require().NoError(err)
+
+	s.Len(vehicles, 4)
+	s.Equal(int32(3), s.queries.Load())
+}
+
+func (s *IdentityAPITestSuite) TestStreamVehiclesByWalletAddressStops() {
+	pages := 0
+	stopErr := fmt.Errorf("stop")
+	err := s.identity.StreamVehiclesByWalletAddress(context.Background(), identityTestOwner, func(page []models.Vehicle) error {
+		pages++
+		return stopErr
+	})
+
+	s.ErrorIs(err, stopErr)
+	s.Equal(1, pages)
+	s.Equal(int32(1), s.queries.Load())
+}
+
+func (s *IdentityAPITestSuite) TestStreamVehiclesByWalletAddressCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := s.identity.StreamVehiclesByWalletAddress(ctx, identityTestOwner, func(page []models.Vehicle) error {
+		cancel()
+		return nil
+	})
+
+	s.ErrorIs(err, context.Canceled)
+	s.Equal(int32(1), s.queries.Load())
+}
+
+func (s *IdentityAPITestSuite) TestGetVehiclesByWalletAddressCached() {
+	_, err := s.identity.GetVehiclesByWalletAddress(context.Background(), identityTestOwner)
+	s.Require().NoError(err)
+
+	vehicles, err := s.identity.GetVehiclesByWalletAddress(context.Background(), strings.ToLower(identityTestOwner))
+	s.Require().NoError(err)
+
+	s.Len(vehicles, 4)
+	s.Equal(int32(3), s.queries.Load())
+}
+
+func (s *IdentityAPITestSuite) TestFetchOwnedVehiclesFiltersByTokenIDs() {
+	s.pages = s.pages[:1]
+
+	vehicles, err := s.identity.FetchOwnedVehicles(context.Background(), identityTestOwner, []int64{1, 2})
+	s.Require().NoError(err)
+
+	s.Len(vehicles, 2)
+
+	request := s.lastRequest.Load().(graphQLRequest)
+	s.Equal(map[string]interface{}{
+		"owner":    identityTestOwner,
+		"tokenIds": []interface{}{float64(1), float64(2)},
+	}, request.Variables["filterBy"])
+	s.NotContains(request.Query, identityTestOwner)
+}
+
+func (s *IdentityAPITestSuite) TestFetchVehicleByTokenIDNotFound() {
+	vehicle, err := s.identity.FetchVehicleByTokenID(context.Background(), 5)
+
+	s.Nil(vehicle)
+	s.ErrorIs(err, ErrGraphQLNotFound)
+}
+
+func (s *IdentityAPITestSuite) TestGetVehicleSacdsAllPages() {
+	sacds, err := s.identity.GetVehicleSacds(context.Background(), 5)
+	s.Require().NoError(err)
+
+	s.Len(sacds, 3)
+	s.Equal("0x30", sacds[2].Permissions)
+	s.Equal(int32(2), s.queries.Load())
+
+	request := s.lastRequest.Load().(graphQLRequest)
+	s.Equal("1", request.Variables["after"])
+	s.Equal(float64(vehiclesPageSize), request.Variables["first"])
+}
+
+func (s *IdentityAPITestSuite) TestGetVehicleSacdsCached() {
+	_, err := s.identity.GetVehicleSacds(context.Background(), 5)
+	s.Require().NoError(err)
+
+	sacds, err := s.identity.GetVehicleSacds(context.Background(), 5)
+	s.Require().NoError(err)
+
+	s.Len(sacds, 3)
+	s.Equal(int32(2), s.queries.Load())
+}
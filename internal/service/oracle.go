@@ -22,6 +22,7 @@ type OracleService struct {
 	identityService IdentityAPI
 	logger          zerolog.Logger
 	settings        config.Settings
+	profiles        config.ChainProfiles
 	stop            chan bool
 	Db              *Vehicle
 	cache           *cache.Cache
@@ -32,12 +33,14 @@ func (cs *OracleService) Stop() {
 	cs.stop <- true
 }
 
-func NewOracleService(ctx context.Context, logger zerolog.Logger, settings config.Settings, db *Vehicle) (*OracleService, error) {
+func NewOracleService(ctx context.Context, logger zerolog.Logger, settings config.Settings, db *Vehicle, identityService IdentityAPI) (*OracleService, error) {
 	// Initialize the dimo node service
 	dimoNodeAPISvc := NewDimoNodeAPIService(logger, settings)
 
-	// Initialize the identity service
-	identityService := NewIdentityAPIService(logger, settings)
+	profiles, err := settings.ChainProfiles()
+	if err != nil {
+		return nil, err
+	}
 
 	// Initialize cache with a default expiration time of 10 minutes and cleanup interval of 15 minutes
 	c := cache.New(10*time.Minute, 15*time.Minute)
@@ -48,6 +51,7 @@ func NewOracleService(ctx context.Context, logger zerolog.Logger, settings confi
 		identityService: identityService,
 		logger:          logger,
 		settings:        settings,
+		profiles:        profiles,
 		Db:              db,
 		cache:           c,
 	}
@@ -135,8 +139,46 @@ func (cs *OracleService) HandleDeviceByVIN(msg interface{}) error {
 		return nil
 	}
 
+	// telemetry is paused for this VIN (paused by the owner or vehicle was transferred to a new owner), do not forward it.
+	// The flag is read uncached, a cached VIN would keep forwarding (or dropping) telemetry for up to 10 minutes
+	paused, err := cs.Db.IsTelemetryPaused(cs.Ctx, vehicleID)
+	if err != nil {
+		failedStatusEventCntr.Inc()
+		cs.logger.Error().Err(err).Msgf("Error reading telemetry pause flag for vehicleID: %s", vehicleID)
+		return err
+	}
+	if paused {
+		pausedStatusEventCntr.Inc()
+		cs.logger.Debug().Msgf("Telemetry is paused for VIN: %s , do not send to DIS", vehicle.Vin)
+		return nil
+	}
+
+	// only forward what the vehicle owner currently allows through SACD
+	if cs.settings.EnableSacdCheck {
+		forward, err := cs.applySacd(vehicle.VehicleTokenID.Int64, data)
+		if err != nil {
+			cs.logger.Error().Err(err).Msgf("Failed to check SACD permissions for VIN: %s", vehicle.Vin)
+			return err
+		}
+		if !forward {
+			cs.logger.Debug().Msgf("SACD permissions do not allow forwarding for VIN: %s , do not send to DIS", vehicle.Vin)
+			return nil
+		}
+
+		cloudEvent.Data, err = json.Marshal(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	profile, ok := cs.chainProfile(vehicle)
+	if !ok {
+		cs.logger.Error().Msgf("No chain profile for chain %d of VIN: %s , do not send to DIS", vehicle.ChainID.Int64, vehicle.Vin)
+		return fmt.Errorf("no chain profile for chain %d", vehicle.ChainID.Int64)
+	}
+
 	// Set the producer DID and subject for the CloudEvent
-	err = convert.SetProducerAndSubject(*vehicle, cloudEvent, cs.settings)
+	err = convert.SetProducerAndSubject(*vehicle, cloudEvent, profile)
 	if err != nil {
 		return err
 	}
@@ -145,6 +187,15 @@ func (cs *OracleService) HandleDeviceByVIN(msg interface{}) error {
 	return cs.HandleSendToDIS(cloudEvent)
 }
 
+// chainProfile returns the profile of the chain the VIN is minted on
+func (cs *OracleService) chainProfile(vehicle *dbmodels.Vin) (config.ChainProfile, bool) {
+	profiles := cs.profiles
+	if profiles == nil {
+		profiles = config.ChainProfiles{cs.settings.DefaultChainProfile()}
+	}
+	return profiles.ByID(vehicle.ChainID.Int64)
+}
+
 func (cs *OracleService) HandleSendToDIS(ce *cloudevent.CloudEvent[json.RawMessage]) error {
 	// Send the CloudEvent to the Dimo Node
 	statusCode, err := cs.dimoNodeAPISvc.SendToDimoNode(ce)
@@ -190,3 +241,8 @@ var failedStatusEventCntr = promauto.NewCounter(prometheus.CounterOpts{
 	Name: "oracle_example_failed_status_events_total",
 	Help: "Total number of failed events",
 })
+
+var pausedStatusEventCntr = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "oracle_example_paused_status_events_total",
+	Help: "Total number of events dropped because telemetry is paused for the VIN",
+})
@@ -24,7 +24,7 @@ import (
 	"time"
 )
 
-const vin = "1GGCM82633A123456"
+const testVin = "1GGCM82633A123456"
 
 type OracleTestSuite struct {
 	suite.Suite
@@ -39,10 +39,11 @@ const migrationsDirRelPath = "../db/migrations"
 // SetupSuite starts container db
 func (s *OracleTestSuite) SetupSuite() {
 	s.ctx = context.Background()
-	s.pdb, s.container, _ = test.StartContainerDatabase(context.Background(), s.T(), migrationsDirRelPath)
+	var settings config.Settings
+	s.pdb, s.container, settings = test.StartContainerDatabase(context.Background(), s.T(), migrationsDirRelPath)
 
 	logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr})
-	vehicleService := NewVehicleService(&s.pdb, &logger)
+	vehicleService := NewVehicleService(&s.pdb, &logger, &settings)
 	s.cs = &OracleService{
 		Ctx:    context.Background(),
 		Db:     vehicleService,
@@ -138,7 +139,7 @@ func (s *OracleTestSuite) TestDeviceMinted() {
 	oracleService := setupOracleService(server.URL)
 	oracleService.Db = s.cs.Db
 	dbVin := dbmodels.Vin{
-		Vin:              vin,
+		Vin:              testVin,
 		VehicleTokenID:   null.Int64From(456),
 		SyntheticTokenID: null.Int64From(789),
 		ExternalID:       null.StringFrom("ffbf0b52-d478-4320-9a1c-3b83f547f33b"),
@@ -165,6 +166,43 @@ func (s *OracleTestSuite) TestDeviceMinted() {
 	require.NoError(s.T(), err)
 }
 
+func (s *OracleTestSuite) TestDevicePausedWhileCached() {
+	// given
+	server, callCount := setupMockServer(s.T())
+	defer server.Close()
+
+	oracleService := setupOracleService(server.URL)
+	oracleService.Db = s.cs.Db
+	oracleService.identityService = &MockIdentityAPIService{
+		MockGetCachedVehicleByTokenID: func(tokenID int64) (*models.Vehicle, error) {
+			return &models.Vehicle{ID: "123", TokenID: tokenID}, nil
+		},
+	}
+	dbVin := dbmodels.Vin{
+		Vin:              testVin,
+		VehicleTokenID:   null.Int64From(456),
+		SyntheticTokenID: null.Int64From(789),
+		ExternalID:       null.StringFrom("ffbf0b52-d478-4320-9a1c-3b83f547f33b"),
+		ConnectionStatus: null.StringFrom("succeeded"),
+	}
+	require.NoError(s.T(), dbVin.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+	require.NoError(s.T(), oracleService.HandleDeviceByVIN([]byte(validCloudEventMsgNoProduceAndSubject)))
+	require.Equal(s.T(), 1, *callCount)
+
+	// when
+	// the VIN is now cached, pausing it must still stop its telemetry
+	dbVin.TelemetryPaused = true
+	_, err := dbVin.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(dbmodels.VinColumns.TelemetryPaused))
+	require.NoError(s.T(), err)
+
+	// then
+	err = oracleService.HandleDeviceByVIN([]byte(validCloudEventMsgNoProduceAndSubject))
+
+	// verify
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, *callCount)
+}
+
 func (s *OracleTestSuite) TestDeviceNotFound() {
 	// given
 	server, _ := setupMockServer(s.T())
@@ -190,7 +228,7 @@ func (s *OracleTestSuite) TestDeviceNotMinted() {
 	oracleService := setupOracleService(server.URL)
 	oracleService.Db = s.cs.Db
 	dbVin := dbmodels.Vin{
-		Vin:              vin,
+		Vin:              testVin,
 		ExternalID:       null.StringFrom("ffbf0b52-d478-4320-9a1c-3b83f547f33b"),
 		ConnectionStatus: null.StringFrom("succeeded"),
 	}
@@ -214,7 +252,7 @@ func (s *OracleTestSuite) TestDeviceSendValidCloudEvent() {
 	oracleService := setupOracleService(server.URL)
 	oracleService.Db = s.cs.Db
 	dbVin := dbmodels.Vin{
-		Vin:              vin,
+		Vin:              testVin,
 		ExternalID:       null.StringFrom("ffbf0b52-d478-4320-9a1c-3b83f547f33b"),
 		ConnectionStatus: null.StringFrom("succeeded"),
 	}
@@ -238,7 +276,7 @@ func (s *OracleTestSuite) TestDeviceSendInvalidCloudEvent() {
 	oracleService := setupOracleService(server.URL)
 	oracleService.Db = s.cs.Db
 	dbVin := dbmodels.Vin{
-		Vin:              vin,
+		Vin:              testVin,
 		ExternalID:       null.StringFrom("ffbf0b52-d478-4320-9a1c-3b83f547f33b"),
 		ConnectionStatus: null.StringFrom("succeeded"),
 	}
@@ -317,6 +355,8 @@ type MockIdentityAPIService struct {
 	MockGetCachedVehicleByTokenID    func(tokenID int64) (*models.Vehicle, error)
 	MockFetchVehicleByTokenID        func(tokenID int64) (*models.Vehicle, error)
 	MockFetchVehiclesByWalletAddress func(walletAddress string) ([]models.Vehicle, error)
+	MockFetchOwnedVehicles           func(owner string, tokenIDs []int64) ([]models.Vehicle, error)
+	MockGetVehicleSacds              func(tokenID int64) ([]models.Sacd, error)
 
 	MockGetDeviceDefinitionByID       func(id string) (*models.DeviceDefinition, error)
 	MockGetCachedDeviceDefinitionByID func(id string) (*models.DeviceDefinition, error)
@@ -330,21 +370,47 @@ func (m *MockIdentityAPIService) GetCachedVehicleByTokenID(tokenID int64) (*mode
 	return nil, nil
 }
 
-func (m *MockIdentityAPIService) FetchVehicleByTokenID(tokenID int64) (*models.Vehicle, error) {
+func (m *MockIdentityAPIService) FetchVehicleByTokenID(_ context.Context, tokenID int64) (*models.Vehicle, error) {
 	if m.MockFetchVehicleByTokenID != nil {
 		return m.MockFetchVehicleByTokenID(tokenID)
 	}
 	return nil, nil
 }
 
-func (m *MockIdentityAPIService) FetchVehiclesByWalletAddress(walletAddress string) ([]models.Vehicle, error) {
+func (m *MockIdentityAPIService) GetVehiclesByWalletAddress(ctx context.Context, walletAddress string) ([]models.Vehicle, error) {
+	return m.FetchVehiclesByWalletAddress(ctx, walletAddress)
+}
+
+func (m *MockIdentityAPIService) FetchVehiclesByWalletAddress(_ context.Context, walletAddress string) ([]models.Vehicle, error) {
 	if m.MockFetchVehiclesByWalletAddress != nil {
 		return m.MockFetchVehiclesByWalletAddress(walletAddress)
 	}
 	return nil, nil
 }
 
-func (m *MockIdentityAPIService) GetDeviceDefinitionByID(id string) (*models.DeviceDefinition, error) {
+func (m *MockIdentityAPIService) StreamVehiclesByWalletAddress(ctx context.Context, walletAddress string, fn func(page []models.Vehicle) error) error {
+	vehicles, err := m.FetchVehiclesByWalletAddress(ctx, walletAddress)
+	if err != nil {
+		return err
+	}
+	return fn(vehicles)
+}
+
+func (m *MockIdentityAPIService) FetchOwnedVehicles(_ context.Context, owner string, tokenIDs []int64) ([]models.Vehicle, error) {
+	if m.MockFetchOwnedVehicles != nil {
+		return m.MockFetchOwnedVehicles(owner, tokenIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockIdentityAPIService) GetVehicleSacds(_ context.Context, tokenID int64) ([]models.Sacd, error) {
+	if m.MockGetVehicleSacds != nil {
+		return m.MockGetVehicleSacds(tokenID)
+	}
+	return nil, nil
+}
+
+func (m *MockIdentityAPIService) GetDeviceDefinitionByID(_ context.Context, id string) (*models.DeviceDefinition, error) {
 	if m.MockGetCachedDeviceDefinitionByID != nil {
 		return m.MockGetCachedDeviceDefinitionByID(id)
 	}
@@ -358,7 +424,7 @@ func (m *MockIdentityAPIService) GetCachedDeviceDefinitionByID(id string) (*mode
 	return nil, nil
 }
 
-func (m *MockIdentityAPIService) FetchDeviceDefinitionByID(id string) (*models.DeviceDefinition, error) {
+func (m *MockIdentityAPIService) FetchDeviceDefinitionByID(_ context.Context, id string) (*models.DeviceDefinition, error) {
 	if m.MockFetchDeviceDefinitionByID != nil {
 		return m.MockFetchDeviceDefinitionByID(id)
 	}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/convert"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// DIMO privileges, each one takes 2 bits in the SACD permissions bitmap
+const (
+	PrivilegeNonLocationTelemetry = 1
+	PrivilegeCommands             = 2
+	PrivilegeCurrentLocation      = 3
+	PrivilegeAllTimeLocation      = 4
+	PrivilegeVinCredential        = 5
+	PrivilegeLiveData             = 6
+	PrivilegeRawData              = 7
+	PrivilegeApproximateLocation  = 8
+)
+
+const locationSignalPrefix = "currentLocation"
+
+var ErrNoSacdGrant = errors.New("no active SACD grant")
+
+// HasPrivilege checks if both bits of the privilege are set in the permissions bitmap
+func HasPrivilege(permissions *big.Int, privilege int) bool {
+	return permissions.Bit(privilege*2) == 1 && permissions.Bit(privilege*2+1) == 1
+}
+
+// ActivePermissions combines permissions of all non expired grants for the grantee
+func ActivePermissions(sacds []models.Sacd, grantee common.Address, now time.Time) (*big.Int, error) {
+	permissions := new(big.Int)
+	found := false
+
+	for _, sacd := range sacds {
+		if common.HexToAddress(sacd.Grantee) != grantee {
+			continue
+		}
+
+		if sacd.ExpiresAt != "" {
+			expiresAt, err := time.Parse(time.RFC3339, sacd.ExpiresAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SACD expiration %q: %w", sacd.ExpiresAt, err)
+			}
+			if !expiresAt.After(now) {
+				continue
+			}
+		}
+
+		value, ok := new(big.Int).SetString(strings.TrimPrefix(sacd.Permissions, "0x"), 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid SACD permissions %q", sacd.Permissions)
+		}
+
+		permissions.Or(permissions, value)
+		found = true
+	}
+
+	if !found {
+		return nil, ErrNoSacdGrant
+	}
+
+	return permissions, nil
+}
+
+// FilterSignalsByPermissions removes signals of categories that are not allowed by the permissions.
+// Returns the remaining signals and the number of removed location and non-location signals.
+func FilterSignalsByPermissions(signals []map[string]interface{}, permissions *big.Int) ([]map[string]interface{}, int, int) {
+	allowLocation := HasPrivilege(permissions, PrivilegeCurrentLocation) || HasPrivilege(permissions, PrivilegeAllTimeLocation)
+	allowTelemetry := HasPrivilege(permissions, PrivilegeNonLocationTelemetry)
+
+	filtered := make([]map[string]interface{}, 0, len(signals))
+	strippedLocation, strippedTelemetry := 0, 0
+
+	for _, signal := range signals {
+		name, _ := signal["name"].(string)
+		isLocation := strings.HasPrefix(name, locationSignalPrefix)
+
+		switch {
+		case isLocation && !allowLocation:
+			strippedLocation++
+		case !isLocation && !allowTelemetry:
+			strippedTelemetry++
+		default:
+			filtered = append(filtered, signal)
+		}
+	}
+
+	return filtered, strippedLocation, strippedTelemetry
+}
+
+// applySacd checks the vehicle's current SACD grants for the configured grantee and strips signals the owner did not allow.
+// Returns false if nothing is left to forward.
+func (cs *OracleService) applySacd(vehicleTokenID int64, data map[string]interface{}) (bool, error) {
+	sacds, err := cs.identityService.GetVehicleSacds(cs.Ctx, vehicleTokenID)
+	if err != nil {
+		sacdSuppressedEventCntr.WithLabelValues("check_failed").Inc()
+		return false, fmt.Errorf("failed to load SACD for vehicle %d: %w", vehicleTokenID, err)
+	}
+
+	permissions, err := ActivePermissions(sacds, cs.settings.SacdGrantee, time.Now())
+	if err != nil {
+		if errors.Is(err, ErrNoSacdGrant) {
+			sacdSuppressedEventCntr.WithLabelValues("no_grant").Inc()
+			return false, nil
+		}
+		sacdSuppressedEventCntr.WithLabelValues("check_failed").Inc()
+		return false, err
+	}
+
+	signalsArr, ok := data["signals"].([]interface{})
+	if !ok {
+		return false, fmt.Errorf("signals is not of type []interface{}")
+	}
+
+	signals, err := convert.CastToSliceOfMaps(signalsArr)
+	if err != nil {
+		return false, err
+	}
+
+	filtered, strippedLocation, strippedTelemetry := FilterSignalsByPermissions(signals, permissions)
+	sacdStrippedSignalsCntr.WithLabelValues("location").Add(float64(strippedLocation))
+	sacdStrippedSignalsCntr.WithLabelValues("telemetry").Add(float64(strippedTelemetry))
+
+	if len(filtered) == 0 {
+		sacdSuppressedEventCntr.WithLabelValues("all_signals_revoked").Inc()
+		return false, nil
+	}
+
+	data["signals"] = filtered
+
+	return true, nil
+}
+
+// Prometheus metrics
+var sacdSuppressedEventCntr = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "oracle_example_sacd_suppressed_events_total",
+	Help: "Total number of events not forwarded because of missing SACD permissions, by reason",
+}, []string{"reason"})
+
+var sacdStrippedSignalsCntr = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "oracle_example_sacd_stripped_signals_total",
+	Help: "Total number of signals removed from forwarded events because of revoked SACD permissions, by category",
+}, []string{"category"})
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type SacdTestSuite struct {
+	suite.Suite
+	grantee common.Address
+	now     time.Time
+}
+
+func (s *SacdTestSuite) SetupSuite() {
+	s.grantee = common.HexToAddress("0x5e31bBc786D7bEd95216383787deA1ab0f1c1897")
+	s.now = time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func TestSacdTestSuite(t *testing.T) {
+	suite.Run(t, new(SacdTestSuite))
+}
+
+func (s *SacdTestSuite) TestHasPrivilege() {
+	// privileges 1-6 granted
+	permissions, _ := new(big.Int).SetString("3ffc", 16)
+
+	s.True(HasPrivilege(permissions, PrivilegeNonLocationTelemetry))
+	s.True(HasPrivilege(permissions, PrivilegeAllTimeLocation))
+	s.True(HasPrivilege(permissions, PrivilegeLiveData))
+	s.False(HasPrivilege(permissions, PrivilegeRawData))
+	s.False(HasPrivilege(permissions, PrivilegeApproximateLocation))
+
+	// only one of the two bits set is not a grant
+	s.False(HasPrivilege(big.NewInt(0b0100), PrivilegeNonLocationTelemetry))
+}
+
+func (s *SacdTestSuite) TestActivePermissions() {
+	sacds := []models.Sacd{
+		{Grantee: s.grantee.Hex(), Permissions: "0xc", ExpiresAt: "2030-01-01T00:00:00Z"},
+		{Grantee: s.grantee.Hex(), Permissions: "0xc0", ExpiresAt: "2025-01-01T00:00:00Z"},
+		{Grantee: "0x0000000000000000000000000000000000000001", Permissions: "0x3ffc", ExpiresAt: "2030-01-01T00:00:00Z"},
+	}
+
+	permissions, err := ActivePermissions(sacds, s.grantee, s.now)
+	s.Require().NoError(err)
+	s.Equal(int64(0xc), permissions.Int64())
+
+	_, err = ActivePermissions(sacds[1:2], s.grantee, s.now)
+	s.ErrorIs(err, ErrNoSacdGrant)
+}
+
+func (s *SacdTestSuite) TestFilterSignalsByPermissions() {
+	signals := []map[string]interface{}{
+		{"name": "speed", "value": 55},
+		{"name": "currentLocationLatitude", "value": 42.1},
+		{"name": "currentLocationLongitude", "value": -83.2},
+	}
+
+	// non location telemetry only
+	filtered, location, telemetry := FilterSignalsByPermissions(signals, big.NewInt(0b1100))
+	s.Len(filtered, 1)
+	s.Equal("speed", filtered[0]["name"])
+	s.Equal(2, location)
+	s.Equal(0, telemetry)
+
+	// location only
+	filtered, location, telemetry = FilterSignalsByPermissions(signals, new(big.Int).Lsh(big.NewInt(0b11), PrivilegeAllTimeLocation*2))
+	s.Len(filtered, 2)
+	s.Equal(0, location)
+	s.Equal(1, telemetry)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
 	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
 	"github.com/DIMO-Network/oracle-example/internal/models"
 	"github.com/DIMO-Network/shared/pkg/db"
@@ -15,17 +16,19 @@ import (
 )
 
 type Vehicle struct {
-	pdb    *db.Store
-	logger *zerolog.Logger
+	pdb      *db.Store
+	logger   *zerolog.Logger
+	settings *config.Settings
 }
 
 var ErrVehicleNotFound = errors.New("vehicle not found")
 
 // NewVehicleService creates a new instance of Vehicle.
-func NewVehicleService(pdb *db.Store, logger *zerolog.Logger) *Vehicle {
+func NewVehicleService(pdb *db.Store, logger *zerolog.Logger, settings *config.Settings) *Vehicle {
 	return &Vehicle{
-		pdb:    pdb,
-		logger: logger,
+		pdb:      pdb,
+		logger:   logger,
+		settings: settings,
 	}
 }
 
@@ -60,6 +63,23 @@ func (ds *Vehicle) GetVehicleByVin(ctx context.Context, vehicleID string) (*dbmo
 	return vin, nil
 }
 
+// IsTelemetryPaused reads the pause flag of the VIN, it is not cached because the owner, a transfer or an operator can
+// flip it at any time
+func (ds *Vehicle) IsTelemetryPaused(ctx context.Context, vehicleID string) (bool, error) {
+	vin, err := dbmodels.Vins(
+		qm.Select(dbmodels.VinColumns.TelemetryPaused),
+		dbmodels.VinWhere.Vin.EQ(vehicleID),
+	).One(ctx, ds.pdb.DBS().Writer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrVehicleNotFound
+		}
+		return false, err
+	}
+
+	return vin.TelemetryPaused, nil
+}
+
 // GetVehiclesByVins retrieves vehicles by their VINs.
 func (ds *Vehicle) GetVehiclesByVins(ctx context.Context, vehicleIDs []string) (dbmodels.VinSlice, error) {
 	tx, err := ds.pdb.DBS().Writer.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
@@ -407,3 +427,28 @@ func (ds *Vehicle) GetVehiclesFromDB(ctx context.Context) (dbmodels.VinSlice, er
 
 	return vins, nil
 }
+
+// GetVinSacdsByVins retrieves the SACD grant state tracked for the given VINs.
+func (ds *Vehicle) GetVinSacdsByVins(ctx context.Context, vins []string) (dbmodels.VinSacdSlice, error) {
+	sacds, err := dbmodels.VinSacds(
+		dbmodels.VinSacdWhere.Vin.IN(vins),
+		qm.OrderBy(dbmodels.VinSacdColumns.Vin),
+	).All(ctx, ds.pdb.DBS().Reader)
+	if err != nil {
+		ds.logger.Error().Err(err).Msg("Failed to get VIN SACDs")
+		return nil, fmt.Errorf("failed to get VIN SACDs: %w", err)
+	}
+	return sacds, nil
+}
+
+// UpsertVinSacd inserts or updates the SACD grant state of a VIN for a grantee.
+func (ds *Vehicle) UpsertVinSacd(ctx context.Context, sacd *dbmodels.VinSacd) error {
+	err := sacd.Upsert(ctx, ds.pdb.DBS().Writer, true,
+		[]string{dbmodels.VinSacdColumns.Vin, dbmodels.VinSacdColumns.Grantee},
+		boil.Infer(), boil.Infer())
+	if err != nil {
+		ds.logger.Error().Err(err).Msgf("Failed to upsert SACD for vehicle %s", sacd.Vin)
+		return fmt.Errorf("failed to upsert VIN SACD: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"strconv"
+)
+
+// VehicleListSortFields maps the supported sort fields to their SQL expressions, NULLs are coalesced so keyset pagination works
+var VehicleListSortFields = map[string]string{
+	"vin":    "vins.vin",
+	"status": "vins.onboarding_status",
+	"make":   "COALESCE(vi.make, '')",
+	"model":  "COALESCE(vi.model, '')",
+	"year":   "COALESCE(vi.year, 0)",
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// VehicleListCursor points at the last vehicle of the previous page
+type VehicleListCursor struct {
+	Value string `json:"v"`
+	Vin   string `json:"vin"`
+}
+
+func (c VehicleListCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeVehicleListCursor(cursor string) (*VehicleListCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	result := new(VehicleListCursor)
+	if err := json.Unmarshal(b, result); err != nil || result.Vin == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return result, nil
+}
+
+type VehicleListFilter struct {
+	Owner common.Address
+	// StatusRange is an inclusive onboarding status range, eg. one of the onboarding phases
+	StatusRange      *[2]int
+	ConnectionStatus string
+	Make             string
+	Model            string
+	Year             int
+	ErrorCode        string
+	SortBy           string
+	Desc             bool
+	After            *VehicleListCursor
+	Limit            int
+}
+
+type VehicleListPage struct {
+	Vins dbmodels.VinSlice
+	// Identities holds cached Identity API data by vehicle token ID, VINs without cached data are missing
+	Identities map[int64]*dbmodels.VehicleIdentity
+	Next       *VehicleListCursor
+}
+
+// ListVehicles returns a page of the owner's VINs joined with cached Identity API data
+func (ds *Vehicle) ListVehicles(ctx context.Context, filter VehicleListFilter) (*VehicleListPage, error) {
+	sortField := filter.SortBy
+	if sortField == "" {
+		sortField = "vin"
+	}
+
+	sortExpr, ok := VehicleListSortFields[sortField]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort field %q", sortField)
+	}
+
+	direction, comparison := "ASC", ">"
+	if filter.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	mods := []qm.QueryMod{
+		qm.LeftOuterJoin(fmt.Sprintf("%s.vehicle_identities vi ON vi.vehicle_token_id = vins.vehicle_token_id", ds.settings.DB.Name)),
+		dbmodels.VinWhere.OwnerAddress.EQ(null.StringFrom(filter.Owner.Hex())),
+	}
+
+	if filter.StatusRange != nil {
+		mods = append(mods,
+			dbmodels.VinWhere.OnboardingStatus.GTE(filter.StatusRange[0]),
+			dbmodels.VinWhere.OnboardingStatus.LTE(filter.StatusRange[1]),
+		)
+	}
+	if filter.ConnectionStatus != "" {
+		mods = append(mods, dbmodels.VinWhere.ConnectionStatus.EQ(null.StringFrom(filter.ConnectionStatus)))
+	}
+	if filter.ErrorCode != "" {
+		mods = append(mods, dbmodels.VinWhere.OperationErrorCode.EQ(null.StringFrom(filter.ErrorCode)))
+	}
+	if filter.Make != "" {
+		mods = append(mods, qm.Where("lower(vi.make) = lower(?)", filter.Make))
+	}
+	if filter.Model != "" {
+		mods = append(mods, qm.Where("lower(vi.model) = lower(?)", filter.Model))
+	}
+	if filter.Year != 0 {
+		mods = append(mods, qm.Where("vi.year = ?", filter.Year))
+	}
+
+	if filter.After != nil {
+		var value interface{} = filter.After.Value
+		if sortField == "status" || sortField == "year" {
+			intValue, err := strconv.Atoi(filter.After.Value)
+			if err != nil {
+				return nil, ErrInvalidCursor
+			}
+			value = intValue
+		}
+		mods = append(mods, qm.Where(fmt.Sprintf("(%s, vins.vin) %s (?, ?)", sortExpr, comparison), value, filter.After.Vin))
+	}
+
+	mods = append(mods,
+		qm.OrderBy(fmt.Sprintf("%s %s, vins.vin %s", sortExpr, direction, direction)),
+		qm.Limit(filter.Limit+1),
+	)
+
+	vins, err := dbmodels.Vins(mods...).All(ctx, ds.pdb.DBS().Reader)
+	if err != nil {
+		ds.logger.Error().Err(err).Msg("Failed to list VINs")
+		return nil, fmt.Errorf("failed to list VINs: %w", err)
+	}
+
+	page := &VehicleListPage{Vins: vins}
+	hasNext := len(vins) > filter.Limit
+	if hasNext {
+		page.Vins = vins[:filter.Limit]
+	}
+
+	page.Identities, err = ds.GetVehicleIdentities(ctx, page.Vins)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasNext {
+		last := page.Vins[len(page.Vins)-1]
+		page.Next = &VehicleListCursor{
+			Value: vehicleListSortValue(sortField, last, page.Identities[last.VehicleTokenID.Int64]),
+			Vin:   last.Vin,
+		}
+	}
+
+	return page, nil
+}
+
+// ClaimUnownedVins sets the owner of minted VINs whose owner isn't tracked yet, eg. onboarded before owners were stored,
+// to the wallet owning their vehicle token on chain. Returns the number of VINs updated.
+func (ds *Vehicle) ClaimUnownedVins(ctx context.Context, owner common.Address, tokenIDs []int64) (int64, error) {
+	if len(tokenIDs) == 0 {
+		return 0, nil
+	}
+
+	updated, err := dbmodels.Vins(
+		dbmodels.VinWhere.OwnerAddress.IsNull(),
+		dbmodels.VinWhere.VehicleTokenID.IN(tokenIDs),
+	).UpdateAll(ctx, ds.pdb.DBS().Writer, dbmodels.M{dbmodels.VinColumns.OwnerAddress: owner.Hex()})
+	if err != nil {
+		ds.logger.Error().Err(err).Msg("Failed to claim unowned VINs")
+		return 0, fmt.Errorf("failed to claim unowned VINs: %w", err)
+	}
+
+	return updated, nil
+}
+
+func vehicleListSortValue(sortField string, vin *dbmodels.Vin, identity *dbmodels.VehicleIdentity) string {
+	if identity == nil {
+		identity = &dbmodels.VehicleIdentity{}
+	}
+
+	switch sortField {
+	case "status":
+		return strconv.Itoa(vin.OnboardingStatus)
+	case "make":
+		return identity.Make.String
+	case "model":
+		return identity.Model.String
+	case "year":
+		return strconv.Itoa(identity.Year.Int)
+	default:
+		return vin.Vin
+	}
+}
+
+// GetVehicleIdentities retrieves cached Identity API data for the VINs, by vehicle token ID
+func (ds *Vehicle) GetVehicleIdentities(ctx context.Context, vins dbmodels.VinSlice) (map[int64]*dbmodels.VehicleIdentity, error) {
+	tokenIDs := make([]int64, 0, len(vins))
+	for _, vin := range vins {
+		if vin.VehicleTokenID.Valid {
+			tokenIDs = append(tokenIDs, vin.VehicleTokenID.Int64)
+		}
+	}
+
+	result := make(map[int64]*dbmodels.VehicleIdentity, len(tokenIDs))
+	if len(tokenIDs) == 0 {
+		return result, nil
+	}
+
+	identities, err := dbmodels.VehicleIdentities(dbmodels.VehicleIdentityWhere.VehicleTokenID.IN(tokenIDs)).All(ctx, ds.pdb.DBS().Reader)
+	if err != nil {
+		ds.logger.Error().Err(err).Msg("Failed to get vehicle identities")
+		return nil, fmt.Errorf("failed to get vehicle identities: %w", err)
+	}
+
+	for _, identity := range identities {
+		result[identity.VehicleTokenID] = identity
+	}
+
+	return result, nil
+}
+
+// UpsertVehicleIdentity stores Identity API data of the vehicle in the DB cache
+func (ds *Vehicle) UpsertVehicleIdentity(ctx context.Context, vehicle *models.Vehicle) (*dbmodels.VehicleIdentity, error) {
+	identity := VehicleIdentityFromModel(vehicle)
+	err := identity.Upsert(ctx, ds.pdb.DBS().Writer, true, []string{dbmodels.VehicleIdentityColumns.VehicleTokenID}, boil.Infer(), boil.Infer())
+	if err != nil {
+		ds.logger.Error().Err(err).Msgf("Failed to upsert identity for vehicle %d", vehicle.TokenID)
+		return nil, fmt.Errorf("failed to upsert vehicle identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// VehicleIdentityFromModel converts Identity API vehicle into its DB cache record
+func VehicleIdentityFromModel(vehicle *models.Vehicle) *dbmodels.VehicleIdentity {
+	identity := &dbmodels.VehicleIdentity{
+		VehicleTokenID: vehicle.TokenID,
+		DefinitionID:   null.NewString(vehicle.Definition.ID, vehicle.Definition.ID != ""),
+		Make:           null.NewString(vehicle.Definition.Make, vehicle.Definition.Make != ""),
+		Model:          null.NewString(vehicle.Definition.Model, vehicle.Definition.Model != ""),
+		Year:           null.NewInt(vehicle.Definition.Year, vehicle.Definition.Year != 0),
+		MintedAt:       null.NewString(vehicle.MintedAt, vehicle.MintedAt != ""),
+	}
+
+	if vehicle.Owner != "" {
+		identity.Owner = null.StringFrom(common.HexToAddress(vehicle.Owner).Hex())
+	}
+	if vehicle.SyntheticDevice.TokenID != 0 {
+		identity.SyntheticTokenID = null.Int64From(vehicle.SyntheticDevice.TokenID)
+	}
+
+	return identity
+}
+
+// VehicleFromIdentity fills the Identity API vehicle model from the VIN record and its cached identity, if any
+func VehicleFromIdentity(vin *dbmodels.Vin, identity *dbmodels.VehicleIdentity) models.Vehicle {
+	vehicle := models.Vehicle{
+		VIN:                 vin.Vin,
+		TokenID:             vin.VehicleTokenID.Int64,
+		Owner:               vin.OwnerAddress.String,
+		ConnectionStatus:    vin.ConnectionStatus.String,
+		DisconnectionStatus: vin.DisconnectionStatus.String,
+		SyntheticDevice: models.SyntheticDevice{
+			TokenID: vin.SyntheticTokenID.Int64,
+		},
+		Definition: models.Definition{
+			ID: vin.DeviceDefinitionID.String,
+		},
+	}
+
+	if identity != nil {
+		vehicle.MintedAt = identity.MintedAt.String
+		if identity.DefinitionID.Valid {
+			vehicle.Definition.ID = identity.DefinitionID.String
+		}
+		vehicle.Definition.Make = identity.Make.String
+		vehicle.Definition.Model = identity.Model.String
+		vehicle.Definition.Year = identity.Year.Int
+	}
+
+	return vehicle
+}
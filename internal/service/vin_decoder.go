@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	"github.com/DIMO-Network/oracle-example/internal/vin"
+	"github.com/DIMO-Network/shared/pkg/logfields"
+	"github.com/DIMO-Network/yaml"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNoLocalVinMatch = errors.New("no local VIN decoding match")
+
+// VinOverride maps VINs matching the pattern to a device definition, for fleets the remote decoder doesn't know.
+// Pattern uses path.Match syntax (? any character, * any suffix, [A-C] character class),
+// {year} in the definition ID is replaced by the model year decoded from the VIN.
+type VinOverride struct {
+	Pattern            string `yaml:"pattern"`
+	DeviceDefinitionID string `yaml:"definitionId"`
+	// AllowInvalidCheckDigit is needed for VINs from regions where the check digit isn't mandatory
+	AllowInvalidCheckDigit bool `yaml:"allowInvalidCheckDigit"`
+}
+
+// LocalVinDecoding is what can be decoded from the VIN without any remote call
+type LocalVinDecoding struct {
+	Vin             string
+	WMI             string
+	Manufacturer    string
+	ModelYear       int
+	CheckDigitValid bool
+}
+
+// LocalVinDecoder decodes VINs offline and resolves their device definition from the override table
+type LocalVinDecoder struct {
+	overrides []VinOverride
+	now       func() time.Time
+}
+
+func NewLocalVinDecoder(overrides []VinOverride) (*LocalVinDecoder, error) {
+	for _, override := range overrides {
+		if _, err := path.Match(override.Pattern, ""); err != nil || override.DeviceDefinitionID == "" {
+			return nil, fmt.Errorf("invalid VIN override %q -> %q", override.Pattern, override.DeviceDefinitionID)
+		}
+	}
+
+	return &LocalVinDecoder{
+		overrides: overrides,
+		now:       time.Now,
+	}, nil
+}
+
+// LoadVinOverrides reads the override table from a yaml file with a list of VinOverride
+func LoadVinOverrides(file string) ([]VinOverride, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read VIN overrides file: %w", err)
+	}
+
+	var overrides []VinOverride
+	if err = yaml.Unmarshal(content, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse VIN overrides file: %w", err)
+	}
+
+	return overrides, nil
+}
+
+func (l *LocalVinDecoder) Decode(rawVin string) LocalVinDecoding {
+	normalized := vin.Normalize(rawVin)
+	decoding := LocalVinDecoding{Vin: normalized}
+	if vin.ValidateFormat(normalized) != nil {
+		return decoding
+	}
+
+	decoding.WMI = vin.WMI(normalized)
+	decoding.Manufacturer = vin.Manufacturer(normalized)
+	decoding.CheckDigitValid = vin.IsValidCheckDigit(normalized)
+	decoding.ModelYear = vin.ModelYear(normalized, l.now())
+
+	return decoding
+}
+
+// DecodeVin resolves the device definition from the override table
+func (l *LocalVinDecoder) DecodeVin(rawVin, _ string) (*DecodeVinResponse, error) {
+	decoding := l.Decode(rawVin)
+	if decoding.WMI == "" {
+		return nil, ErrNoLocalVinMatch
+	}
+
+	for _, override := range l.overrides {
+		if matched, _ := path.Match(override.Pattern, decoding.Vin); !matched {
+			continue
+		}
+
+		if !decoding.CheckDigitValid && !override.AllowInvalidCheckDigit {
+			return nil, fmt.Errorf("%w: invalid check digit", ErrNoLocalVinMatch)
+		}
+
+		definitionID := override.DeviceDefinitionID
+		if strings.Contains(definitionID, "{year}") {
+			if decoding.ModelYear == 0 {
+				return nil, fmt.Errorf("%w: unknown model year", ErrNoLocalVinMatch)
+			}
+			definitionID = strings.ReplaceAll(definitionID, "{year}", strconv.Itoa(decoding.ModelYear))
+		}
+
+		return &DecodeVinResponse{DeviceDefinitionID: definitionID}, nil
+	}
+
+	return nil, ErrNoLocalVinMatch
+}
+
+// FallbackVinDecoder uses the remote decoder and, when it fails or finds no match, the local override table
+type FallbackVinDecoder struct {
+	remote DeviceDefinitionsAPI
+	local  *LocalVinDecoder
+	logger zerolog.Logger
+}
+
+// NewFallbackVinDecoder wraps the remote decoder when VIN_DECODER_OVERRIDES_FILE is set, otherwise returns it as is
+func NewFallbackVinDecoder(logger zerolog.Logger, settings config.Settings, remote DeviceDefinitionsAPI) (DeviceDefinitionsAPI, error) {
+	if settings.VinDecoderOverridesFile == "" {
+		return remote, nil
+	}
+
+	overrides, err := LoadVinOverrides(settings.VinDecoderOverridesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := NewLocalVinDecoder(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FallbackVinDecoder{
+		remote: remote,
+		local:  local,
+		logger: logger,
+	}, nil
+}
+
+func (f *FallbackVinDecoder) DecodeVin(rawVin, countryCode string) (*DecodeVinResponse, error) {
+	decoded, remoteErr := f.remote.DecodeVin(rawVin, countryCode)
+	if remoteErr == nil && decoded != nil && decoded.DeviceDefinitionID != "" {
+		vinDecodeCntr.WithLabelValues("remote").Inc()
+		return decoded, nil
+	}
+
+	local, err := f.local.DecodeVin(rawVin, countryCode)
+	if err != nil {
+		vinDecodeCntr.WithLabelValues("none").Inc()
+		if remoteErr != nil {
+			return nil, fmt.Errorf("remote VIN decoding failed: %w, local: %w", remoteErr, err)
+		}
+		return nil, err
+	}
+
+	f.logger.Info().Err(remoteErr).Str(logfields.VIN, rawVin).Str(logfields.DefinitionID, local.DeviceDefinitionID).Msg("VIN decoded with local override table")
+	vinDecodeCntr.WithLabelValues("local").Inc()
+
+	return local, nil
+}
+
+// Prometheus metrics
+var vinDecodeCntr = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "oracle_example_vin_decode_total",
+	Help: "VIN decodings by source: remote decoder, local override table or none",
+}, []string{"source"})
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type VinDecoderTestSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (s *VinDecoderTestSuite) SetupSuite() {
+	s.now = time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func TestVinDecoderTestSuite(t *testing.T) {
+	suite.Run(t, new(VinDecoderTestSuite))
+}
+
+func (s *VinDecoderTestSuite) TestDecode() {
+	decoder, err := NewLocalVinDecoder(nil)
+	s.Require().NoError(err)
+	decoder.now = func() time.Time { return s.now }
+
+	decoding := decoder.Decode(" 1hgcm82633a004352 ")
+
+	s.Equal(LocalVinDecoding{
+		Vin:             "1HGCM82633A004352",
+		WMI:             "1HG",
+		Manufacturer:    "Honda",
+		ModelYear:       2003,
+		CheckDigitValid: true,
+	}, decoding)
+}
+
+func (s *VinDecoderTestSuite) TestDecodeVinOverrides() {
+	decoder, err := NewLocalVinDecoder([]VinOverride{
+		{Pattern: "1HGCM826?3*", DeviceDefinitionID: "honda_accord_{year}"},
+		{Pattern: "WF0XXXGCD*", DeviceDefinitionID: "ford_focus_2020", AllowInvalidCheckDigit: true},
+		{Pattern: "1HG*", DeviceDefinitionID: "honda_civic_2003"},
+	})
+	s.Require().NoError(err)
+	decoder.now = func() time.Time { return s.now }
+
+	decoded, err := decoder.DecodeVin("1HGCM82633A004352", "USA")
+	s.Require().NoError(err)
+	s.Equal("honda_accord_2003", decoded.DeviceDefinitionID)
+
+	// first match wins, but invalid check digit is refused unless allowed
+	_, err = decoder.DecodeVin("1HGCM82643A004352", "USA")
+	s.ErrorIs(err, ErrNoLocalVinMatch)
+
+	decoded, err = decoder.DecodeVin("WF0XXXGCDX1234567", "DEU")
+	s.Require().NoError(err)
+	s.Equal("ford_focus_2020", decoded.DeviceDefinitionID)
+
+	_, err = decoder.DecodeVin("5YJ3E1EA0SF000000", "USA")
+	s.ErrorIs(err, ErrNoLocalVinMatch)
+}
+
+func (s *VinDecoderTestSuite) TestNewLocalVinDecoderInvalidOverride() {
+	_, err := NewLocalVinDecoder([]VinOverride{{Pattern: "1HG[", DeviceDefinitionID: "honda_civic_2003"}})
+	s.Error(err)
+
+	_, err = NewLocalVinDecoder([]VinOverride{{Pattern: "1HG*"}})
+	s.Error(err)
+}
+
+type failingVinDecoder struct {
+	response *DecodeVinResponse
+	err      error
+}
+
+func (f failingVinDecoder) DecodeVin(_, _ string) (*DecodeVinResponse, error) {
+	return f.response, f.err
+}
+
+func (s *VinDecoderTestSuite) TestFallbackVinDecoder() {
+	local, err := NewLocalVinDecoder([]VinOverride{{Pattern: "1HG*", DeviceDefinitionID: "honda_accord_2003"}})
+	s.Require().NoError(err)
+
+	// remote is preferred
+	fallback := &FallbackVinDecoder{remote: failingVinDecoder{response: &DecodeVinResponse{DeviceDefinitionID: "honda_accord_2003_remote"}}, local: local}
+	decoded, err := fallback.DecodeVin("1HGCM82633A004352", "USA")
+	s.Require().NoError(err)
+	s.Equal("honda_accord_2003_remote", decoded.DeviceDefinitionID)
+
+	// remote down
+	fallback.remote = failingVinDecoder{err: errors.New("connection refused")}
+	decoded, err = fallback.DecodeVin("1HGCM82633A004352", "USA")
+	s.Require().NoError(err)
+	s.Equal("honda_accord_2003", decoded.DeviceDefinitionID)
+
+	// remote without match
+	fallback.remote = failingVinDecoder{response: &DecodeVinResponse{}}
+	decoded, err = fallback.DecodeVin("1HGCM82633A004352", "USA")
+	s.Require().NoError(err)
+	s.Equal("honda_accord_2003", decoded.DeviceDefinitionID)
+
+	// neither knows it
+	fallback.remote = failingVinDecoder{err: errors.New("connection refused")}
+	_, err = fallback.DecodeVin("5YJ3E1EA0SF000000", "USA")
+	s.ErrorIs(err, ErrNoLocalVinMatch)
+	s.ErrorContains(err, "connection refused")
+}
+
+func (s *VinDecoderTestSuite) TestLoadVinOverridesSample() {
+	overrides, err := LoadVinOverrides("../../resources/vin_overrides.sample.yaml")
+	s.Require().NoError(err)
+
+	_, err = NewLocalVinDecoder(overrides)
+	s.NoError(err)
+	s.NotEmpty(overrides)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"time"
+)
+
+// VinDriftCursor points at the last drift of the previous page, drifts are sorted newest first
+type VinDriftCursor struct {
+	DetectedAt time.Time `json:"t"`
+	Vin        string    `json:"vin"`
+	Kind       string    `json:"kind"`
+}
+
+func (c VinDriftCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeVinDriftCursor(cursor string) (*VinDriftCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	result := new(VinDriftCursor)
+	if err := json.Unmarshal(b, result); err != nil || result.Vin == "" || result.Kind == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return result, nil
+}
+
+type VinDriftFilter struct {
+	IncludeResolved bool
+	After           *VinDriftCursor
+	Limit           int
+}
+
+type VinDriftPage struct {
+	Drifts dbmodels.VinDriftSlice
+	Next   *VinDriftCursor
+}
+
+// GetVinDrifts retrieves a page of chain/DB mismatches found by the reconciliation job, newest first.
+func (ds *Vehicle) GetVinDrifts(ctx context.Context, filter VinDriftFilter) (*VinDriftPage, error) {
+	var mods []qm.QueryMod
+	if !filter.IncludeResolved {
+		mods = append(mods, dbmodels.VinDriftWhere.ResolvedAt.IsNull())
+	}
+	if filter.After != nil {
+		mods = append(mods, qm.Where("(detected_at, vin, kind) < (?, ?, ?)", filter.After.DetectedAt, filter.After.Vin, filter.After.Kind))
+	}
+	mods = append(mods,
+		qm.OrderBy("detected_at DESC, vin DESC, kind DESC"),
+		qm.Limit(filter.Limit+1),
+	)
+
+	drifts, err := dbmodels.VinDrifts(mods...).All(ctx, ds.pdb.DBS().Reader)
+	if err != nil {
+		ds.logger.Error().Err(err).Msg("Failed to get VIN drifts")
+		return nil, fmt.Errorf("failed to get VIN drifts: %w", err)
+	}
+
+	page := &VinDriftPage{Drifts: drifts}
+	if len(drifts) > filter.Limit {
+		page.Drifts = drifts[:filter.Limit]
+		last := page.Drifts[len(page.Drifts)-1]
+		page.Next = &VinDriftCursor{DetectedAt: last.DetectedAt, Vin: last.Vin, Kind: last.Kind}
+	}
+
+	return page, nil
+}
+
+// CountUnresolvedVinDrifts returns the number of unresolved drifts by kind, over all pages
+func (ds *Vehicle) CountUnresolvedVinDrifts(ctx context.Context) (map[string]int, error) {
+	var rows []struct {
+		Kind  string `boil:"kind"`
+		Count int    `boil:"count"`
+	}
+	err := dbmodels.VinDrifts(
+		qm.Select(dbmodels.VinDriftColumns.Kind, "count(*) AS count"),
+		dbmodels.VinDriftWhere.ResolvedAt.IsNull(),
+		qm.GroupBy(dbmodels.VinDriftColumns.Kind),
+	).Bind(ctx, ds.pdb.DBS().Reader, &rows)
+	if err != nil {
+		ds.logger.Error().Err(err).Msg("Failed to count VIN drifts")
+		return nil, fmt.Errorf("failed to count VIN drifts: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Kind] = row.Count
+	}
+	return counts, nil
+}
@@ -150,6 +150,14 @@ func AuthInjectorTestHandler(userID string, userEthAddr *common.Address) fiber.H
 	}
 }
 
+// WalletInjectorTestHandler sets the wallet as the access middleware does after checking the JWT
+func WalletInjectorTestHandler(wallet common.Address) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("wallet", wallet)
+		return c.Next()
+	}
+}
+
 func BuildRequest(method, url, body string) *http.Request {
 	req, _ := http.NewRequest(
 		method,
@@ -0,0 +1,162 @@
+// Package vin normalizes and validates Vehicle Identification Numbers (ISO 3779).
+package vin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const Length = 17
+
+var (
+	ErrInvalidLength     = errors.New("VIN must be 17 characters")
+	ErrInvalidCharacters = errors.New("VIN contains invalid characters, only A-Z (except I, O, Q) and 0-9 are allowed")
+	ErrInvalidCheckDigit = errors.New("invalid VIN check digit")
+)
+
+type Region string
+
+const (
+	RegionAfrica       Region = "Africa"
+	RegionAsia         Region = "Asia"
+	RegionEurope       Region = "Europe"
+	RegionNorthAmerica Region = "North America"
+	RegionOceania      Region = "Oceania"
+	RegionSouthAmerica Region = "South America"
+	RegionUnknown      Region = "Unknown"
+)
+
+// transliteration maps VIN letters to their check digit values, I, O and Q are not allowed in VINs
+var transliteration = map[rune]int{
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+var checkDigitWeights = [Length]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// modelYearChars are the model year characters (position 10) in order, the sequence repeats every 30 years from 1980
+const modelYearChars = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+// Normalize uppercases the VIN and removes all whitespace
+func Normalize(vin string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, vin)
+}
+
+// ValidateFormat checks length and character set of the normalized VIN
+func ValidateFormat(vin string) error {
+	if len(vin) != Length {
+		return fmt.Errorf("%w, got %d", ErrInvalidLength, len(vin))
+	}
+
+	for i, char := range vin {
+		if _, ok := transliteration[char]; !ok && (char < '0' || char > '9') {
+			return fmt.Errorf("%w: %q at position %d", ErrInvalidCharacters, char, i+1)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the normalized VIN format and, for North American VINs where it's mandatory, the check digit
+func Validate(vin string) error {
+	if err := ValidateFormat(vin); err != nil {
+		return err
+	}
+
+	if GetRegion(vin) == RegionNorthAmerica {
+		expected, _ := CheckDigit(vin)
+		if vin[8] != expected {
+			return fmt.Errorf("%w: expected %c, got %c", ErrInvalidCheckDigit, expected, vin[8])
+		}
+	}
+
+	return nil
+}
+
+// CheckDigit computes the expected 9th character of the VIN
+func CheckDigit(vin string) (byte, error) {
+	if err := ValidateFormat(vin); err != nil {
+		return 0, err
+	}
+
+	sum := 0
+	for i, char := range vin {
+		value, ok := transliteration[char]
+		if !ok {
+			value = int(char - '0')
+		}
+		sum += value * checkDigitWeights[i]
+	}
+
+	if sum%11 == 10 {
+		return 'X', nil
+	}
+	return byte('0' + sum%11), nil
+}
+
+// IsValidCheckDigit reports whether the 9th character matches the computed check digit, regardless of region
+func IsValidCheckDigit(vin string) bool {
+	expected, err := CheckDigit(vin)
+	return err == nil && vin[8] == expected
+}
+
+// GetRegion classifies the VIN by the region of its first character
+func GetRegion(vin string) Region {
+	if vin == "" {
+		return RegionUnknown
+	}
+
+	switch first := vin[0]; {
+	case first >= 'A' && first <= 'H':
+		return RegionAfrica
+	case first >= 'J' && first <= 'R':
+		return RegionAsia
+	case first >= 'S' && first <= 'Z':
+		return RegionEurope
+	case first >= '1' && first <= '5':
+		return RegionNorthAmerica
+	case first == '6' || first == '7':
+		return RegionOceania
+	case first == '8' || first == '9' || first == '0':
+		return RegionSouthAmerica
+	default:
+		return RegionUnknown
+	}
+}
+
+// WMI returns the World Manufacturer Identifier, the first 3 characters
+func WMI(vin string) string {
+	if len(vin) < 3 {
+		return ""
+	}
+	return vin[:3]
+}
+
+// ModelYear decodes the 10th character model year, the latest matching year not after next year is used.
+// Returns 0 when the character is not a model year.
+func ModelYear(vin string, now time.Time) int {
+	if len(vin) != Length {
+		return 0
+	}
+
+	index := strings.IndexByte(modelYearChars, vin[9])
+	if index < 0 {
+		return 0
+	}
+
+	year := 1980 + index
+	for year+30 <= now.Year()+1 {
+		year += 30
+	}
+
+	return year
+}
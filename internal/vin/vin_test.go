@@ -0,0 +1,74 @@
+package vin
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type VinTestSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (s *VinTestSuite) SetupSuite() {
+	s.now = time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func TestVinTestSuite(t *testing.T) {
+	suite.Run(t, new(VinTestSuite))
+}
+
+func (s *VinTestSuite) TestNormalize() {
+	s.Equal("1HGCM82633A004352", Normalize(" 1hgcm 82633a004352\n"))
+}
+
+func (s *VinTestSuite) TestValidate() {
+	s.NoError(Validate("1HGCM82633A004352"))
+	// X check digit
+	s.NoError(Validate("1M8GDM9AXKP042788"))
+	// check digit isn't mandatory outside North America
+	s.NoError(Validate("ABCDEFG1234567811"))
+
+	s.ErrorIs(Validate("1HGCM82643A004352"), ErrInvalidCheckDigit)
+	s.ErrorIs(Validate("1HGCM82633A00435"), ErrInvalidLength)
+	// I, O and Q are not allowed
+	s.ErrorIs(Validate("1HGCM8263OA004352"), ErrInvalidCharacters)
+	s.ErrorIs(Validate("1hgcm82633a004352"), ErrInvalidCharacters)
+}
+
+func (s *VinTestSuite) TestCheckDigit() {
+	digit, err := CheckDigit("1HGCM82633A004352")
+	s.Require().NoError(err)
+	s.Equal(byte('3'), digit)
+
+	s.True(IsValidCheckDigit("1M8GDM9AXKP042788"))
+	s.False(IsValidCheckDigit("1HGCM82643A004352"))
+	s.False(IsValidCheckDigit("1HGCM82633A00435"))
+}
+
+func (s *VinTestSuite) TestGetRegion() {
+	s.Equal(RegionNorthAmerica, GetRegion("1HGCM82633A004352"))
+	s.Equal(RegionAsia, GetRegion("JHMCM82633C004352"))
+	s.Equal(RegionEurope, GetRegion("WVWZZZ1JZXW000001"))
+	s.Equal(RegionAfrica, GetRegion("ABCDEFG1234567811"))
+	s.Equal(RegionOceania, GetRegion("6T1BF28B7YU000001"))
+	s.Equal(RegionSouthAmerica, GetRegion("9BWZZZ377VT004251"))
+	s.Equal(RegionUnknown, GetRegion(""))
+}
+
+func (s *VinTestSuite) TestModelYear() {
+	s.Equal(2003, ModelYear("1HGCM82633A004352", s.now))
+	s.Equal(2019, ModelYear("1M8GDM9AXKP042788", s.now))
+	s.Equal(2025, ModelYear("5YJ3E1EA0SF000000", s.now))
+	// next year's models are already sold
+	s.Equal(2026, ModelYear("5YJ3E1EA0TF000000", s.now))
+	s.Equal(1997, ModelYear("5YJ3E1EA0VF000000", s.now))
+	// U is not a valid model year
+	s.Equal(0, ModelYear("5YJ3E1EA0UF000000", s.now))
+}
+
+func (s *VinTestSuite) TestManufacturer() {
+	s.Equal("Honda", Manufacturer("1HGCM82633A004352"))
+	s.Equal("", Manufacturer("ABCDEFG1234567811"))
+}
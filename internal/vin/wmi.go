@@ -0,0 +1,31 @@
+package vin
+
+// wmiManufacturers maps World Manufacturer Identifiers of common makes to the manufacturer name
+var wmiManufacturers = map[string]string{
+	"1FA": "Ford", "1FM": "Ford", "1FT": "Ford", "3FA": "Ford", "WF0": "Ford",
+	"1G1": "Chevrolet", "1GC": "Chevrolet", "1GN": "Chevrolet", "3GN": "Chevrolet",
+	"1GT": "GMC", "1GK": "GMC",
+	"1C4": "Jeep", "1J4": "Jeep", "1C6": "Ram", "2C3": "Chrysler",
+	"1HG": "Honda", "2HG": "Honda", "JHM": "Honda", "5FN": "Honda",
+	"1N4": "Nissan", "1N6": "Nissan", "JN1": "Nissan", "JN8": "Nissan",
+	"2T1": "Toyota", "4T1": "Toyota", "5TD": "Toyota", "5TF": "Toyota", "JTD": "Toyota", "JTE": "Toyota", "JTM": "Toyota",
+	"3VW": "Volkswagen", "WVW": "Volkswagen", "WVG": "Volkswagen", "1V2": "Volkswagen", "LFV": "Volkswagen", "LSV": "Volkswagen",
+	"5YJ": "Tesla", "7SA": "Tesla", "LRW": "Tesla", "XP7": "Tesla",
+	"KM8": "Hyundai", "KMH": "Hyundai", "5NP": "Hyundai",
+	"KNA": "Kia", "KND": "Kia", "5XY": "Kia",
+	"JM1": "Mazda", "JM3": "Mazda",
+	"JF1": "Subaru", "JF2": "Subaru", "4S4": "Subaru",
+	"WAU": "Audi", "WA1": "Audi",
+	"WBA": "BMW", "WBS": "BMW", "5UX": "BMW",
+	"WDD": "Mercedes-Benz", "WDC": "Mercedes-Benz", "W1K": "Mercedes-Benz", "W1N": "Mercedes-Benz",
+	"WP0": "Porsche", "WP1": "Porsche",
+	"YV1": "Volvo", "YV4": "Volvo",
+	"SAL": "Land Rover", "SAJ": "Jaguar",
+	"ZFA": "Fiat", "VF1": "Renault", "VF3": "Peugeot", "VSS": "SEAT", "TMB": "Skoda",
+	"7FC": "Rivian", "50E": "Lucid",
+}
+
+// Manufacturer returns the manufacturer name for the VIN's WMI, empty if unknown
+func Manufacturer(vin string) string {
+	return wmiManufacturers[WMI(vin)]
+}
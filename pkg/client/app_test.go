@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/DIMO-Network/go-transactions"
+	"github.com/DIMO-Network/go-zerodev"
+	"github.com/DIMO-Network/oracle-example/internal/app"
+	"github.com/DIMO-Network/oracle-example/internal/config"
+	dbmodels "github.com/DIMO-Network/oracle-example/internal/db/models"
+	"github.com/DIMO-Network/oracle-example/internal/health"
+	"github.com/DIMO-Network/oracle-example/internal/mocks"
+	"github.com/DIMO-Network/oracle-example/internal/models"
+	"github.com/DIMO-Network/oracle-example/internal/onboarding"
+	"github.com/DIMO-Network/oracle-example/internal/service"
+	"github.com/DIMO-Network/oracle-example/internal/test"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/null/v8"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const (
+	migrationsDirRelPath = "../../internal/db/migrations"
+	testDefinitionID     = "ford_f-150_2020"
+)
+
+// AppTestSuite runs the client against app.App in-process, with workers that complete jobs right away
+type AppTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	pdb       db.Store
+	container testcontainers.Container
+	river     *river.Client[pgx.Tx]
+	jwks      *httptest.Server
+	server    *httptest.Server
+	signer    *PrivateKeySigner
+	client    *Client
+}
+
+func TestAppTestSuite(t *testing.T) {
+	suite.Run(t, new(AppTestSuite))
+}
+
+func (s *AppTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	logger := zerolog.Nop()
+
+	var settings = test.GetTestDbSettings()
+	s.pdb, s.container, settings = test.StartContainerDatabase(s.ctx, s.T(), migrationsDirRelPath)
+	vs := service.NewVehicleService(&s.pdb, &logger, &settings)
+
+	workers := river.NewWorkers()
+	river.AddWorker[onboarding.VerifyArgs](workers, &verifyWorker{vs: vs})
+	river.AddWorker[onboarding.OnboardingArgs](workers, &mintWorker{vs: vs})
+
+	dbPool, err := pgxpool.New(s.ctx, settings.DB.BuildConnectionString(true))
+	s.Require().NoError(err)
+	s.river, err = river.NewClient(riverpgxv5.New(dbPool), &river.Config{
+		Queues:            onboarding.RiverQueues(&settings),
+		Workers:           workers,
+		FetchPollInterval: 50 * time.Millisecond,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.river.Start(s.ctx))
+
+	jwtKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.jwks = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(jwtKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(jwtKey.E)).Bytes()),
+		}}})
+	}))
+	settings.JwtKeySetURL = s.jwks.URL
+
+	identity := mocks.NewIdentityAPIMock(nil, []models.DeviceDefinition{{
+		DeviceDefinitionID: testDefinitionID,
+		Manufacturer:       models.Manufacturer{TokenID: 42, Name: "Ford"},
+		Model:              "F-150",
+		Year:               2020,
+	}})
+	tr := &transactions.Client{
+		RegistryAddress: common.HexToAddress("0x5eAA326fB2fc97fAcCe6A79A304876daD0F2e96c"),
+		ZerodevClient:   &zerodev.Client{ChainID: big.NewInt(80002)},
+	}
+	oracle := app.App(&settings, &logger, vs, identity, s.river, nil, onboarding.NewChains(80002, map[int64]*transactions.Client{80002: tr}), service.NewAccessService(&s.pdb, &logger), health.NewChecker(&settings), config.NewProvider("settings.yaml", settings, logger))
+	s.server = httptest.NewServer(adaptor.FiberApp(oracle))
+
+	key, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	s.signer = NewPrivateKeySigner(key)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"ethereum_address": s.signer.Address().Hex(),
+		"exp":              time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "test"
+	signedToken, err := token.SignedString(jwtKey)
+	s.Require().NoError(err)
+
+	s.client = New(s.server.URL, WithToken(signedToken), WithPollInterval(100*time.Millisecond))
+}
+
+func (s *AppTestSuite) TearDownTest() {
+	test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+func (s *AppTestSuite) TearDownSuite() {
+	s.server.Close()
+	s.jwks.Close()
+	if err := s.river.Stop(s.ctx); err != nil {
+		s.T().Error(err)
+	}
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Error(err)
+	}
+}
+
+func (s *AppTestSuite) TestOnboardVINs() {
+	ctx, cancel := context.WithTimeout(s.ctx, 30*time.Second)
+	defer cancel()
+
+	result, err := s.client.OnboardVINs(ctx, []VinWithCountryCode{
+		{Vin: "1HGCM82633A004352", CountryCode: "USA"},
+		{Vin: "abcdefg1234567811", CountryCode: "DEU"},
+		{Vin: "1HGCM82643A004352", CountryCode: "USA"},
+	}, s.signer)
+	s.Require().NoError(err)
+
+	s.ElementsMatch([]string{"1HGCM82633A004352", "ABCDEFG1234567811"}, result.Succeeded())
+	s.Require().Len(result.Errors, 1)
+	s.Equal("1HGCM82643A004352", result.Errors[0].Vin)
+	s.Equal(CodeVinInvalid, result.Errors[0].Code)
+
+	statuses, err := s.client.GetMintStatus(ctx, []string{"1HGCM82633A004352"})
+	s.Require().NoError(err)
+	s.Equal(StatusSuccess, statuses.Statuses[0].Status)
+}
+
+func (s *AppTestSuite) TestErrors() {
+	_, err := s.client.GetVerificationStatus(s.ctx, []string{"A345"})
+	s.Require().True(HasCode(err, CodeBatchRejected), err)
+	s.NotEmpty(err.(*Error).CorrelationID)
+	s.Equal(CodeVinInvalid, err.(*Error).Errors[0].Code)
+
+	_, err = New(s.server.URL).GetVerificationStatus(s.ctx, []string{"1HGCM82633A004352"})
+	s.True(HasCode(err, CodeUnauthorized), err)
+}
+
+// verifyWorker decodes every VIN to the test definition
+type verifyWorker struct {
+	river.WorkerDefaults[onboarding.VerifyArgs]
+	vs *service.Vehicle
+}
+
+func (w *verifyWorker) Work(ctx context.Context, job *river.Job[onboarding.VerifyArgs]) error {
+	return updateVin(ctx, w.vs, job.Args.VIN, func(vin *dbmodels.Vin) {
+		vin.OnboardingStatus = onboarding.OnboardingStatusVendorValidationSuccess
+		vin.DeviceDefinitionID = null.StringFrom(testDefinitionID)
+	})
+}
+
+// mintWorker mints without going on-chain
+type mintWorker struct {
+	river.WorkerDefaults[onboarding.OnboardingArgs]
+	vs *service.Vehicle
+}
+
+func (w *mintWorker) Work(ctx context.Context, job *river.Job[onboarding.OnboardingArgs]) error {
+	return updateVin(ctx, w.vs, job.Args.VIN, func(vin *dbmodels.Vin) {
+		vin.OnboardingStatus = onboarding.OnboardingStatusMintSuccess
+		vin.VehicleTokenID = null.Int64From(job.ID)
+		vin.SyntheticTokenID = null.Int64From(job.ID)
+	})
+}
+
+func updateVin(ctx context.Context, vs *service.Vehicle, vin string, update func(*dbmodels.Vin)) error {
+	dbVins, err := vs.GetVehiclesByVins(ctx, []string{vin})
+	if err != nil {
+		return err
+	}
+
+	update(dbVins[0])
+	return vs.InsertOrUpdateVin(ctx, dbVins[0])
+}
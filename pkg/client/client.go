@@ -0,0 +1,242 @@
+// Package client is the Go SDK of the oracle API. It wraps every /v1 endpoint with typed requests and responses,
+// and drives the onboarding, disconnection and deletion flows (get payloads, sign, submit, poll) with OnboardVINs,
+// DisconnectVINs and DeleteVINs. Types mirror the OpenAPI document served at /docs.
+//
+// The oracle has no server-sent events or other push channel, so the flow helpers poll the status endpoints. SSE is out
+// of scope for this package.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+	defaultPollInterval = 5 * time.Second
+	defaultTimeout      = 30 * time.Second
+)
+
+// TokenSource returns the DIMO JWT sent as bearer token, it's called for every request so tokens can be refreshed
+type TokenSource func(ctx context.Context) (string, error)
+
+type Client struct {
+	baseURL      string
+	httpClient   *http.Client
+	tokenSource  TokenSource
+	maxRetries   int
+	retryBackoff time.Duration
+	pollInterval time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default client with a 30s timeout
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken sets a static bearer token
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.tokenSource = func(context.Context) (string, error) {
+			return token, nil
+		}
+	}
+}
+
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = tokenSource
+	}
+}
+
+// WithRetries sets how many times GET requests are retried on network errors, 429, 502, 503 and 504, with exponential
+// backoff starting at backoff. Submits are never retried, as they aren't idempotent.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+	}
+}
+
+// WithPollInterval sets how often the flow helpers poll statuses, default 5s
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.pollInterval = interval
+	}
+}
+
+// New creates a client for the oracle at baseURL, e.g. https://oracle.example.com
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		httpClient:   &http.Client{Timeout: defaultTimeout},
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+		pollInterval: defaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// BatchOption changes how batch endpoints handle VINs they can't process
+type BatchOption func(url.Values)
+
+// Atomic fails the whole request, before anything is submitted, if any VIN can't be processed.
+// Otherwise, the valid VINs are processed and the rest are reported in the response Errors.
+func Atomic() BatchOption {
+	return func(query url.Values) {
+		query.Set("atomic", "true")
+	}
+}
+
+func batchQuery(opts []BatchOption) url.Values {
+	query := url.Values{}
+	for _, opt := range opts {
+		opt(query)
+	}
+	return query
+}
+
+func vinsQuery(vins []string, opts []BatchOption) url.Values {
+	query := batchQuery(opts)
+	query.Set("vins", strings.Join(vins, ","))
+	return query
+}
+
+func get[T any](ctx context.Context, c *Client, path string, query url.Values) (*T, error) {
+	result := new(T)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func post[T any](ctx context.Context, c *Client, path string, query url.Values, body any) (*T, error) {
+	result := new(T)
+	if err := c.do(ctx, http.MethodPost, path, query, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	retries := 0
+	if method == http.MethodGet {
+		retries = c.maxRetries
+	}
+
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, path, query, payload, result)
+		if err == nil || attempt >= retries || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, payload []byte, result any) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.tokenSource != nil {
+		token, err := c.tokenSource(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return &networkError{err: err}
+	}
+	defer res.Body.Close() //nolint:errcheck
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return &networkError{err: err}
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return newError(res, resBody)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resBody, result); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+
+	return nil
+}
+
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string {
+	return "request failed: " + e.err.Error()
+}
+
+func (e *networkError) Unwrap() error {
+	return e.err
+}
+
+func retryable(err error) bool {
+	switch e := err.(type) {
+	case *networkError:
+		return true
+	case *Error:
+		switch e.Status {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
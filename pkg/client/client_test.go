@@ -0,0 +1,255 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/DIMO-Network/oracle-example/internal/apierrors"
+	"github.com/DIMO-Network/oracle-example/internal/controllers"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type ClientTestSuite struct {
+	suite.Suite
+}
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+func (s *ClientTestSuite) server(handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	s.T().Cleanup(srv.Close)
+
+	return New(srv.URL, WithToken("token"), WithRetries(2, time.Millisecond), WithPollInterval(time.Millisecond))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *ClientTestSuite) TestRequest() {
+	c := s.server(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("Bearer token", r.Header.Get("Authorization"))
+		s.Equal("/v1/vehicle/mint/status", r.URL.Path)
+		s.Equal("ABCDEFG1234567811,ABCDEFG1234567812", r.URL.Query().Get("vins"))
+		s.Equal("true", r.URL.Query().Get("atomic"))
+
+		writeJSON(w, http.StatusOK, StatusForVinsResponse{Statuses: []VinStatus{{Vin: "ABCDEFG1234567811", Status: StatusSuccess}}})
+	})
+
+	res, err := c.GetMintStatus(context.Background(), []string{"ABCDEFG1234567811", "ABCDEFG1234567812"}, Atomic())
+	s.Require().NoError(err)
+	s.Equal([]VinStatus{{Vin: "ABCDEFG1234567811", Status: StatusSuccess}}, res.Statuses)
+}
+
+func (s *ClientTestSuite) TestProblem() {
+	c := s.server(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Correlation-ID", "abc-123")
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"title":  "None of the VINs could be processed",
+			"status": http.StatusBadRequest,
+			"code":   CodeBatchRejected,
+			"errors": []VinError{{Vin: "A345", Code: CodeVinInvalid, Message: "invalid VIN length"}},
+		})
+	})
+
+	_, err := c.GetVerificationStatus(context.Background(), []string{"A345"})
+	s.True(HasCode(err, CodeBatchRejected))
+
+	apiErr := err.(*Error)
+	s.Equal(http.StatusBadRequest, apiErr.Status)
+	s.Equal("abc-123", apiErr.CorrelationID)
+	s.Equal([]VinError{{Vin: "A345", Code: CodeVinInvalid, Message: "invalid VIN length"}}, apiErr.Errors)
+}
+
+func (s *ClientTestSuite) TestRetries() {
+	var calls atomic.Int32
+	c := s.server(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, http.StatusOK, StatusForVinsResponse{})
+	})
+
+	_, err := c.GetPauseStatus(context.Background(), []string{"ABCDEFG1234567811"})
+	s.Require().NoError(err)
+	s.Equal(int32(3), calls.Load())
+
+	// submits aren't retried
+	calls.Store(0)
+	_, err = c.Pause(context.Background(), []string{"ABCDEFG1234567811"})
+	s.True(HasCode(err, CodeInternal))
+	s.Equal(int32(1), calls.Load())
+}
+
+func (s *ClientTestSuite) TestOnboardVINs() {
+	key, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	signer := NewPrivateKeySigner(key)
+
+	var mintPolls atomic.Int32
+	c := s.server(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/vehicle/verify":
+			writeJSON(w, http.StatusOK, StatusForVinsResponse{
+				Statuses: []VinStatus{{Vin: "ABCDEFG1234567811", Status: StatusPending}, {Vin: "ABCDEFG1234567812", Status: StatusPending}},
+				Errors:   []VinError{{Vin: "A345", Code: CodeVinInvalid}},
+			})
+		case "GET /v1/vehicle/verify":
+			writeJSON(w, http.StatusOK, StatusForVinsResponse{
+				Statuses: []VinStatus{{Vin: "ABCDEFG1234567811", Status: StatusSuccess}, {Vin: "ABCDEFG1234567812", Status: StatusFailure}},
+			})
+		case "GET /v1/vehicle/mint":
+			s.Equal("ABCDEFG1234567811", r.URL.Query().Get("vins"))
+			writeJSON(w, http.StatusOK, MintDataForVins{VinMintingData: []VinTransactionData{{Vin: "ABCDEFG1234567811", TypedData: testTypedData()}}})
+		case "POST /v1/vehicle/mint":
+			var body MintDataForVins
+			s.Require().NoError(json.NewDecoder(r.Body).Decode(&body))
+			s.Require().Len(body.VinMintingData, 1)
+
+			hash, _, err := apitypes.TypedDataAndHash(*body.VinMintingData[0].TypedData)
+			s.Require().NoError(err)
+			signature := append([]byte{}, body.VinMintingData[0].Signature...)
+			signature[crypto.RecoveryIDOffset] -= 27
+			pub, err := crypto.SigToPub(hash, signature)
+			s.Require().NoError(err)
+			s.Equal(signer.Address(), crypto.PubkeyToAddress(*pub))
+
+			writeJSON(w, http.StatusOK, StatusForVinsResponse{Statuses: []VinStatus{{Vin: "ABCDEFG1234567811", Status: StatusPending}}})
+		case "GET /v1/vehicle/mint/status":
+			status := StatusPending
+			if mintPolls.Add(1) > 1 {
+				status = StatusSuccess
+			}
+			writeJSON(w, http.StatusOK, StatusForVinsResponse{Statuses: []VinStatus{{Vin: "ABCDEFG1234567811", Status: status}}})
+		default:
+			s.Failf("unexpected request", "%s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	result, err := c.OnboardVINs(context.Background(), []VinWithCountryCode{
+		{Vin: "ABCDEFG1234567811", CountryCode: "USA"},
+		{Vin: "ABCDEFG1234567812", CountryCode: "USA"},
+		{Vin: "A345", CountryCode: "USA"},
+	}, signer)
+	s.Require().NoError(err)
+
+	s.Equal([]string{"ABCDEFG1234567811"}, result.Succeeded())
+	s.ElementsMatch([]VinStatus{{Vin: "ABCDEFG1234567811", Status: StatusSuccess}, {Vin: "ABCDEFG1234567812", Status: StatusFailure}}, result.Statuses)
+	s.Equal([]VinError{{Vin: "A345", Code: CodeVinInvalid}}, result.Errors)
+	s.Equal(int32(2), mintPolls.Load())
+}
+
+func (s *ClientTestSuite) TestOnboardVINsUnknownStatus() {
+	var polls atomic.Int32
+	c := s.server(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/vehicle/verify":
+			writeJSON(w, http.StatusOK, StatusForVinsResponse{
+				Statuses: []VinStatus{{Vin: "ABCDEFG1234567811", Status: StatusPending}, {Vin: "ABCDEFG1234567812", Status: StatusPending}},
+			})
+		case "GET /v1/vehicle/verify":
+			polls.Add(1)
+			// the second VIN was reset to an earlier step meanwhile
+			writeJSON(w, http.StatusOK, StatusForVinsResponse{
+				Statuses: []VinStatus{{Vin: "ABCDEFG1234567811", Status: StatusPending}, {Vin: "ABCDEFG1234567812", Status: StatusUnknown}},
+			})
+		default:
+			s.Failf("unexpected request", "%s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	result, err := c.OnboardVINs(context.Background(), []VinWithCountryCode{
+		{Vin: "ABCDEFG1234567811", CountryCode: "USA"},
+		{Vin: "ABCDEFG1234567812", CountryCode: "USA"},
+	}, NewPrivateKeySigner(nil))
+
+	s.Nil(result)
+	s.ErrorIs(err, ErrUnknownStatus)
+	s.ErrorContains(err, "ABCDEFG1234567812")
+	s.NotContains(err.Error(), "ABCDEFG1234567811")
+	s.Equal(int32(1), polls.Load(), "unknown statuses aren't polled again")
+}
+
+func (s *ClientTestSuite) TestCodesMatchCatalog() {
+	for _, code := range []Code{
+		CodeRequestInvalid, CodeUnauthorized, CodeAccessDenied, CodeNotFound, CodeVinInvalid, CodeVinDuplicate,
+		CodeVinNotFound, CodeVinNotOwned, CodeDataInvalid, CodeStateConflict, CodeTokenMismatch, CodeChainUnsupported,
+		CodeBatchRejected, CodeUpstreamIdentityFailed, CodeUpstreamChainFailed, CodeDatabaseFailed,
+	} {
+		// unknown codes get the internal error title
+		s.NotEqualf(apierrors.CodeInternal.Title(), apierrors.Code(code).Title(), "unknown code %s", code)
+	}
+	s.Equal(string(apierrors.CodeInternal), string(CodeInternal))
+}
+
+func (s *ClientTestSuite) TestTypesMatchAPI() {
+	pairs := [][2]any{
+		{StatusForVinsResponse{}, controllers.StatusForVinsResponse{}},
+		{SubmitVinVerificationParams{}, controllers.SubmitVinVerificationParams{}},
+		{VehiclesResponse{}, controllers.VehiclesResponse{}},
+		{VehicleListItem{}, controllers.VehicleListItem{}},
+		{VehicleResponse{}, controllers.VehicleResponse{}},
+		{VehicleRegisterPayload{}, controllers.VehicleRegisterPayload{}},
+		{SacdInput{}, controllers.SacdInput{}},
+		{VinTransactionData{}, controllers.VinTransactionData{}},
+		{MintDataForVins{}, controllers.MintDataForVins{}},
+		{VinUserOperationData{}, controllers.VinUserOperationData{}},
+		{DisconnectDataForVins{}, controllers.DisconnectDataForVins{}},
+		{DeleteDataForVins{}, controllers.DeleteDataForVins{}},
+		{VinSacdData{}, controllers.VinSacdData{}},
+		{SacdDataForVins{}, controllers.SacdDataForVins{}},
+		{VinSacdStatus{}, controllers.VinSacdStatus{}},
+		{SacdStatusForVinsResponse{}, controllers.SacdStatusForVinsResponse{}},
+		{DriftReportResponse{}, controllers.DriftReportResponse{}},
+		{VinDrift{}, controllers.VinDrift{}},
+		{VinError{}, controllers.VinError{}},
+	}
+
+	for _, pair := range pairs {
+		s.Equalf(jsonFields(reflect.TypeOf(pair[1])), jsonFields(reflect.TypeOf(pair[0])), "fields of %T", pair[0])
+	}
+}
+
+func jsonFields(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" {
+			names = append(names, jsonFields(field.Type)...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func testTypedData() *apitypes.TypedData {
+	return &apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{{Name: "name", Type: "string"}},
+			"Mint":         []apitypes.Type{{Name: "owner", Type: "address"}},
+		},
+		PrimaryType: "Mint",
+		Domain:      apitypes.TypedDataDomain{Name: "DIMO"},
+		Message:     apitypes.TypedDataMessage{"owner": common.HexToAddress("0x1").Hex()},
+	}
+}
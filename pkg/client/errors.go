@@ -0,0 +1,94 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Code is the stable, machine-readable code of an API error or of a VIN a batch request couldn't process
+type Code string
+
+const (
+	CodeRequestInvalid Code = "REQUEST_INVALID"
+	CodeUnauthorized   Code = "UNAUTHORIZED"
+	CodeAccessDenied   Code = "ACCESS_DENIED"
+	CodeNotFound       Code = "NOT_FOUND"
+
+	CodeVinInvalid       Code = "VIN_INVALID"
+	CodeVinDuplicate     Code = "VIN_DUPLICATE"
+	CodeVinNotFound      Code = "VIN_NOT_FOUND"
+	CodeVinNotOwned      Code = "VIN_NOT_OWNED"
+	CodeDataInvalid      Code = "DATA_INVALID"
+	CodeStateConflict    Code = "STATE_CONFLICT"
+	CodeTokenMismatch    Code = "TOKEN_MISMATCH"
+	CodeChainUnsupported Code = "CHAIN_UNSUPPORTED"
+	CodeBatchRejected    Code = "BATCH_REJECTED"
+
+	CodeUpstreamIdentityFailed Code = "UPSTREAM_IDENTITY_FAILED"
+	CodeUpstreamChainFailed    Code = "UPSTREAM_CHAIN_FAILED"
+	CodeDatabaseFailed         Code = "DATABASE_FAILED"
+	CodeInternal               Code = "INTERNAL_ERROR"
+)
+
+// Error is an error response of the API, an RFC 7807 problem
+type Error struct {
+	Type          string     `json:"type"`
+	Title         string     `json:"title"`
+	Status        int        `json:"status"`
+	Detail        string     `json:"detail,omitempty"`
+	Instance      string     `json:"instance,omitempty"`
+	Code          Code       `json:"code"`
+	CorrelationID string     `json:"correlationId,omitempty"`
+	Errors        []VinError `json:"errors,omitempty"`
+}
+
+func newError(res *http.Response, body []byte) *Error {
+	apiErr := &Error{}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Code == "" {
+		// not a problem document, e.g. from the JWT middleware or a proxy in front of the oracle
+		apiErr = &Error{
+			Title:  http.StatusText(res.StatusCode),
+			Detail: string(body),
+			Code:   codeFromStatus(res.StatusCode),
+		}
+	}
+	apiErr.Status = res.StatusCode
+	if apiErr.CorrelationID == "" {
+		apiErr.CorrelationID = res.Header.Get("X-Correlation-ID")
+	}
+
+	return apiErr
+}
+
+func codeFromStatus(status int) Code {
+	switch {
+	case status == http.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == http.StatusForbidden:
+		return CodeAccessDenied
+	case status == http.StatusNotFound:
+		return CodeNotFound
+	case status < http.StatusInternalServerError:
+		return CodeRequestInvalid
+	default:
+		return CodeInternal
+	}
+}
+
+func (e *Error) Error() string {
+	msg := string(e.Code) + ": " + e.Title
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.CorrelationID != "" {
+		msg += " (correlation ID " + e.CorrelationID + ")"
+	}
+	return msg
+}
+
+// HasCode reports whether err is an API error with the code
+func HasCode(err error, code Code) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
@@ -0,0 +1,255 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FlowResult is the outcome of a flow helper. Each VIN is either in Statuses, with the status of the last step it
+// reached, or in Errors, when the oracle rejected it at one of the steps.
+type FlowResult struct {
+	Statuses []VinStatus
+	Errors   []VinError
+}
+
+// Succeeded returns the VINs that went through the whole flow
+func (r *FlowResult) Succeeded() []string {
+	vins := make([]string, 0, len(r.Statuses))
+	for _, status := range r.Statuses {
+		if status.Status == StatusSuccess {
+			vins = append(vins, status.Vin)
+		}
+	}
+	return vins
+}
+
+// ErrUnknownStatus is returned by the flow helpers when the oracle reports a VIN in a status that doesn't belong to the
+// step being waited for, e.g. a VIN reset by an operator or moved on by another client
+var ErrUnknownStatus = errors.New("VIN status is unknown to the step")
+
+type statusFunc func(ctx context.Context, vins []string, opts ...BatchOption) (*StatusForVinsResponse, error)
+
+// OnboardVINs verifies the VINs, waits for the verification, signs the minting typed data, submits it and waits for the
+// vehicles and synthetic devices to be minted. The oracle only reports progress by status endpoints, which are polled.
+// VINs failing a step are left out of the next ones, the returned error is only for failures of the whole flow.
+// Polling goes on as long as VINs are pending, bound it with a ctx deadline.
+func (c *Client) OnboardVINs(ctx context.Context, vins []VinWithCountryCode, signer Signer) (*FlowResult, error) {
+	result := &FlowResult{}
+
+	submitted, err := c.SubmitVerification(ctx, vins)
+	if err = result.collect(err, submitted); err != nil {
+		return nil, fmt.Errorf("failed to submit verification: %w", err)
+	}
+
+	verified, err := c.waitFor(ctx, result, vinsOf(submitted), c.GetVerificationStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for verification: %w", err)
+	}
+	if len(verified) == 0 {
+		return result, nil
+	}
+
+	mintData, err := c.GetMintData(ctx, verified)
+	if err != nil {
+		result.dropStatuses(verified)
+		if err = result.collect(err, nil); err != nil {
+			return nil, fmt.Errorf("failed to get mint data: %w", err)
+		}
+		return result, nil
+	}
+	result.Errors = append(result.Errors, mintData.Errors...)
+	result.dropStatuses(verified)
+
+	signed := MintDataForVins{VinMintingData: make([]VinTransactionData, 0, len(mintData.VinMintingData))}
+	for _, data := range mintData.VinMintingData {
+		// no typed data when only the vendor connection is retried
+		if data.TypedData != nil {
+			if data.Signature, err = signer.SignTypedData(ctx, data.TypedData); err != nil {
+				return nil, fmt.Errorf("failed to sign mint typed data of %s: %w", data.Vin, err)
+			}
+		}
+		signed.VinMintingData = append(signed.VinMintingData, data)
+	}
+
+	minting, err := c.SubmitMintData(ctx, signed)
+	if err = result.collect(err, minting); err != nil {
+		return nil, fmt.Errorf("failed to submit mint data: %w", err)
+	}
+
+	if _, err = c.waitFor(ctx, result, vinsOf(minting), c.GetMintStatus); err != nil {
+		return nil, fmt.Errorf("failed to wait for minting: %w", err)
+	}
+
+	return result, nil
+}
+
+// DisconnectVINs signs and submits the burning of the VINs' synthetic devices and waits for it
+func (c *Client) DisconnectVINs(ctx context.Context, vins []string, signer Signer) (*FlowResult, error) {
+	result := &FlowResult{}
+
+	data, err := c.GetDisconnectData(ctx, vins)
+	if err != nil {
+		if err = result.collect(err, nil); err != nil {
+			return nil, fmt.Errorf("failed to get disconnect data: %w", err)
+		}
+		return result, nil
+	}
+	result.Errors = append(result.Errors, data.Errors...)
+
+	if err = signUserOperations(ctx, signer, data.VinDisconnectData); err != nil {
+		return nil, err
+	}
+
+	submitted, err := c.SubmitDisconnectData(ctx, DisconnectDataForVins{VinDisconnectData: data.VinDisconnectData})
+	if err = result.collect(err, submitted); err != nil {
+		return nil, fmt.Errorf("failed to submit disconnect data: %w", err)
+	}
+
+	if _, err = c.waitFor(ctx, result, vinsOf(submitted), c.GetDisconnectStatus); err != nil {
+		return nil, fmt.Errorf("failed to wait for disconnection: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteVINs signs and submits the burning of the disconnected VINs' vehicles and waits for it
+func (c *Client) DeleteVINs(ctx context.Context, vins []string, signer Signer) (*FlowResult, error) {
+	result := &FlowResult{}
+
+	data, err := c.GetDeleteData(ctx, vins)
+	if err != nil {
+		if err = result.collect(err, nil); err != nil {
+			return nil, fmt.Errorf("failed to get delete data: %w", err)
+		}
+		return result, nil
+	}
+	result.Errors = append(result.Errors, data.Errors...)
+
+	if err = signUserOperations(ctx, signer, data.VinDeleteData); err != nil {
+		return nil, err
+	}
+
+	submitted, err := c.SubmitDeleteData(ctx, DeleteDataForVins{VinDeleteData: data.VinDeleteData})
+	if err = result.collect(err, submitted); err != nil {
+		return nil, fmt.Errorf("failed to submit delete data: %w", err)
+	}
+
+	if _, err = c.waitFor(ctx, result, vinsOf(submitted), c.GetDeleteStatus); err != nil {
+		return nil, fmt.Errorf("failed to wait for deletion: %w", err)
+	}
+
+	return result, nil
+}
+
+func signUserOperations(ctx context.Context, signer Signer, data []VinUserOperationData) error {
+	for i := range data {
+		signature, err := signer.SignHash(ctx, data[i].Hash)
+		if err != nil {
+			return fmt.Errorf("failed to sign user operation of %s: %w", data[i].Vin, err)
+		}
+		data[i].Signature = signature
+	}
+	return nil
+}
+
+// waitFor polls until none of the VINs is pending, records their final statuses and returns the successful ones.
+// Unknown statuses aren't final, polling them would never end, so they fail the wait.
+func (c *Client) waitFor(ctx context.Context, result *FlowResult, vins []string, getStatus statusFunc) ([]string, error) {
+	if len(vins) == 0 {
+		return nil, nil
+	}
+
+	for {
+		res, err := getStatus(ctx, vins)
+		if err != nil {
+			return nil, err
+		}
+
+		if unknown := vinsWithStatus(res.Statuses, StatusUnknown); len(unknown) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownStatus, strings.Join(unknown, ", "))
+		}
+
+		if !anyPending(res.Statuses) {
+			result.dropStatuses(vins)
+			result.Statuses = append(result.Statuses, res.Statuses...)
+			result.Errors = append(result.Errors, res.Errors...)
+
+			succeeded := make([]string, 0, len(res.Statuses))
+			for _, status := range res.Statuses {
+				if status.Status == StatusSuccess {
+					succeeded = append(succeeded, status.Vin)
+				}
+			}
+			return succeeded, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+// collect records the statuses and per-VIN errors of a step. A rejected batch isn't an error of the flow, its VINs are
+// only recorded, like those a successful request reports in Errors.
+func (r *FlowResult) collect(err error, res *StatusForVinsResponse) error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.Code == CodeBatchRejected {
+		r.Errors = append(r.Errors, apiErr.Errors...)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	r.dropStatuses(vinsOf(res))
+	r.Statuses = append(r.Statuses, res.Statuses...)
+	r.Errors = append(r.Errors, res.Errors...)
+	return nil
+}
+
+// dropStatuses removes statuses of earlier steps of the VINs
+func (r *FlowResult) dropStatuses(vins []string) {
+	drop := make(map[string]bool, len(vins))
+	for _, vin := range vins {
+		drop[vin] = true
+	}
+
+	kept := r.Statuses[:0]
+	for _, status := range r.Statuses {
+		if !drop[status.Vin] {
+			kept = append(kept, status)
+		}
+	}
+	r.Statuses = kept
+}
+
+func vinsOf(res *StatusForVinsResponse) []string {
+	if res == nil {
+		return nil
+	}
+
+	vins := make([]string, 0, len(res.Statuses))
+	for _, status := range res.Statuses {
+		vins = append(vins, status.Vin)
+	}
+	return vins
+}
+
+func anyPending(statuses []VinStatus) bool {
+	return len(vinsWithStatus(statuses, StatusPending)) > 0
+}
+
+func vinsWithStatus(statuses []VinStatus, status string) []string {
+	var vins []string
+	for _, s := range statuses {
+		if s.Status == status {
+			vins = append(vins, s.Vin)
+		}
+	}
+	return vins
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer signs the payloads returned by the oracle on behalf of the vehicle owner's wallet, e.g. with a passkey,
+// a KMS or a private key. The oracle submits the signatures as they are.
+type Signer interface {
+	// SignTypedData signs the EIP-712 typed data of minting
+	SignTypedData(ctx context.Context, data *apitypes.TypedData) ([]byte, error)
+	// SignHash signs the hash of a user operation of disconnecting, deleting or SACD
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// PrivateKeySigner signs with an ECDSA key, for wallets validated by it such as EOAs or kernel accounts with the ECDSA
+// validator. User operation hashes are signed as EIP-191 personal messages.
+type PrivateKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key}
+}
+
+func (s *PrivateKeySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *PrivateKeySigner) SignTypedData(_ context.Context, data *apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(*data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+
+	return s.sign(hash)
+}
+
+func (s *PrivateKeySigner) SignHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	return s.sign(accounts.TextHash(hash.Bytes()))
+}
+
+func (s *PrivateKeySigner) sign(hash []byte) ([]byte, error) {
+	signature, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, err
+	}
+	// Ethereum signatures use 27/28 as recovery ID
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, nil
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"time"
+)
+
+// Statuses of VinStatus, pause statuses are Paused, Active, Pending, Failure and Unknown
+const (
+	StatusSuccess = "Success"
+	StatusFailure = "Failure"
+	StatusPending = "Pending"
+	StatusUnknown = "Unknown"
+)
+
+// VinError is a VIN a batch request couldn't process
+type VinError struct {
+	Vin     string `json:"vin"`
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+}
+
+type VinStatus struct {
+	Vin     string `json:"vin"`
+	Status  string `json:"status"`
+	Details string `json:"details"`
+	Sacd    string `json:"sacd,omitempty"`
+}
+
+type StatusForVinsResponse struct {
+	Statuses []VinStatus `json:"statuses"`
+	Errors   []VinError  `json:"errors,omitempty"`
+}
+
+type VinWithCountryCode struct {
+	Vin string `json:"vin"`
+	// CountryCode is the ISO 3166-1 alpha-3 country code, e.g. USA
+	CountryCode string `json:"countryCode"`
+	// ChainID of the chain profile to mint the vehicle on, the oracle's default chain when empty
+	ChainID int64 `json:"chainId,omitempty"`
+}
+
+type SubmitVinVerificationParams struct {
+	Vins []VinWithCountryCode `json:"vins"`
+}
+
+type VinsParams struct {
+	Vins []string `json:"vins"`
+}
+
+type Definition struct {
+	ID    string `json:"id"`
+	Make  string `json:"make"`
+	Model string `json:"model"`
+	Year  int    `json:"year"`
+}
+
+type SyntheticDevice struct {
+	ID       string `json:"id"`
+	TokenID  int64  `json:"tokenId"`
+	MintedAt string `json:"mintedAt"`
+}
+
+type Vehicle struct {
+	VIN                 string          `json:"vin"`
+	ID                  string          `json:"id"`
+	TokenID             int64           `json:"tokenId"`
+	MintedAt            string          `json:"mintedAt"`
+	Owner               string          `json:"owner"`
+	Definition          Definition      `json:"definition"`
+	SyntheticDevice     SyntheticDevice `json:"syntheticDevice"`
+	ConnectionStatus    string          `json:"connectionStatus"`
+	DisconnectionStatus string          `json:"disconnectionStatus"`
+}
+
+type VehicleListItem struct {
+	Vehicle
+	OnboardingStatus string `json:"onboardingStatus"`
+	Phase            string `json:"phase"`
+	TelemetryPaused  bool   `json:"telemetryPaused"`
+	ErrorCode        string `json:"errorCode,omitempty"`
+}
+
+type VehiclesResponse struct {
+	Vehicles   []VehicleListItem `json:"vehicles"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+}
+
+type VehicleResponse struct {
+	Vehicle Vehicle `json:"vehicle"`
+}
+
+type VehicleRegisterPayload struct {
+	Vin     string `json:"vin"`
+	TokenID string `json:"token_id"`
+}
+
+// VehiclesQuery filters and sorts the vehicle listing, zero values are left out
+type VehiclesQuery struct {
+	Cursor           string
+	Limit            int
+	Phase            string
+	ConnectionStatus string
+	Make             string
+	Model            string
+	Year             int
+	ErrorCode        string
+	Sort             string
+	Order            string
+}
+
+// SacdInput is a SACD grant, the API uses the Go field names as JSON keys
+type SacdInput struct {
+	Grantee     common.Address
+	Permissions int64
+	Expiration  int64
+	Source      string
+}
+
+type VinTransactionData struct {
+	Vin       string              `json:"vin"`
+	TypedData *apitypes.TypedData `json:"typedData,omitempty"`
+	Signature hexutil.Bytes       `json:"signature,omitempty"`
+}
+
+type MintDataForVins struct {
+	VinMintingData []VinTransactionData `json:"vinMintingData"`
+	Sacd           SacdInput            `json:"sacd,omitempty"`
+	Errors         []VinError           `json:"errors,omitempty"`
+}
+
+// VinUserOperationData carries an ERC-4337 user operation, which is kept as returned by the oracle, and its hash to sign
+type VinUserOperationData struct {
+	Vin           string          `json:"vin"`
+	UserOperation json.RawMessage `json:"userOperation"`
+	Hash          common.Hash     `json:"hash"`
+	Signature     hexutil.Bytes   `json:"signature,omitempty"`
+}
+
+type DisconnectDataForVins struct {
+	VinDisconnectData []VinUserOperationData `json:"vinDisconnectData"`
+	Errors            []VinError             `json:"errors,omitempty"`
+}
+
+type DeleteDataForVins struct {
+	VinDeleteData []VinUserOperationData `json:"vinDeleteData"`
+	Errors        []VinError             `json:"errors,omitempty"`
+}
+
+type VinSacdData struct {
+	Vin           string          `json:"vin"`
+	Sacd          SacdInput       `json:"sacd"`
+	UserOperation json.RawMessage `json:"userOperation"`
+	Hash          common.Hash     `json:"hash"`
+	Signature     hexutil.Bytes   `json:"signature,omitempty"`
+}
+
+type SacdDataForVins struct {
+	VinSacdData []VinSacdData `json:"vinSacdData"`
+	Errors      []VinError    `json:"errors,omitempty"`
+}
+
+// SacdQuery is the grant to build SACD user operations for. Without a grantee, the grant requested at mint time is used.
+// Zero permissions revoke.
+type SacdQuery struct {
+	Grantee     *common.Address
+	Permissions int64
+	Expiration  int64
+	Source      string
+}
+
+type VinSacdStatus struct {
+	Vin         string    `json:"vin"`
+	Grantee     string    `json:"grantee"`
+	Permissions string    `json:"permissions"`
+	Expiration  int64     `json:"expiration"`
+	Source      string    `json:"source,omitempty"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type SacdStatusForVinsResponse struct {
+	Sacds  []VinSacdStatus `json:"sacds"`
+	Errors []VinError      `json:"errors,omitempty"`
+}
+
+type VinDrift struct {
+	Vin        string     `json:"vin"`
+	Kind       string     `json:"kind"`
+	DBValue    string     `json:"dbValue"`
+	ChainValue string     `json:"chainValue"`
+	DetectedAt time.Time  `json:"detectedAt"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+type DriftReportResponse struct {
+	Drifts []VinDrift `json:"drifts"`
+	// Counts holds the number of unresolved drifts by kind, over all pages
+	Counts     map[string]int `json:"counts"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+// DriftsQuery selects a page of the drift report, only ADMIN_WALLETS can read it
+type DriftsQuery struct {
+	// Resolved includes already resolved drifts
+	Resolved bool
+	Cursor   string
+	Limit    int
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// CheckAccess returns an ACCESS_DENIED error if the token's wallet isn't allowed to use the oracle
+func (c *Client) CheckAccess(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/v1/access", nil, nil, nil)
+}
+
+// GetVehicles returns a page of the wallet's onboarded vehicles, pass NextCursor as Cursor for the next one
+func (c *Client) GetVehicles(ctx context.Context, q VehiclesQuery) (*VehiclesResponse, error) {
+	query := url.Values{}
+	setString := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	setString("cursor", q.Cursor)
+	setString("phase", q.Phase)
+	setString("connectionStatus", q.ConnectionStatus)
+	setString("make", q.Make)
+	setString("model", q.Model)
+	setString("errorCode", q.ErrorCode)
+	setString("sort", q.Sort)
+	setString("order", q.Order)
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Year > 0 {
+		query.Set("year", strconv.Itoa(q.Year))
+	}
+
+	return get[VehiclesResponse](ctx, c, "/v1/vehicles", query)
+}
+
+func (c *Client) GetVehicle(ctx context.Context, externalID string) (*Vehicle, error) {
+	result, err := get[VehicleResponse](ctx, c, "/v1/vehicle/"+url.PathEscape(externalID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Vehicle, nil
+}
+
+// RegisterVehicle registers an already minted vehicle owned by the wallet
+func (c *Client) RegisterVehicle(ctx context.Context, vin string, tokenID int64) (*Vehicle, error) {
+	payload := VehicleRegisterPayload{Vin: vin, TokenID: strconv.FormatInt(tokenID, 10)}
+	result, err := post[VehicleResponse](ctx, c, "/v1/vehicle/register", nil, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Vehicle, nil
+}
+
+func (c *Client) GetVerificationStatus(ctx context.Context, vins []string, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return c.getStatuses(ctx, "/v1/vehicle/verify", vins, opts)
+}
+
+// SubmitVerification decodes the VINs and checks vendor support, poll GetVerificationStatus for the result
+func (c *Client) SubmitVerification(ctx context.Context, vins []VinWithCountryCode, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return post[StatusForVinsResponse](ctx, c, "/v1/vehicle/verify", batchQuery(opts), SubmitVinVerificationParams{Vins: vins})
+}
+
+func (c *Client) GetMintStatus(ctx context.Context, vins []string, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return c.getStatuses(ctx, "/v1/vehicle/mint/status", vins, opts)
+}
+
+// GetMintData returns the EIP-712 typed data to sign for minting the verified VINs
+func (c *Client) GetMintData(ctx context.Context, vins []string, opts ...BatchOption) (*MintDataForVins, error) {
+	return get[MintDataForVins](ctx, c, "/v1/vehicle/mint", vinsQuery(vins, opts))
+}
+
+// SubmitMintData submits the signed typed data, and optionally a SACD grant for the minted vehicles
+func (c *Client) SubmitMintData(ctx context.Context, data MintDataForVins, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return post[StatusForVinsResponse](ctx, c, "/v1/vehicle/mint", batchQuery(opts), data)
+}
+
+func (c *Client) GetDisconnectStatus(ctx context.Context, vins []string, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return c.getStatuses(ctx, "/v1/vehicle/disconnect/status", vins, opts)
+}
+
+func (c *Client) GetDisconnectData(ctx context.Context, vins []string, opts ...BatchOption) (*DisconnectDataForVins, error) {
+	return get[DisconnectDataForVins](ctx, c, "/v1/vehicle/disconnect", vinsQuery(vins, opts))
+}
+
+func (c *Client) SubmitDisconnectData(ctx context.Context, data DisconnectDataForVins, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return post[StatusForVinsResponse](ctx, c, "/v1/vehicle/disconnect", batchQuery(opts), data)
+}
+
+func (c *Client) GetDeleteStatus(ctx context.Context, vins []string, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return c.getStatuses(ctx, "/v1/vehicle/delete/status", vins, opts)
+}
+
+func (c *Client) GetDeleteData(ctx context.Context, vins []string, opts ...BatchOption) (*DeleteDataForVins, error) {
+	return get[DeleteDataForVins](ctx, c, "/v1/vehicle/delete", vinsQuery(vins, opts))
+}
+
+func (c *Client) SubmitDeleteData(ctx context.Context, data DeleteDataForVins, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return post[StatusForVinsResponse](ctx, c, "/v1/vehicle/delete", batchQuery(opts), data)
+}
+
+func (c *Client) GetPauseStatus(ctx context.Context, vins []string, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return c.getStatuses(ctx, "/v1/vehicle/pause/status", vins, opts)
+}
+
+// Pause stops telemetry of minted VINs, vehicle and synthetic device stay minted
+func (c *Client) Pause(ctx context.Context, vins []string, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return post[StatusForVinsResponse](ctx, c, "/v1/vehicle/pause", batchQuery(opts), VinsParams{Vins: vins})
+}
+
+func (c *Client) Resume(ctx context.Context, vins []string, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return post[StatusForVinsResponse](ctx, c, "/v1/vehicle/resume", batchQuery(opts), VinsParams{Vins: vins})
+}
+
+func (c *Client) GetSacdStatus(ctx context.Context, vins []string, opts ...BatchOption) (*SacdStatusForVinsResponse, error) {
+	return get[SacdStatusForVinsResponse](ctx, c, "/v1/vehicle/sacd/status", vinsQuery(vins, opts))
+}
+
+// GetSacdData returns the user operations to sign for granting, extending or revoking SACD on minted vehicles
+func (c *Client) GetSacdData(ctx context.Context, vins []string, q SacdQuery, opts ...BatchOption) (*SacdDataForVins, error) {
+	query := vinsQuery(vins, opts)
+	if q.Grantee != nil {
+		query.Set("grantee", q.Grantee.Hex())
+		query.Set("permissions", strconv.FormatInt(q.Permissions, 10))
+		query.Set("expiration", strconv.FormatInt(q.Expiration, 10))
+		if q.Source != "" {
+			query.Set("source", q.Source)
+		}
+	}
+
+	return get[SacdDataForVins](ctx, c, "/v1/vehicle/sacd", query)
+}
+
+func (c *Client) SubmitSacdData(ctx context.Context, data SacdDataForVins, opts ...BatchOption) (*StatusForVinsResponse, error) {
+	return post[StatusForVinsResponse](ctx, c, "/v1/vehicle/sacd", batchQuery(opts), data)
+}
+
+// GetDrifts returns a page of mismatches between the oracle DB and on-chain state, newest first
+func (c *Client) GetDrifts(ctx context.Context, q DriftsQuery) (*DriftReportResponse, error) {
+	query := url.Values{}
+	if q.Resolved {
+		query.Set("resolved", "true")
+	}
+	if q.Cursor != "" {
+		query.Set("cursor", q.Cursor)
+	}
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	return get[DriftReportResponse](ctx, c, "/v1/reconciliation/drifts", query)
+}
+
+func (c *Client) getStatuses(ctx context.Context, path string, vins []string, opts []BatchOption) (*StatusForVinsResponse, error) {
+	return get[StatusForVinsResponse](ctx, c, path, vinsQuery(vins, opts))
+}